@@ -0,0 +1,46 @@
+// 命令 createadmin 创建管理员账号。Admin 不能通过公开的 POST /api/v1/register 注册，只能由运维在服务器上执行本命令创建。
+//
+// 用法：
+//
+//	ADMIN_PASSWORD=... go run ./cmd/createadmin -username admin [-config ./configs]
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"xquant-default-management/internal/config"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/database"
+	"xquant-default-management/internal/repository"
+	"xquant-default-management/internal/service"
+)
+
+func main() {
+	username := flag.String("username", "", "管理员用户名 (必填，至少 4 个字符)")
+	configPath := flag.String("config", "./configs", "配置文件所在目录")
+	flag.Parse()
+
+	// 密码从环境变量读取，避免出现在命令行历史和进程列表中
+	password := os.Getenv("ADMIN_PASSWORD")
+	if len(*username) < 4 || len(password) < 6 {
+		log.Println("需要 -username (至少 4 个字符) 和 ADMIN_PASSWORD 环境变量 (至少 6 个字符)")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// 1. 加载配置并连接数据库
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("无法加载配置: %v", err)
+	}
+	database.Connect(cfg)
+
+	// 2. 与注册接口使用同一套业务逻辑 (用户名查重、密码哈希)
+	userService := service.NewUserService(repository.NewUserRepository(database.DB), cfg)
+	user, err := userService.Register(*username, password, core.RoleAdmin)
+	if err != nil {
+		log.Fatalf("创建管理员失败: %v", err)
+	}
+	log.Printf("已创建管理员 %s (%s)", user.Username, user.ID)
+}
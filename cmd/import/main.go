@@ -0,0 +1,74 @@
+// 命令 import 从命令行批量导入客户 CSV，与 POST /api/v1/customers/import 使用同一套业务逻辑。
+//
+// 用法：
+//
+//	go run ./cmd/import -file customers.csv [-config ./configs] [-report report.json]
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"xquant-default-management/internal/config"
+	"xquant-default-management/internal/database"
+	"xquant-default-management/internal/repository"
+	"xquant-default-management/internal/service"
+)
+
+func main() {
+	filePath := flag.String("file", "", "要导入的 CSV 文件路径 (必填)")
+	configPath := flag.String("config", "./configs", "配置文件所在目录")
+	reportPath := flag.String("report", "", "将完整的逐行报告以 JSON 写入该文件 (可选)")
+	flag.Parse()
+
+	if *filePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// 1. 加载配置并连接数据库
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("无法加载配置: %v", err)
+	}
+	database.Connect(cfg)
+	db := database.DB
+
+	// 2. 组装 Service (只需要客户相关的依赖)
+	customerService := service.NewCustomerService(db, repository.NewCustomerRepository(db, repository.CustomerSearchOptions{Trigram: database.TrigramSearch}), repository.NewApplicationRepository(db), repository.NewDictionaryRepository(db))
+
+	// 3. 执行导入
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Fatalf("无法打开文件: %v", err)
+	}
+	defer file.Close()
+
+	report, err := customerService.ImportCustomers(file)
+	if err != nil {
+		log.Fatalf("导入失败: %v", err)
+	}
+
+	// 4. 输出报告：被拒绝的行逐条打印，完整报告可选地写入文件
+	for _, row := range report.Rows {
+		if row.Status == "rejected" {
+			log.Printf("第 %d 行 [%s] 被拒绝: %s", row.Row, row.Name, row.Reason)
+		}
+	}
+	log.Printf("导入完成：共 %d 行，新增 %d，更新 %d，拒绝 %d", report.Total, report.Created, report.Updated, report.Rejected)
+
+	if *reportPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatalf("无法生成报告: %v", err)
+		}
+		if err := os.WriteFile(*reportPath, data, 0o644); err != nil {
+			log.Fatalf("无法写入报告: %v", err)
+		}
+	}
+
+	if report.Rejected > 0 {
+		os.Exit(1)
+	}
+}
@@ -1,15 +1,18 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 	"xquant-default-management/internal/config"
 	"xquant-default-management/internal/database"
 	"xquant-default-management/internal/handler"
 	"xquant-default-management/internal/middleware"
 	"xquant-default-management/internal/repository"
 	"xquant-default-management/internal/service"
+	"xquant-default-management/internal/storage"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -71,18 +74,53 @@ func main() {
 	// Repositories 是最底层的组件，它们直接与数据库对话，是数据的“仓库管理员”。
 	// 它们只依赖于数据库连接 (db)，不依赖于任何其他业务组件，所以最先被创建。
 	userRepository := repository.NewUserRepository(db)
-	customerRepository := repository.NewCustomerRepository(db)
+	customerRepository := repository.NewCustomerRepository(db, repository.CustomerSearchOptions{Trigram: database.TrigramSearch}) // pg_trgm 不可用时只按 ILIKE 搜索
+	customerGroupRepository := repository.NewCustomerGroupRepository(db)
 	appRepository := repository.NewApplicationRepository(db)
 	statsRepository := repository.NewStatisticsRepository(db) // 新增：统计 Repository
+	ratingRepository := repository.NewRatingRepository(db)
+	dictionaryRepository := repository.NewDictionaryRepository(db)
+	exposureRepository := repository.NewExposureRepository(db)
+	customerMergeRepository := repository.NewCustomerMergeRepository(db)
+	attachmentRepository := repository.NewAttachmentRepository(db)
+	commentRepository := repository.NewCommentRepository(db)
+	routingRepository := repository.NewRoutingRepository(db)
+	escalationRepository := repository.NewEscalationRepository(db)
+	reasonRepository := repository.NewReasonRepository(db)
+	draftRepository := repository.NewDraftRepository(db)
+	idempotencyRepository := repository.NewIdempotencyRepository(db)
+
+	// 附件内容的存储后端 (本地目录或 S3 兼容的对象存储)，附件元数据仍然保存在数据库中
+	attachmentStorage, err := storage.New(cfg)
+	if err != nil {
+		log.Fatalf("无法初始化附件存储: %v", err)
+	}
 
 	// --- 业务逻辑层 (Services) ---
 	// Services 是应用的核心，负责编排业务流程，是决策的“项目经理”。
 	// 它们依赖于 Repositories 来获取和存储数据。
 	// 注意，userService 还需要 cfg 来读取 JWT 相关的配置（密钥和过期时间）。
 	userService := service.NewUserService(userRepository, cfg)
-	appService := service.NewApplicationService(db, appRepository, customerRepository)
+	appService := service.NewApplicationService(db, appRepository, customerRepository, routingRepository, reasonRepository, service.NewApprovalPolicy(cfg.ApprovalLevels))
 	queryService := service.NewQueryService(appRepository)
-	statsService := service.NewStatisticsService(statsRepository) // 新增：统计 Service
+	statsService := service.NewStatisticsService(statsRepository, dictionaryRepository, reasonRepository) // 新增：统计 Service
+	customerService := service.NewCustomerService(db, customerRepository, appRepository, dictionaryRepository)
+	customerGroupService := service.NewCustomerGroupService(db, customerGroupRepository, customerRepository)
+	ratingService := service.NewRatingService(db, ratingRepository, customerRepository)
+	dictionaryService := service.NewDictionaryService(dictionaryRepository)
+	reasonService := service.NewReasonService(reasonRepository)
+	draftService := service.NewDraftService(db, draftRepository)
+	exposureService := service.NewExposureService(exposureRepository, customerRepository)
+	customerMergeService := service.NewCustomerMergeService(db, customerMergeRepository)
+	assignmentService := service.NewAssignmentService(appRepository, routingRepository)
+	commentService := service.NewCommentService(db, appRepository, commentRepository, userRepository)
+	slaService := service.NewSLAService(escalationRepository, service.NewSLAPolicy(cfg.SLAHours))
+	idempotencyTTL := time.Duration(cfg.IdempotencyTTLHours) * time.Hour
+	if idempotencyTTL <= 0 {
+		idempotencyTTL = 24 * time.Hour
+	}
+	idempotencyService := service.NewIdempotencyService(idempotencyRepository, idempotencyTTL)
+	attachmentService := service.NewAttachmentService(appRepository, attachmentRepository, attachmentStorage, int64(cfg.AttachmentMaxSizeMB)<<20)
 
 	// --- API 接口层 (Handlers) ---
 	// Handlers 是最外层的组件，负责处理 HTTP 请求和响应，是应用的“前台接待”。
@@ -91,6 +129,27 @@ func main() {
 	appHandler := handler.NewApplicationHandler(appService)
 	queryHandler := handler.NewQueryHandler(queryService)
 	statsHandler := handler.NewStatisticsHandler(statsService) // 新增：统计 Handler
+	customerHandler := handler.NewCustomerHandler(customerService)
+	customerGroupHandler := handler.NewCustomerGroupHandler(customerGroupService)
+	ratingHandler := handler.NewRatingHandler(ratingService)
+	dictionaryHandler := handler.NewDictionaryHandler(dictionaryService)
+	reasonHandler := handler.NewReasonHandler(reasonService)
+	draftHandler := handler.NewDraftHandler(draftService)
+	exposureHandler := handler.NewExposureHandler(exposureService)
+	customerMergeHandler := handler.NewCustomerMergeHandler(customerMergeService)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService)
+	commentHandler := handler.NewCommentHandler(commentService)
+	assignmentHandler := handler.NewAssignmentHandler(assignmentService)
+	escalationHandler := handler.NewEscalationHandler(slaService)
+
+	// SLA 调度器在后台运行：为待审核的申请计算到期时间，并把超时的申请升级给管理员
+	slaInterval := time.Duration(cfg.SLACheckIntervalMinutes) * time.Minute
+	if slaInterval <= 0 {
+		slaInterval = 10 * time.Minute
+	}
+	go slaService.Run(context.Background(), slaInterval)
+	// 每小时清理一次过期的 Idempotency-Key
+	go idempotencyService.Run(context.Background(), time.Hour)
 
 	// =========================================================================
 	// 4. 初始化 Web 引擎和注册路由 (Routing)
@@ -164,26 +223,65 @@ func main() {
 				// 2. 针对此路由单独应用的 RBACMiddleware (保安 B - 授权)，确保用户角色是 'Applicant'。
 				// 中间件会按照定义的顺序依次执行。
 				applications.GET("", queryHandler.FindApplications)
+				// 状态机图 (Mermaid)，用于文档
+				applications.GET("/state-machine", appHandler.GetStateGraph)
 
-				applications.POST("", middleware.RBACMiddleware("Applicant"), appHandler.CreateApplication)
-				// 新增：Approver 查询待审批列表的端点
+				// 写操作支持 Idempotency-Key：前端在网络不稳定时重试，使用同一个 Key 的重试直接返回第一次的响应
+				idempotent := middleware.IdempotencyMiddleware(idempotencyService)
+				applications.POST("", middleware.RBACMiddleware("Applicant"), idempotent, appHandler.CreateApplication)
+				// 申请草稿：填写过程中自动保存，只有提交时才按新申请的规则校验并进入待审核
+				drafts := applications.Group("/drafts")
+				drafts.Use(middleware.RBACMiddleware("Applicant"))
+				{
+					drafts.GET("", draftHandler.ListDrafts)
+					drafts.POST("", draftHandler.CreateDraft)
+					drafts.GET("/:id", draftHandler.GetDraft)
+					drafts.PUT("/:id", draftHandler.UpdateDraft)
+					drafts.DELETE("/:id", draftHandler.DeleteDraft)
+					drafts.POST("/:id/submit", idempotent, draftHandler.SubmitDraft) // 提交会创建申请，重试时返回第一次的结果
+				}
+				// 申请人撤回自己提交的待审核申请或待审核重生
+				applications.POST("/withdraw", middleware.RBACMiddleware("Applicant"), idempotent, appHandler.WithdrawApplication)
+				// 申请人修改被退回的申请后重新提交，历轮内容可以逐轮比较
+				applications.POST("/resubmit", middleware.RBACMiddleware("Applicant"), idempotent, appHandler.ResubmitApplication)
+				// 申请详情：完整的生命周期记录，申请人只能查看自己提交或发起重生的申请
+				applications.GET("/:id", queryHandler.GetApplication)
+				// 历轮提交内容：可见范围与申请详情一致
+				applications.GET("/:id/rounds", appHandler.GetApplicationRounds)
+				// 证据附件：申请人上传，能看到申请的用户可以查看和下载
+				applications.POST("/:id/attachments", middleware.RBACMiddleware("Applicant"), attachmentHandler.UploadAttachment)
+				applications.GET("/:id/attachments", attachmentHandler.ListAttachments)
+				applications.GET("/:id/attachments/:attachment_id", attachmentHandler.DownloadAttachment)
+				// 讨论评论：能看到申请的用户都可以发表评论，只有作者可以编辑自己的评论
+				applications.GET("/:id/comments", commentHandler.ListComments)
+				applications.POST("/:id/comments", commentHandler.AddComment)
+				applications.PUT("/:id/comments/:comment_id", commentHandler.EditComment)
+				// 新增：Approver 查询待审批列表的端点，view=mine / unassigned 只看分派给自己的或尚未分派的申请
 				applications.GET("/pending", middleware.RBACMiddleware("Approver"), appHandler.GetPendingApplications)
+				// 管理员把申请改派给另一位审批人
+				applications.POST("/assign", middleware.RBACMiddleware("Admin"), assignmentHandler.AssignApplication)
 				// --- 新增审批路由 ---
 				// 将审批相关的路由分组到 /review 下，更符合 RESTful 风格
 				review := applications.Group("/review")
-				review.Use(middleware.RBACMiddleware("Approver")) // 只有 Approver 角色能访问
+				review.Use(middleware.RBACMiddleware("Approver"), idempotent) // 只有 Approver 角色能访问
 				{
 					review.POST("/approve", appHandler.ApproveApplication)
 					review.POST("/reject", appHandler.RejectApplication) // 新增
+					// 退回申请人补充材料
+					review.POST("/return", appHandler.ReturnApplication)
+					// 批量审核：逐个申请在各自的事务中批准或拒绝，返回每个申请的结果
+					review.POST("/batch", appHandler.BatchReview)
 
 				}
 				// 新增：重生相关路由
 				rebirth := applications.Group("/rebirth")
 				{
 					// 假设 Applicant 可以发起重生申请
-					rebirth.POST("/apply", middleware.RBACMiddleware("Applicant"), appHandler.ApplyForRebirth)
+					rebirth.POST("/apply", middleware.RBACMiddleware("Applicant"), idempotent, appHandler.ApplyForRebirth)
 					// Approver 批准重生申请
-					rebirth.POST("/approve", middleware.RBACMiddleware("Approver"), appHandler.ApproveRebirth)
+					rebirth.POST("/approve", middleware.RBACMiddleware("Approver"), idempotent, appHandler.ApproveRebirth)
+					// Approver 驳回重生申请，申请回到 Approved
+					rebirth.POST("/reject", middleware.RBACMiddleware("Approver"), idempotent, appHandler.RejectRebirth)
 				}
 				// --- 新增：统计路由 ---
 				// 将所有统计相关的端点都组织在这个分组下
@@ -194,15 +292,93 @@ func main() {
 					{
 						defaults.GET("/by-industry", statsHandler.GetDefaultsByIndustry)
 						defaults.GET("/by-region", statsHandler.GetDefaultsByRegion)
+						defaults.GET("/by-reason", statsHandler.GetDefaultsByReason)
 					}
 					// 重生统计
 					rebirths := statistics.Group("/rebirths")
 					{
 						rebirths.GET("/by-industry", statsHandler.GetRebirthsByIndustry)
 						rebirths.GET("/by-region", statsHandler.GetRebirthsByRegion)
+						rebirths.GET("/by-reason", statsHandler.GetRebirthsByReason)
 					}
 				}
 			}
+
+			// --- 客户主数据路由 ---
+			// 所有已登录用户都可以查询客户，但只有 Admin 角色可以维护客户主数据。
+			customers := protected.Group("/customers")
+			{
+				customers.GET("", customerHandler.ListCustomers)
+				customers.GET("/search", customerHandler.SearchCustomers)
+				// 重复客户检测与合并：会改写违约申请的归属，只有 Admin 可以操作
+				customers.GET("/duplicates", middleware.RBACMiddleware("Admin"), customerMergeHandler.FindDuplicates)
+				customers.GET("/merges", middleware.RBACMiddleware("Admin"), customerMergeHandler.ListMergeRecords)
+				customers.POST("/merge", middleware.RBACMiddleware("Admin"), customerMergeHandler.MergeCustomers)
+				customers.GET("/:id", customerHandler.GetCustomer)
+				customers.GET("/:id/timeline", customerHandler.GetCustomerTimeline)
+				customers.GET("/:id/aliases", customerHandler.GetCustomerAliases)
+				customers.POST("", middleware.RBACMiddleware("Admin"), customerHandler.CreateCustomer)
+				customers.POST("/import", middleware.RBACMiddleware("Admin"), customerHandler.ImportCustomers)
+				customers.PUT("/:id", middleware.RBACMiddleware("Admin"), customerHandler.UpdateCustomer)
+				customers.DELETE("/:id", middleware.RBACMiddleware("Admin"), customerHandler.DeleteCustomer)
+				// 外部评级历史：评级数据同样属于主数据，只有 Admin 可以写入
+				customers.GET("/:id/ratings", ratingHandler.GetRatingHistory)
+				customers.POST("/:id/ratings", middleware.RBACMiddleware("Admin"), ratingHandler.IngestRating)
+				customers.GET("/:id/exposures", exposureHandler.GetExposures)
+				customers.POST("/:id/exposures", middleware.RBACMiddleware("Admin"), exposureHandler.RecordExposure)
+			}
+
+			// --- 行业/区域参考数据字典路由 ---
+			dictionaries := protected.Group("/dictionaries")
+			{
+				dictionaries.GET("/:kind", dictionaryHandler.ListEntries)
+				dictionaries.POST("/:kind", middleware.RBACMiddleware("Admin"), dictionaryHandler.CreateEntry)
+				dictionaries.PUT("/:kind/:code", middleware.RBACMiddleware("Admin"), dictionaryHandler.UpdateEntry)
+				dictionaries.DELETE("/:kind/:code", middleware.RBACMiddleware("Admin"), dictionaryHandler.DeleteEntry)
+			}
+
+			// --- 违约原因/重生原因目录路由 ---
+			// 所有已登录用户都可以查询目录 (申请时选择原因)，只有 Admin 可以维护目录。
+			reasons := protected.Group("/reasons")
+			{
+				reasons.GET("/:kind", reasonHandler.ListReasons)
+				reasons.POST("/:kind", middleware.RBACMiddleware("Admin"), reasonHandler.CreateReason)
+				reasons.PUT("/:kind/:code", middleware.RBACMiddleware("Admin"), reasonHandler.UpdateReason)
+				reasons.DELETE("/:kind/:code", middleware.RBACMiddleware("Admin"), reasonHandler.DeleteReason)
+			}
+
+			// --- 评级映射表路由 ---
+			ratingScale := protected.Group("/rating-scale")
+			{
+				ratingScale.GET("", ratingHandler.ListScale)
+				ratingScale.PUT("", middleware.RBACMiddleware("Admin"), ratingHandler.UpsertScaleEntry)
+				ratingScale.DELETE("/:id", middleware.RBACMiddleware("Admin"), ratingHandler.DeleteScaleEntry)
+			}
+
+			// --- 审批人分派规则路由 ---
+			routingRules := protected.Group("/routing-rules")
+			routingRules.Use(middleware.RBACMiddleware("Admin"))
+			{
+				routingRules.GET("", assignmentHandler.ListRoutingRules)
+				routingRules.POST("", assignmentHandler.CreateRoutingRule)
+				routingRules.DELETE("/:id", assignmentHandler.DeleteRoutingRule)
+			}
+
+			// --- SLA 超时升级记录，升级的接收人是管理员 ---
+			protected.GET("/escalations", middleware.RBACMiddleware("Admin"), escalationHandler.ListEscalations)
+
+			// --- 关联集团路由 ---
+			// 与客户主数据相同：所有已登录用户可查询，只有 Admin 可以维护集团及其成员。
+			groups := protected.Group("/customer-groups")
+			{
+				groups.GET("", customerGroupHandler.ListGroups)
+				groups.GET("/:id", customerGroupHandler.GetGroup)
+				groups.POST("", middleware.RBACMiddleware("Admin"), customerGroupHandler.CreateGroup)
+				groups.PUT("/:id", middleware.RBACMiddleware("Admin"), customerGroupHandler.UpdateGroup)
+				groups.DELETE("/:id", middleware.RBACMiddleware("Admin"), customerGroupHandler.DeleteGroup)
+				groups.POST("/:id/members", middleware.RBACMiddleware("Admin"), customerGroupHandler.AddMember)
+				groups.DELETE("/:id/members/:customer_id", middleware.RBACMiddleware("Admin"), customerGroupHandler.RemoveMember)
+			}
 		}
 	}
 
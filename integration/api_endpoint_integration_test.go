@@ -3,11 +3,13 @@ package integration
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"xquant-default-management/internal/api"
 	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/database"
 	"xquant-default-management/internal/handler"
 	"xquant-default-management/internal/middleware"
 	"xquant-default-management/internal/repository"
@@ -34,17 +36,17 @@ func (s *ApiEndpointIntegrationSuite) SetupSuite() {
 	// Dependency Injection from main.go (simplified)
 	userRepo := repository.NewUserRepository(s.db)
 	appRepo := repository.NewApplicationRepository(s.db)
-	customerRepo := repository.NewCustomerRepository(s.db)
+	customerRepo := repository.NewCustomerRepository(s.db, repository.CustomerSearchOptions{Trigram: database.TrigramSearch})
 	statsRepo := repository.NewStatisticsRepository(s.db)
 
 	userService := service.NewUserService(userRepo, s.cfg)
-	appService := service.NewApplicationService(s.db, appRepo, customerRepo)
+	appService := service.NewApplicationService(s.db, appRepo, customerRepo, repository.NewRoutingRepository(s.db), repository.NewReasonRepository(s.db), service.NewApprovalPolicy(nil))
 	queryService := service.NewQueryService(appRepo)
-	statsService := service.NewStatisticsService(statsRepo)
+	statsService := service.NewStatisticsService(statsRepo, repository.NewDictionaryRepository(s.db), repository.NewReasonRepository(s.db))
 
 	userHandler := handler.NewUserHandler(userService)
 	appHandler := handler.NewApplicationHandler(appService)
-	_ = handler.NewQueryHandler(queryService)
+	queryHandler := handler.NewQueryHandler(queryService)
 	_ = handler.NewStatisticsHandler(statsService)
 
 	// Setup routes
@@ -60,6 +62,8 @@ func (s *ApiEndpointIntegrationSuite) SetupSuite() {
 			{
 				applications.POST("", middleware.RBACMiddleware("Applicant"), appHandler.CreateApplication)
 				applications.GET("/pending", middleware.RBACMiddleware("Approver"), appHandler.GetPendingApplications)
+				applications.GET("", queryHandler.FindApplications)
+				applications.GET("/:id", queryHandler.GetApplication)
 				review := applications.Group("/review")
 				review.Use(middleware.RBACMiddleware("Approver"))
 				{
@@ -136,6 +140,7 @@ func (s *ApiEndpointIntegrationSuite) TestFullApplicationE2EWorkflow() {
 	createAppReq := api.CreateApplicationRequest{
 		CustomerName: customer.Name,
 		Severity:     "High",
+		ReasonCode:   "PAYMENT_DEFAULT",
 		Reason:       "E2E Test Reason",
 	}
 	createAppJson, _ := json.Marshal(createAppReq)
@@ -168,19 +173,84 @@ func (s *ApiEndpointIntegrationSuite) TestFullApplicationE2EWorkflow() {
 	// Step 6: Approver approves the application
 	approveReq := api.ApproveRequest{ApplicationID: createdApp.ID}
 	approveJson, _ := json.Marshal(approveReq)
+	ifMatch := fmt.Sprintf(`"%d"`, pendingApps[0].Version)
 	req, _ = http.NewRequest(http.MethodPost, s.server.URL+"/api/v1/applications/review/approve", bytes.NewBuffer(approveJson))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+approverToken)
+	req.Header.Set("If-Match", ifMatch)
 	resp, err = http.DefaultClient.Do(req)
 	s.Require().NoError(err)
 	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Assert().Equal(fmt.Sprintf(`"%d"`, pendingApps[0].Version+1), resp.Header.Get("ETag"))
 	resp.Body.Close()
 
+	// Step 6b: Replaying the decision with the old ETag is rejected as stale
+	req, _ = http.NewRequest(http.MethodPost, s.server.URL+"/api/v1/applications/review/approve", bytes.NewBuffer(approveJson))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+approverToken)
+	req.Header.Set("If-Match", ifMatch)
+	resp, err = http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	s.Assert().Equal(http.StatusPreconditionFailed, resp.StatusCode)
+	var stale api.StaleApplicationResponse
+	json.NewDecoder(resp.Body).Decode(&stale)
+	resp.Body.Close()
+	s.Assert().Equal(string(core.StatusApproved), stale.Current.Status)
+
+	// Step 6c: The applicant reads the application detail with the customer and the approver
+	req, _ = http.NewRequest(http.MethodGet, s.server.URL+"/api/v1/applications/"+createdApp.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+applicantToken)
+	resp, err = http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Assert().Equal(fmt.Sprintf(`"%d"`, pendingApps[0].Version+1), resp.Header.Get("ETag"))
+	var detail api.ApplicationDetailResponse
+	json.NewDecoder(resp.Body).Decode(&detail)
+	resp.Body.Close()
+	s.Assert().Equal(customer.Industry, detail.Customer.Industry)
+	s.Assert().Equal(customer.Region, detail.Customer.Region)
+	s.Require().NotNil(detail.ApproverName)
+	s.Assert().Equal(approverUsername, *detail.ApproverName)
+
+	// Step 6d: Another applicant cannot see it
+	s.registerUser("e2e_other_applicant", password, "Applicant")
+	otherToken := s.loginUser("e2e_other_applicant", password)
+	req, _ = http.NewRequest(http.MethodGet, s.server.URL+"/api/v1/applications/"+createdApp.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+otherToken)
+	resp, err = http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	s.Assert().Equal(http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 6e: ...nor find it through the application list
+	req, _ = http.NewRequest(http.MethodGet, s.server.URL+"/api/v1/applications", nil)
+	req.Header.Set("Authorization", "Bearer "+otherToken)
+	resp, err = http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var otherList api.PaginatedApplicationsResponse
+	json.NewDecoder(resp.Body).Decode(&otherList)
+	resp.Body.Close()
+	s.Assert().Zero(otherList.Total)
+	s.Assert().Empty(otherList.Data)
+
+	// Step 6f: The applicant who filed it does find it in the list
+	req, _ = http.NewRequest(http.MethodGet, s.server.URL+"/api/v1/applications", nil)
+	req.Header.Set("Authorization", "Bearer "+applicantToken)
+	resp, err = http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var ownList api.PaginatedApplicationsResponse
+	json.NewDecoder(resp.Body).Decode(&ownList)
+	resp.Body.Close()
+	s.Require().Len(ownList.Data, 1)
+	s.Assert().Equal(createdApp.ID, ownList.Data[0].ID)
+
 	// Step 7: Verify application status
 	var finalApp core.DefaultApplication
 	err = s.db.First(&finalApp, "id = ?", createdApp.ID).Error
 	s.Require().NoError(err)
-	s.Assert().Equal("Approved", finalApp.Status)
+	s.Assert().Equal(core.StatusApproved, finalApp.Status)
 }
 
 // Helper functions to reduce code duplication
@@ -3,6 +3,7 @@ package integration
 import (
 	"log"
 	"os"
+	"strings"
 	"testing"
 	"xquant-default-management/internal/config"
 	"xquant-default-management/internal/core"
@@ -15,6 +16,16 @@ import (
 	"gorm.io/gorm"
 )
 
+// integrationModels 是测试库需要迁移的全部模型，与 database.Connect 中的 AutoMigrate 列表保持一致
+var integrationModels = []interface{}{&core.User{}, &core.Customer{}, &core.DefaultApplication{}, &core.CustomerGroup{}, &core.ExternalRating{}, &core.RatingScaleEntry{}, &core.DictionaryEntry{}, &core.Exposure{}, &core.CustomerAlias{}, &core.CustomerMergeRecord{}, &core.ApprovalStep{}, &core.RebirthRejection{}, &core.ApplicationRevision{}, &core.Attachment{}, &core.Comment{}, &core.CommentEdit{}, &core.RoutingRule{}, &core.EscalationEvent{}, &core.ReasonCatalogEntry{}, &core.ApplicationDraft{}, &core.IdempotencyRecord{}, &core.RebirthWithdrawal{}}
+
+// referenceTables 是测试之间保留的参考数据：database.Connect 写入的原因目录，以及评级映射和字典
+var referenceTables = map[string]bool{
+	"reason_catalog_entries": true,
+	"rating_scale_entries":   true,
+	"dictionary_entries":     true,
+}
+
 type ServiceRepoIntegrationSuite struct {
 	suite.Suite
 	db          *gorm.DB
@@ -36,7 +47,7 @@ func (s *ServiceRepoIntegrationSuite) SetupSuite() {
 	s.db = database.DB
 
 	// Auto-migrate the schema
-	err = s.db.AutoMigrate(&core.User{}, &core.Customer{}, &core.DefaultApplication{})
+	err = s.db.AutoMigrate(integrationModels...)
 	s.Require().NoError(err)
 
 	// Initialize real repositories and services
@@ -51,10 +62,18 @@ func (s *ServiceRepoIntegrationSuite) TearDownSuite() {
 }
 
 func (s *ServiceRepoIntegrationSuite) BeforeTest(suiteName, testName string) {
-	// Clean up the database before each test
-	s.db.Exec("DELETE FROM default_applications")
-	s.db.Exec("DELETE FROM customers")
-	s.db.Exec("DELETE FROM users")
+	// Clean up the database before each test.
+	// 审批记录、评论等子表通过外键引用申请和用户，逐表 DELETE 会违反外键约束，
+	// 因此一次 TRUNCATE 全部业务表并 CASCADE，参考数据 (referenceTables) 保留。
+	var tables []string
+	for _, model := range integrationModels {
+		stmt := &gorm.Statement{DB: s.db}
+		s.Require().NoError(stmt.Parse(model))
+		if !referenceTables[stmt.Schema.Table] {
+			tables = append(tables, stmt.Schema.Table)
+		}
+	}
+	s.Require().NoError(s.db.Exec("TRUNCATE " + strings.Join(tables, ", ") + " CASCADE").Error)
 }
 
 func TestServiceRepoIntegration(t *testing.T) {
@@ -15,6 +15,7 @@ type RegisterRequest struct {
 
 	// Role 是用户注册时指定的角色。
 	// 验证规则：必填 (required)，且值必须是 "Applicant" 或 "Approver" 两者之一 (oneof=Applicant Approver)。
+	// Admin 不能自助注册，只能通过 cmd/createadmin 命令创建。
 	Role string `json:"role" binding:"required,oneof=Applicant Approver"`
 }
 
@@ -47,17 +48,25 @@ type LoginResponse struct {
 
 // CreateApplicationRequest 代表创建违约申请时客户端需要发送的请求体。
 type CreateApplicationRequest struct {
-	// CustomerName 是申请所针对的客户名称。
-	// 验证规则：必填 (required)。
-	CustomerName string `json:"customer_name" binding:"required"`
+	// 以下三个字段用于指定申请所针对的客户，至少提供一个，优先级为 CustomerID > CreditCode > CustomerName。
+	// CustomerID 是客户 ID。
+	CustomerID string `json:"customer_id" binding:"omitempty,uuid"`
+	// CreditCode 是客户的 18 位统一社会信用代码。
+	CreditCode string `json:"credit_code" binding:"omitempty,len=18"`
+	// CustomerName 是客户名称，也可以是客户的曾用名。
+	// 验证规则：未提供 CustomerID 和 CreditCode 时必填。
+	CustomerName string `json:"customer_name" binding:"required_without_all=CustomerID CreditCode"`
 
 	// Severity 是违约事件的严重等级。
 	// 验证规则：必填 (required)，且值必须是 "High", "Medium", "Low" 三者之一。
 	Severity string `json:"severity" binding:"required,oneof=High Medium Low"`
 
-	// Reason 是提交违约申请的主要原因。
-	// 验证规则：必填 (required)。
-	Reason string `json:"reason" binding:"required"`
+	// ReasonCode 是违约原因在原因目录中的编码 (见 GET /reasons/default)。
+	// 验证规则：必填 (required)，且必须是目录中当前生效的原因。
+	ReasonCode string `json:"reason_code" binding:"required,max=50"`
+
+	// Reason 是对违约原因的补充说明 (可选字段)，省略时使用目录中的原因名称。
+	Reason string `json:"reason"`
 
 	// Remarks 是申请的附加备注信息 (可选字段)。
 	Remarks string `json:"remarks"`
@@ -76,13 +85,31 @@ type ApplicationResponse struct {
 	// ApplicantID 是提交此申请的用户的 ID。
 	// ApplicantID string `json:"applicant_id"`
 	ApplicantName string `json:"applicant_name"` // 新增
+	// AssigneeName 是负责审核该申请的审批人，尚未分派时为空。
+	AssigneeName string `json:"assignee_name,omitempty"`
 	// ApplicationTime 是申请被提交的时间。
 	ApplicationTime time.Time `json:"application_time"`
+	// DueAt 是当前审核环节的 SLA 到期时间，Overdue 表示已经超时。
+	DueAt   *time.Time `json:"due_at,omitempty"`
+	Overdue bool       `json:"overdue"`
+	// Version 申请的乐观锁版本号 (即 ETag)，审核、重生等操作需要在 If-Match 请求头中带上
+	Version int `json:"version"`
 }
 
 // ApproveRequest 代表审核操作的请求体
 type ApproveRequest struct {
 	ApplicationID string `json:"application_id" binding:"required,uuid"`
+	// PropagateToGroup 为 true 时，为客户所在关联集团的其他成员自动发起关联违约申请 (可选)。
+	PropagateToGroup bool `json:"propagate_to_group"`
+}
+
+// ApprovalProgressResponse 是多级审批尚未完成时的响应体。
+type ApprovalProgressResponse struct {
+	Message string `json:"message"`
+	// Approvals 当前环节已完成的审批级数。
+	Approvals int `json:"approvals"`
+	// RequiredApprovals 按严重等级需要的审批人数量。
+	RequiredApprovals int `json:"required_approvals"`
 }
 
 // RejectRequest 代表拒绝操作的请求体
@@ -91,11 +118,59 @@ type RejectRequest struct {
 	RejectionReason string `json:"rejection_reason" binding:"required"`
 }
 
+// BatchReviewRequest 代表批量审核的请求体，一次最多 100 个申请
+type BatchReviewRequest struct {
+	Items []BatchReviewItem `json:"items" binding:"required,min=1,max=100,dive"`
+}
+
+// BatchReviewItem 是批量审核中对一个申请的决定
+type BatchReviewItem struct {
+	ApplicationID string `json:"application_id" binding:"required,uuid"`
+	// Decision 审核决定：approve (批准) 或 reject (拒绝)
+	Decision string `json:"decision" binding:"required,oneof=approve reject"`
+	// Reason 拒绝原因，reject 时必填
+	Reason string `json:"reason" binding:"required_if=Decision reject"`
+	// PropagateToGroup 仅用于 approve：是否向客户所在关联集团的其他成员传导违约
+	PropagateToGroup bool `json:"propagate_to_group"`
+	// Version 审批人看到的申请版本号，作用与单个审核接口的 If-Match 相同：申请已被修改时该项失败
+	Version int `json:"version" binding:"required,min=1"`
+}
+
+// BatchReviewResponse 是批量审核的响应体，Results 与请求的 Items 一一对应
+type BatchReviewResponse struct {
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []BatchReviewResult `json:"results"`
+}
+
+// BatchReviewResult 是批量审核中一个申请的处理结果
+type BatchReviewResult struct {
+	ApplicationID string `json:"application_id"`
+	Decision      string `json:"decision"`
+	Success       bool   `json:"success"`
+	// Status 处理后的申请状态。多级审批尚未完成时仍为 Pending，Approvals / RequiredApprovals 给出审批进度
+	Status            string `json:"status,omitempty"`
+	Approvals         int    `json:"approvals,omitempty"`
+	RequiredApprovals int    `json:"required_approvals,omitempty"`
+	// ErrorCode / Error 失败的类型和原因。ErrorCode 取值：not_found、invalid_transition、forbidden、
+	// self_approval、duplicate_approver、invalid_reason、precondition_failed、internal_error
+	ErrorCode string `json:"error_code,omitempty"`
+	Error     string `json:"error,omitempty"`
+	// Version 成功时为申请的新版本号，precondition_failed 时为申请的当前版本号
+	Version int `json:"version,omitempty"`
+}
+
+// StaleApplicationResponse 是 If-Match 版本过期 (412) 时的响应体，Current 是申请的当前状态
+type StaleApplicationResponse struct {
+	Error   string              `json:"error"`
+	Current ApplicationResponse `json:"current"`
+}
+
 // RebirthApplyRequest 代表发起重生申请的请求体
 type RebirthApplyRequest struct {
 	ApplicationID string `json:"application_id" binding:"required,uuid"`
-	// 使用 oneof 验证，确保重生原因来自预设列表
-	RebirthReason string `json:"rebirth_reason" binding:"required,oneof=正常结算后解除 '在其他金融机构违约解除，或外部评级显示为非违约级别' 计提比例小于设置界限 '连续 12 个月内按时支付本金和利息' '客户的还款意愿和还款能力明显好转，已偿付各项逾期本金、逾期利息和其他费用（包括罚息等），且连续 12 个月内按时支付本金、利息' '导致违约的关联集团内其他发生违约的客户已经违约重生，解除关联成员的违约设定'"`
+	// ReasonCode 重生原因在原因目录中的编码 (见 GET /reasons/rebirth)，必须是目录中当前生效的原因
+	ReasonCode string `json:"reason_code" binding:"required,max=50"`
 }
 
 // RebirthApproveRequest 代表批准重生申请的请求体
@@ -103,6 +178,102 @@ type RebirthApproveRequest struct {
 	ApplicationID string `json:"application_id" binding:"required,uuid"`
 }
 
+// RebirthRejectRequest 代表驳回重生申请的请求体
+type RebirthRejectRequest struct {
+	ApplicationID   string `json:"application_id" binding:"required,uuid"`
+	RejectionReason string `json:"rejection_reason" binding:"required"`
+}
+
+// RebirthRejectionResponse 代表一次被驳回的重生申请
+type RebirthRejectionResponse struct {
+	RebirthReason    string     `json:"rebirth_reason"`
+	RebirthAppliedAt *time.Time `json:"rebirth_applied_at,omitempty"`
+	RejectedBy       string     `json:"rejected_by,omitempty"`
+	RejectedAt       time.Time  `json:"rejected_at"`
+	RejectionReason  string     `json:"rejection_reason"`
+	// RebirthReasonCode 被驳回的重生原因在原因目录中的编码
+	RebirthReasonCode string `json:"rebirth_reason_code,omitempty"`
+}
+
+// RebirthWithdrawalResponse 代表一次被撤回的重生申请
+type RebirthWithdrawalResponse struct {
+	RebirthReason     string     `json:"rebirth_reason"`
+	RebirthReasonCode string     `json:"rebirth_reason_code,omitempty"`
+	RebirthAppliedAt  *time.Time `json:"rebirth_applied_at,omitempty"`
+	WithdrawnBy       string     `json:"withdrawn_by,omitempty"`
+	WithdrawnAt       time.Time  `json:"withdrawn_at"`
+	WithdrawalReason  string     `json:"withdrawal_reason,omitempty"`
+}
+
+// ReturnRequest 代表审批人退回申请、要求补充材料的请求体
+type ReturnRequest struct {
+	ApplicationID string `json:"application_id" binding:"required,uuid"`
+	Questions     string `json:"questions" binding:"required"`
+}
+
+// ResubmitRequest 代表申请人修改被退回的申请后重新提交的请求体，省略的字段保持不变。
+type ResubmitRequest struct {
+	ApplicationID string  `json:"application_id" binding:"required,uuid"`
+	Severity      *string `json:"severity" binding:"omitempty,oneof=High Medium Low"`
+	ReasonCode    *string `json:"reason_code" binding:"omitempty,min=1,max=50"`
+	Reason        *string `json:"reason" binding:"omitempty,min=1"`
+	Remarks       *string `json:"remarks"`
+}
+
+// ApplicationRoundResponse 代表申请某一轮提交的内容
+type ApplicationRoundResponse struct {
+	Round         int       `json:"round"`
+	Severity      string    `json:"severity"`
+	ReasonCode    string    `json:"reason_code,omitempty"`
+	DefaultReason string    `json:"default_reason"`
+	Remarks       string    `json:"remarks,omitempty"`
+	SubmittedAt   time.Time `json:"submitted_at"`
+	// Questions 本轮被退回时审批人提出的问题
+	Questions  string     `json:"questions,omitempty"`
+	ReturnedBy string     `json:"returned_by,omitempty"`
+	ReturnedAt *time.Time `json:"returned_at,omitempty"`
+	// Changes 与上一轮相比修改的字段
+	Changes []FieldChangeResponse `json:"changes,omitempty"`
+}
+
+// FieldChangeResponse 代表相邻两轮之间一个字段的修改
+type FieldChangeResponse struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// AssignRequest 代表管理员改派申请的请求体
+type AssignRequest struct {
+	ApplicationID string `json:"application_id" binding:"required,uuid"`
+	AssigneeID    string `json:"assignee_id" binding:"required,uuid"`
+}
+
+// RoutingRuleRequest 代表新增审批人分派规则的请求体。区域和行业至少填写一项。
+type RoutingRuleRequest struct {
+	// Priority 越小越优先匹配，同一优先级匹配到多位审批人时按工作量分派。
+	Priority   int    `json:"priority" binding:"gte=0"`
+	Region     string `json:"region" binding:"required_without=Industry"`
+	Industry   string `json:"industry" binding:"required_without=Region"`
+	ApproverID string `json:"approver_id" binding:"required,uuid"`
+}
+
+// RoutingRuleResponse 代表一条审批人分派规则
+type RoutingRuleResponse struct {
+	ID           string `json:"id"`
+	Priority     int    `json:"priority"`
+	Region       string `json:"region,omitempty"`
+	Industry     string `json:"industry,omitempty"`
+	ApproverID   string `json:"approver_id"`
+	ApproverName string `json:"approver_name,omitempty"`
+}
+
+// WithdrawRequest 代表申请人撤回待审核申请 (或待审核重生) 的请求体
+type WithdrawRequest struct {
+	ApplicationID string `json:"application_id" binding:"required,uuid"`
+	Reason        string `json:"reason" binding:"required,max=500"`
+}
+
 // ApplicationDetailResponse 是一个更详细的响应 DTO，满足查询需求
 type ApplicationDetailResponse struct {
 	ID              string     `json:"id"`
@@ -111,11 +282,77 @@ type ApplicationDetailResponse struct {
 	Status          string     `json:"status"`
 	DefaultReason   string     `json:"default_reason"`
 	Severity        string     `json:"severity"`
+	Round           int        `json:"round"`
 	ApplicantName   string     `json:"applicant_name,omitempty"`
 	ApplicationTime time.Time  `json:"application_time"`
 	ApproverName    *string    `json:"approver_name,omitempty"`
 	ApprovalTime    *time.Time `json:"approval_time,omitempty"`
 	RebirthReason   string     `json:"rebirth_reason,omitempty"`
+	// WithdrawnAt / WithdrawalReason 申请人撤回申请的时间和原因 (撤回重生见 RebirthWithdrawals)
+	WithdrawnAt      *time.Time `json:"withdrawn_at,omitempty"`
+	WithdrawalReason string     `json:"withdrawal_reason,omitempty"`
+	// RebirthRejections 是被驳回的历次重生申请 (从早到晚)
+	RebirthRejections []RebirthRejectionResponse `json:"rebirth_rejections,omitempty"`
+	// RatingHistory 是客户的外部评级轨迹 (从新到旧)，供审批人参考评级变化趋势
+	RatingHistory []RatingResponse `json:"rating_history,omitempty"`
+	// Exposure 是违约认定批准时的敞口快照，未批准或客户没有敞口记录时为空
+	Exposure *ExposureSnapshot `json:"exposure,omitempty"`
+	// AssigneeName / AssignedAt 负责审核该申请的审批人和分派时间
+	AssigneeName string     `json:"assignee_name,omitempty"`
+	AssignedAt   *time.Time `json:"assigned_at,omitempty"`
+	// DueAt / Overdue 当前审核环节的 SLA 到期时间和是否已超时，EscalatedAt 超时后升级给管理员的时间
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	Overdue     bool       `json:"overdue"`
+	EscalatedAt *time.Time `json:"escalated_at,omitempty"`
+	// Comments 是申请下的讨论评论 (从早到晚)，只在申请详情中返回
+	Comments []CommentResponse `json:"comments,omitempty"`
+	// DefaultReasonCode / RebirthReasonCode 违约原因、重生原因在原因目录中的编码
+	DefaultReasonCode string `json:"default_reason_code,omitempty"`
+	RebirthReasonCode string `json:"rebirth_reason_code,omitempty"`
+	// Version 申请的乐观锁版本号 (即 ETag)，审核、重生等操作需要在 If-Match 请求头中带上
+	Version int `json:"version"`
+
+	// Customer 申请关联的客户，包括行业、区域和统一社会信用代码
+	Customer CustomerResponse `json:"customer"`
+	// CreatedAt / UpdatedAt 申请记录的创建和最后修改时间，RoundSubmittedAt 当前轮次的提交时间 (第一轮为空)
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	RoundSubmittedAt *time.Time `json:"round_submitted_at,omitempty"`
+	// RejectionReason 审批人拒绝申请的原因，Remarks 申请人填写的备注
+	RejectionReason string `json:"rejection_reason,omitempty"`
+	Remarks         string `json:"remarks,omitempty"`
+	// AssignedByName 改派申请的管理员，自动分派时为空
+	AssignedByName string `json:"assigned_by_name,omitempty"`
+	// RebirthApplicantName / RebirthAppliedAt 发起重生的用户和时间，RebirthApproverName / RebirthApprovalTime 批准重生的审批人和时间
+	RebirthApplicantName string     `json:"rebirth_applicant_name,omitempty"`
+	RebirthAppliedAt     *time.Time `json:"rebirth_applied_at,omitempty"`
+	RebirthApproverName  *string    `json:"rebirth_approver_name,omitempty"`
+	RebirthApprovalTime  *time.Time `json:"rebirth_approval_time,omitempty"`
+	// WithdrawnByName 撤回申请的用户
+	WithdrawnByName string `json:"withdrawn_by_name,omitempty"`
+	// TriggerApplicationID 集团传导时触发本申请的申请 ID
+	TriggerApplicationID string `json:"trigger_application_id,omitempty"`
+	// RebirthWithdrawals 是被发起人撤回的历次重生申请 (从早到晚)
+	RebirthWithdrawals []RebirthWithdrawalResponse `json:"rebirth_withdrawals,omitempty"`
+	// ApprovalSteps 多级审批中逐级的审批记录 (按环节、轮次、级别排序)，只在申请详情中返回
+	ApprovalSteps []ApprovalStepResponse `json:"approval_steps,omitempty"`
+}
+
+// ApprovalStepResponse 是多级审批中的一级审批记录
+type ApprovalStepResponse struct {
+	Stage        string    `json:"stage"` // Approve (违约认定) 或 ApproveRebirth (重生)
+	Round        int       `json:"round"`
+	Level        int       `json:"level"`
+	ApproverName string    `json:"approver_name"`
+	ApprovedAt   time.Time `json:"approved_at"`
+}
+
+// ExposureSnapshot 是申请上记录的敞口快照
+type ExposureSnapshot struct {
+	Principal float64    `json:"principal"`
+	Interest  float64    `json:"interest"`
+	Currency  string     `json:"currency"`
+	AsOfDate  *time.Time `json:"as_of_date,omitempty"`
 }
 
 // PaginatedApplicationsResponse 是包含分页信息的响应体
@@ -127,10 +364,333 @@ type PaginatedApplicationsResponse struct {
 
 // StatisticsResponse 是用于统计 API 的标准响应体
 type StatisticsResponse struct {
-	Dimension  string   `json:"dimension"`             // 维度名称 (例如 "Technology")
-	Count      int64    `json:"count"`                 // 计数
-	Percentage float64  `json:"percentage"`            // 占比 (例如 0.25 代表 25%)
-	GrowthRate *float64 `json:"growth_rate,omitempty"` // 同比增长率 (指针以表示可能无法计算)
+	Dimension     string   `json:"dimension"`                // 维度编码 (例如行业编码 "C39")
+	DimensionName string   `json:"dimension_name,omitempty"` // 维度在字典中的名称
+	Count         int64    `json:"count"`                    // 计数
+	Percentage    float64  `json:"percentage"`               // 占比 (例如 0.25 代表 25%)
+	GrowthRate    *float64 `json:"growth_rate,omitempty"`    // 同比增长率 (指针以表示可能无法计算)
+
+	// 以下为按敞口金额 (本金 + 利息) 加权的统计，只计入指定币种的敞口快照
+	Currency         string   `json:"currency"`                     // 金额统计的币种
+	Amount           float64  `json:"amount"`                       // 敞口金额合计
+	AmountPercentage float64  `json:"amount_percentage"`            // 金额占比
+	AmountGrowthRate *float64 `json:"amount_growth_rate,omitempty"` // 金额同比增长率
+}
+
+// CreateCustomerRequest 代表创建客户时客户端需要发送的请求体。
+type CreateCustomerRequest struct {
+	// Name 是客户名称，系统内唯一。
+	// 验证规则：必填 (required)，最大长度 255。
+	Name string `json:"name" binding:"required,max=255"`
+	// CreditCode 是 18 位统一社会信用代码 (可选)，系统内唯一，会校验校验位。
+	CreditCode string `json:"credit_code" binding:"omitempty,len=18"`
+	// Industry 是客户所属行业，必须是行业字典中的编码。
+	Industry string `json:"industry" binding:"max=100"`
+	// Region 是客户所属区域，必须是区域字典中的编码。
+	Region string `json:"region" binding:"max=100"`
+	// 最新外部评级 (LatestExtGrade) 不能在这里指定，只能通过录入评级 (POST /customers/{id}/ratings) 派生。
+}
+
+// UpdateCustomerRequest 代表更新客户时的请求体。
+// 所有字段都使用指针，nil 表示不修改该字段。
+type UpdateCustomerRequest struct {
+	Name       *string `json:"name" binding:"omitempty,min=1,max=255"`
+	CreditCode *string `json:"credit_code" binding:"omitempty,max=18"` // 空字符串表示清除
+	Industry   *string `json:"industry" binding:"omitempty,max=100"`
+	Region     *string `json:"region" binding:"omitempty,max=100"`
+}
+
+// CustomerResponse 代表返回给客户端的客户信息。
+type CustomerResponse struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	CreditCode     string    `json:"credit_code,omitempty"`
+	Industry       string    `json:"industry"`
+	Region         string    `json:"region"`
+	IsDefault      bool      `json:"is_default"`
+	LatestExtGrade string    `json:"latest_ext_grade,omitempty"`
+	GroupID        string    `json:"group_id,omitempty"` // 所属关联集团 ID
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// CustomerSearchResult 是客户搜索的一条结果
+type CustomerSearchResult struct {
+	ID                    string  `json:"id"`
+	Name                  string  `json:"name"`
+	CreditCode            string  `json:"credit_code,omitempty"`
+	Industry              string  `json:"industry"`
+	Region                string  `json:"region"`
+	IsDefault             bool    `json:"is_default"`              // 违约状态标记
+	HasPendingApplication bool    `json:"has_pending_application"` // 已有待处理申请的客户无法再次提交
+	MatchType             string  `json:"match_type"`              // exact / prefix / substring / fuzzy
+	Score                 float64 `json:"score"`                   // 名称相似度 (0 ~ 1)
+}
+
+// CustomerAliasResponse 代表客户的一条曾用名。
+type CustomerAliasResponse struct {
+	Name       string    `json:"name"`
+	ValidUntil time.Time `json:"valid_until"`
+}
+
+// PaginatedCustomersResponse 是包含分页信息的客户列表响应体
+type PaginatedCustomersResponse struct {
+	Total int64              `json:"total"`
+	Page  int                `json:"page"`
+	Data  []CustomerResponse `json:"data"`
+}
+
+// DuplicateCustomerPair 代表重复客户检测报告中的一对疑似重复客户。
+type DuplicateCustomerPair struct {
+	Customer  CustomerResponse `json:"customer"`
+	Duplicate CustomerResponse `json:"duplicate"`
+	// Score 是规范化名称的相似度 (0 ~ 1)，越大越可能是同一客户。
+	Score float64 `json:"score"`
+}
+
+// MergeCustomersRequest 代表合并重复客户时的请求体。
+type MergeCustomersRequest struct {
+	// SurvivorID 是合并后保留的客户 ID。
+	SurvivorID string `json:"survivor_id" binding:"required,uuid"`
+	// LoserID 是被合并 (合并后软删除) 的客户 ID。
+	LoserID string `json:"loser_id" binding:"required,uuid,nefield=SurvivorID"`
+	Reason  string `json:"reason" binding:"max=500"`
+}
+
+// CustomerMergeRecordResponse 代表一条客户合并审计记录。
+type CustomerMergeRecordResponse struct {
+	ID                 string    `json:"id"`
+	SurvivorID         string    `json:"survivor_id"`
+	LoserID            string    `json:"loser_id"`
+	LoserName          string    `json:"loser_name"`
+	LoserCreditCode    string    `json:"loser_credit_code,omitempty"`
+	MovedApplications  int64     `json:"moved_applications"`
+	SurvivorWasDefault bool      `json:"survivor_was_default"`
+	SurvivorIsDefault  bool      `json:"survivor_is_default"`
+	Reason             string    `json:"reason,omitempty"`
+	OperatorName       string    `json:"operator_name,omitempty"`
+	MergedAt           time.Time `json:"merged_at"`
+}
+
+// CreateCustomerGroupRequest 代表创建关联集团时的请求体。
+type CreateCustomerGroupRequest struct {
+	// Name 是集团名称，系统内唯一。
+	Name string `json:"name" binding:"required,max=255"`
+	// ParentCustomerID 是集团母公司对应的客户 ID (可选)，母公司会自动成为集团成员。
+	ParentCustomerID string `json:"parent_customer_id" binding:"omitempty,uuid"`
+}
+
+// UpdateCustomerGroupRequest 代表更新关联集团时的请求体，nil 表示不修改该字段。
+type UpdateCustomerGroupRequest struct {
+	Name             *string `json:"name" binding:"omitempty,min=1,max=255"`
+	ParentCustomerID *string `json:"parent_customer_id" binding:"omitempty,uuid"`
+}
+
+// AddGroupMemberRequest 代表向关联集团添加成员的请求体。
+type AddGroupMemberRequest struct {
+	CustomerID string `json:"customer_id" binding:"required,uuid"`
+}
+
+// CustomerGroupResponse 代表返回给客户端的关联集团信息。
+type CustomerGroupResponse struct {
+	ID                 string             `json:"id"`
+	Name               string             `json:"name"`
+	ParentCustomerID   string             `json:"parent_customer_id,omitempty"`
+	ParentCustomerName string             `json:"parent_customer_name,omitempty"`
+	Members            []CustomerResponse `json:"members"`
+}
+
+// IngestRatingRequest 代表写入一条外部评级时的请求体。
+type IngestRatingRequest struct {
+	// Agency 是评级机构。
+	Agency string `json:"agency" binding:"required,max=100"`
+	// Grade 是评级结果，必须在评级映射表中有定义。
+	Grade string `json:"grade" binding:"required,max=50"`
+	// EffectiveDate 是评级生效日期，格式为 YYYY-MM-DD。
+	EffectiveDate string `json:"effective_date" binding:"required,datetime=2006-01-02"`
+	// Source 是数据来源 (可选)。
+	Source string `json:"source" binding:"max=255"`
+}
+
+// RatingResponse 代表一条外部评级历史记录。
+type RatingResponse struct {
+	ID             string    `json:"id"`
+	Agency         string    `json:"agency"`
+	Grade          string    `json:"grade"`
+	Ordinal        int       `json:"ordinal"`
+	IsDefaultGrade bool      `json:"is_default_grade"`
+	EffectiveDate  time.Time `json:"effective_date"`
+	Source         string    `json:"source,omitempty"`
+}
+
+// RatingScaleEntryRequest 代表新增或调整评级映射项时的请求体。
+type RatingScaleEntryRequest struct {
+	Agency string `json:"agency" binding:"required,max=100"`
+	Grade  string `json:"grade" binding:"required,max=50"`
+	// Ordinal 是统一的评级序数，数值越大信用越差。
+	Ordinal int `json:"ordinal" binding:"min=0"`
+	// IsDefault 表示该评级是否属于违约级别。
+	IsDefault bool `json:"is_default"`
+}
+
+// RatingScaleEntryResponse 代表评级映射表中的一项。
+type RatingScaleEntryResponse struct {
+	ID        string `json:"id"`
+	Agency    string `json:"agency"`
+	Grade     string `json:"grade"`
+	Ordinal   int    `json:"ordinal"`
+	IsDefault bool   `json:"is_default"`
+}
+
+// CustomerImportRowResult 记录批量导入时单行数据的处理结果
+type CustomerImportRowResult struct {
+	Row    int    `json:"row"`              // CSV 中的行号 (表头为第 1 行)
+	Name   string `json:"name"`             // 该行的客户名称
+	Status string `json:"status"`           // created / updated / rejected
+	Reason string `json:"reason,omitempty"` // 被拒绝时的原因
+}
+
+// CustomerImportReport 是批量导入客户的校验报告
+type CustomerImportReport struct {
+	Total    int                       `json:"total"`
+	Created  int                       `json:"created"`
+	Updated  int                       `json:"updated"`
+	Rejected int                       `json:"rejected"`
+	Rows     []CustomerImportRowResult `json:"rows"`
+}
+
+// CustomerTimelinePeriod 是客户违约状态时间线上的一个区间。
+// Type 为 Default 时区间从违约认定批准开始，到重生批准结束；
+// Type 为 Reborn 时区间从重生批准开始，到下一次违约认定批准结束。End 为空表示区间仍在持续。
+type CustomerTimelinePeriod struct {
+	Type          string     `json:"type"` // Default / Reborn
+	Start         time.Time  `json:"start"`
+	End           *time.Time `json:"end,omitempty"`
+	ApplicationID string     `json:"application_id"`
+	Severity      string     `json:"severity"`
+	ApproverName  string     `json:"approver_name,omitempty"`
+	DefaultReason string     `json:"default_reason,omitempty"`
+	RebirthReason string     `json:"rebirth_reason,omitempty"`
+}
+
+// CustomerTimelineResponse 是客户违约状态时间线的响应体
+type CustomerTimelineResponse struct {
+	CustomerID   string                   `json:"customer_id"`
+	CustomerName string                   `json:"customer_name"`
+	IsDefault    bool                     `json:"is_default"`
+	Periods      []CustomerTimelinePeriod `json:"periods"`
+	// InDefaultOn 仅在请求带 date 参数时返回，表示客户在该日期内是否处于违约状态。
+	InDefaultOn *bool `json:"in_default_on,omitempty"`
+}
+
+// DictionaryEntryRequest 代表新增字典条目时的请求体。
+type DictionaryEntryRequest struct {
+	Code string `json:"code" binding:"required,max=50"`
+	Name string `json:"name" binding:"required,max=100"`
+	// ParentCode 上级条目编码 (可选)，为空表示顶层条目。
+	ParentCode string `json:"parent_code" binding:"max=50"`
+}
+
+// UpdateDictionaryEntryRequest 代表修改字典条目名称时的请求体。
+type UpdateDictionaryEntryRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// DictionaryEntryResponse 代表返回给客户端的字典条目。
+type DictionaryEntryResponse struct {
+	Kind       string `json:"kind"`
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	ParentCode string `json:"parent_code,omitempty"`
+	Level      int    `json:"level"`
+}
+
+// ReasonRequest 代表新增违约原因或重生原因目录条目时的请求体。
+type ReasonRequest struct {
+	Code string `json:"code" binding:"required,max=50"`
+	UpdateReasonRequest
+}
+
+// UpdateReasonRequest 代表修改原因目录条目时的请求体。
+type UpdateReasonRequest struct {
+	LabelZh string `json:"label_zh" binding:"required,max=500"`
+	LabelEn string `json:"label_en" binding:"max=500"`
+	// ValidFrom / ValidTo 生效期间 (可选)，为空表示不限。停用一个原因时设置 ValidTo。
+	ValidFrom *time.Time `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to"`
+	// EvidenceHint 提示申请人需要上传的证据材料 (可选)。
+	EvidenceHint string `json:"evidence_hint" binding:"max=500"`
+}
+
+// ReasonResponse 代表返回给客户端的原因目录条目。
+type ReasonResponse struct {
+	Kind         string     `json:"kind"`
+	Code         string     `json:"code"`
+	LabelZh      string     `json:"label_zh"`
+	LabelEn      string     `json:"label_en,omitempty"`
+	ValidFrom    *time.Time `json:"valid_from,omitempty"`
+	ValidTo      *time.Time `json:"valid_to,omitempty"`
+	EvidenceHint string     `json:"evidence_hint,omitempty"`
+	// Active 当前是否处于生效期间，只有生效的原因可以用于新的申请
+	Active bool `json:"active"`
+}
+
+// RecordExposureRequest 代表录入一条客户敞口记录时的请求体。
+type RecordExposureRequest struct {
+	// Principal 是未偿本金。
+	Principal float64 `json:"principal" binding:"gte=0"`
+	// Interest 是应收未收利息 (可选)。
+	Interest float64 `json:"interest" binding:"gte=0"`
+	// Currency 是 ISO 4217 币种代码，例如 CNY。
+	Currency string `json:"currency" binding:"required,len=3,uppercase"`
+	// AsOfDate 是敞口统计时点，格式为 YYYY-MM-DD。
+	AsOfDate string `json:"as_of_date" binding:"required,datetime=2006-01-02"`
+}
+
+// ExposureResponse 代表返回给客户端的敞口记录。
+type ExposureResponse struct {
+	ID        string    `json:"id"`
+	Principal float64   `json:"principal"`
+	Interest  float64   `json:"interest"`
+	Currency  string    `json:"currency"`
+	AsOfDate  time.Time `json:"as_of_date"`
+}
+
+// AttachmentResponse 代表违约申请的一个证据附件。
+type AttachmentResponse struct {
+	ID            string    `json:"id"`
+	ApplicationID string    `json:"application_id"`
+	Purpose       string    `json:"purpose"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	Size          int64     `json:"size"`
+	SHA256        string    `json:"sha256"`
+	UploadedBy    string    `json:"uploaded_by,omitempty"`
+	UploadedAt    time.Time `json:"uploaded_at"`
+}
+
+// CommentRequest 代表发表或编辑评论时的请求体。正文中的 @用户名 会被记录为提及。
+type CommentRequest struct {
+	Body string `json:"body" binding:"required,max=5000"`
+}
+
+// CommentResponse 代表申请下的一条评论
+type CommentResponse struct {
+	ID     string `json:"id"`
+	Author string `json:"author"`
+	Body   string `json:"body"`
+	// Mentions 评论中提及的用户名
+	Mentions  []string   `json:"mentions,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	// Edits 编辑历史，每条记录是一次编辑之前的正文 (从早到晚)
+	Edits []CommentEditResponse `json:"edits,omitempty"`
+}
+
+// CommentEditResponse 代表评论被编辑之前的一个版本
+type CommentEditResponse struct {
+	Body     string    `json:"body"`
+	EditedAt time.Time `json:"edited_at"`
 }
 
 // ErrorResponse is a generic error response
@@ -142,3 +702,48 @@ type ErrorResponse struct {
 type SuccessResponse struct {
 	Message string `json:"message"`
 }
+
+// EscalationResponse 是一条超时升级记录
+type EscalationResponse struct {
+	ID            string    `json:"id"`
+	ApplicationID string    `json:"application_id"`
+	CustomerName  string    `json:"customer_name"`
+	Severity      string    `json:"severity"`
+	Stage         string    `json:"stage"`
+	DueAt         time.Time `json:"due_at"`
+	EscalatedAt   time.Time `json:"escalated_at"`
+	// AssigneeName 超时时负责该申请的审批人，尚未分派时为空
+	AssigneeName string `json:"assignee_name,omitempty"`
+	EscalatedTo  string `json:"escalated_to"`
+}
+
+// DraftRequest 代表新建或自动保存违约申请草稿的请求体。
+// 所有字段都可以为空，只校验已填写内容的格式；提交草稿时才要求客户、严重等级和违约原因完整。
+type DraftRequest struct {
+	CustomerID   string `json:"customer_id" binding:"omitempty,uuid"`
+	CreditCode   string `json:"credit_code" binding:"omitempty,len=18"`
+	CustomerName string `json:"customer_name" binding:"max=255"`
+	Severity     string `json:"severity" binding:"omitempty,oneof=High Medium Low"`
+	ReasonCode   string `json:"reason_code" binding:"max=50"`
+	Reason       string `json:"reason"`
+	Remarks      string `json:"remarks"`
+}
+
+// DraftResponse 代表一个违约申请草稿
+type DraftResponse struct {
+	ID           string    `json:"id"`
+	CustomerID   string    `json:"customer_id,omitempty"`
+	CreditCode   string    `json:"credit_code,omitempty"`
+	CustomerName string    `json:"customer_name,omitempty"`
+	Severity     string    `json:"severity,omitempty"`
+	ReasonCode   string    `json:"reason_code,omitempty"`
+	Reason       string    `json:"reason,omitempty"`
+	Remarks      string    `json:"remarks,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	// Missing 提交前还必须填写的字段，为空表示可以提交
+	Missing []string `json:"missing,omitempty"`
+	// ApplicationID / SubmittedAt 草稿提交后创建的申请和提交时间
+	ApplicationID string     `json:"application_id,omitempty"`
+	SubmittedAt   *time.Time `json:"submitted_at,omitempty"`
+}
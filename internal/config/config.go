@@ -12,6 +12,27 @@ type Config struct {
 	DBName     string `mapstructure:"DB_NAME"`
 	JWTSecret  string `mapstructure:"JWT_SECRET"`
 	TokenTTL   int    `mapstructure:"TOKEN_TTL"` // in hours
+	// ApprovalLevels 按严重等级配置违约认定和重生需要的不同审批人数量，例如 {High: 2, Medium: 1, Low: 1}。
+	// 未配置的等级只需要 1 人审批。
+	ApprovalLevels map[string]int `mapstructure:"APPROVAL_LEVELS"`
+	// SLAHours 按严重等级配置审批人处理违约认定和重生的时限 (小时)，例如 {High: 24, Medium: 72, Low: 120}。
+	// 未配置的等级不设 SLA。SLA 调度器每隔 SLACheckIntervalMinutes 分钟检查一次，默认 10 分钟。
+	SLAHours                map[string]int `mapstructure:"SLA_HOURS"`
+	SLACheckIntervalMinutes int            `mapstructure:"SLA_CHECK_INTERVAL_MINUTES"`
+
+	// 附件存储：ATTACHMENT_STORAGE 为 local (默认) 时保存在 ATTACHMENT_DIR 目录下，为 s3 时保存在 S3 兼容的对象存储中。
+	AttachmentStorage   string `mapstructure:"ATTACHMENT_STORAGE"`
+	AttachmentDir       string `mapstructure:"ATTACHMENT_DIR"`
+	AttachmentMaxSizeMB int    `mapstructure:"ATTACHMENT_MAX_SIZE_MB"` // 单个附件的大小上限，默认 20 MB
+	S3Endpoint          string `mapstructure:"S3_ENDPOINT"`
+	S3Region            string `mapstructure:"S3_REGION"`
+	S3Bucket            string `mapstructure:"S3_BUCKET"`
+	S3AccessKey         string `mapstructure:"S3_ACCESS_KEY"`
+	S3SecretKey         string `mapstructure:"S3_SECRET_KEY"`
+	S3UsePathStyle      bool   `mapstructure:"S3_USE_PATH_STYLE"`
+
+	// IdempotencyTTLHours 是 Idempotency-Key 的保留时间 (小时)，默认 24 小时。过期的 Key 每小时清理一次，之后可以重新使用。
+	IdempotencyTTLHours int `mapstructure:"IDEMPOTENCY_TTL_HOURS"`
 }
 
 // LoadConfig 从文件或环境变量中加载配置
@@ -0,0 +1,47 @@
+package core
+
+// ApplicationStatus 是违约申请的状态。状态之间的迁移由 service 层的状态机统一管理，
+// 业务代码不应直接给 DefaultApplication.Status 赋值。
+type ApplicationStatus string
+
+const (
+	// StatusPending 待审核
+	StatusPending ApplicationStatus = "Pending"
+	// StatusApproved 已认定违约
+	StatusApproved ApplicationStatus = "Approved"
+	// StatusRejected 已拒绝 (终态)
+	StatusRejected ApplicationStatus = "Rejected"
+	// StatusRebirthPending 已发起重生，待审核
+	StatusRebirthPending ApplicationStatus = "RebirthPending"
+	// StatusReborn 已重生 (终态)
+	StatusReborn ApplicationStatus = "Reborn"
+	// StatusReturned 审批人退回补充材料，待申请人修改后重新提交
+	StatusReturned ApplicationStatus = "Returned"
+	// StatusWithdrawn 申请人在审核前撤回 (终态)
+	StatusWithdrawn ApplicationStatus = "Withdrawn"
+)
+
+// ApplicationEvent 是驱动违约申请状态迁移的操作。
+type ApplicationEvent string
+
+const (
+	EventApprove        ApplicationEvent = "Approve"
+	EventReject         ApplicationEvent = "Reject"
+	EventApplyRebirth   ApplicationEvent = "ApplyRebirth"
+	EventApproveRebirth ApplicationEvent = "ApproveRebirth"
+	// EventReturn 审批人退回申请并提出问题，EventResubmit 申请人修改后重新提交 (进入下一轮)。
+	EventReturn   ApplicationEvent = "Return"
+	EventResubmit ApplicationEvent = "Resubmit"
+	// EventRejectRebirth 驳回重生：申请回到 Approved，客户保持违约状态。
+	EventRejectRebirth ApplicationEvent = "RejectRebirth"
+	// EventWithdraw 申请人撤回：待审核的申请变为 Withdrawn，待审核的重生退回 Approved。
+	EventWithdraw ApplicationEvent = "Withdraw"
+)
+
+// 用户角色。RoleSystem 不对应任何登录用户，只用于系统自动触发的状态迁移 (如关联集团违约的解除)。
+const (
+	RoleApplicant = "Applicant"
+	RoleApprover  = "Approver"
+	RoleAdmin     = "Admin"
+	RoleSystem    = "System"
+)
@@ -33,11 +33,80 @@ type User struct {
 type Customer struct {
 	BaseModel
 	Name           string `gorm:"size:255;not null;uniqueIndex"`
-	Industry       string `gorm:"size:100;index"` // 行业
-	Region         string `gorm:"size:100;index"` // 区域
+	Industry       string `gorm:"size:100;index"` // 行业，取值为行业字典 (DictionaryEntry, Kind=industry) 中的编码
+	Region         string `gorm:"size:100;index"` // 区域，取值为区域字典 (DictionaryEntry, Kind=region) 中的编码
 	IsDefault      bool   `gorm:"default:false;index"`
 	LatestExtGrade string `gorm:"size:50"` // 新增：最新外部等级
 
+	// CreditCode 18 位统一社会信用代码，是客户的唯一登记标识，不随改名而变化。
+	// 使用指针类型：历史客户可能尚未补录代码，多个 NULL 不会违反唯一索引。
+	CreditCode *string `gorm:"size:18;uniqueIndex"`
+
+	// GroupID 客户所属的关联集团 (可选)。一个客户最多属于一个集团。
+	GroupID *uuid.UUID `gorm:"type:uuid;index"`
+
+	// Ratings 客户的外部评级历史 (用于 GORM 预加载)。LatestExtGrade 由其中最新的一条记录派生。
+	Ratings []ExternalRating `gorm:"foreignKey:CustomerID"`
+
+	// Aliases 客户的曾用名历史 (用于 GORM 预加载)。
+	Aliases []CustomerAlias `gorm:"foreignKey:CustomerID"`
+}
+
+// CustomerAlias 客户的曾用名。客户改名时，旧名称会被记录为一条别名，
+// 以便按旧名称提交的申请仍能找到同一个客户。
+type CustomerAlias struct {
+	BaseModel
+	CustomerID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name       string    `gorm:"size:255;not null;index"`
+	// ValidUntil 该名称停止使用的时间，即改名发生的时间。
+	ValidUntil time.Time `gorm:"not null"`
+}
+
+// ExternalRating 外部评级历史记录，每次评级机构调整客户评级都会新增一条，而不是覆盖。
+type ExternalRating struct {
+	BaseModel
+	CustomerID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Agency        string    `gorm:"size:100;not null"` // 评级机构
+	Grade         string    `gorm:"size:50;not null"`  // 评级结果，必须在评级映射表中有定义
+	EffectiveDate time.Time `gorm:"not null;index"`    // 评级生效日期
+	Source        string    `gorm:"size:255"`          // 数据来源 (例如评级报告编号、数据供应商)
+
+	// 以下两个字段是写入时从评级映射表中拷贝的快照，映射表后续调整不会改写历史记录。
+	Ordinal        int  `gorm:"not null"` // 评级序数，数值越大信用越差
+	IsDefaultGrade bool `gorm:"not null"` // 该评级是否属于违约级别
+}
+
+// Exposure 客户在某个时点的信用敞口记录。敞口按时点追加记录，不覆盖历史。
+type Exposure struct {
+	BaseModel
+	CustomerID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Principal  float64   `gorm:"type:numeric(20,2);not null"`           // 未偿本金
+	Interest   float64   `gorm:"type:numeric(20,2);not null;default:0"` // 应收未收利息
+	Currency   string    `gorm:"size:3;not null"`                       // 币种 (ISO 4217，例如 CNY)
+	AsOfDate   time.Time `gorm:"type:date;not null;index"`              // 敞口统计时点
+}
+
+// RatingScaleEntry 评级映射表的一项：将某评级机构的某个评级映射到统一的序数和违约标志。
+type RatingScaleEntry struct {
+	BaseModel
+	Agency    string `gorm:"size:100;not null;uniqueIndex:idx_rating_scale_agency_grade"`
+	Grade     string `gorm:"size:50;not null;uniqueIndex:idx_rating_scale_agency_grade"`
+	Ordinal   int    `gorm:"not null"`
+	IsDefault bool   `gorm:"not null;default:false"`
+}
+
+// CustomerGroup 关联集团，用于描述母公司与成员企业之间的关联关系。
+// 集团内某一成员被认定违约时，可以据此为其他成员发起关联违约认定。
+type CustomerGroup struct {
+	BaseModel
+	Name string `gorm:"size:255;not null;uniqueIndex"`
+
+	// ParentCustomerID 集团母公司对应的客户 ID (可选)，母公司本身也是集团成员。
+	ParentCustomerID *uuid.UUID `gorm:"type:uuid;index"`
+	Parent           *Customer  `gorm:"foreignKey:ParentCustomerID"`
+
+	// Members 集团的全部成员客户 (通过 Customer.GroupID 关联)。
+	Members []Customer `gorm:"foreignKey:GroupID"`
 }
 
 // DefaultApplication 代表一条客户违约认定的申请记录。
@@ -51,9 +120,18 @@ type DefaultApplication struct {
 	// Customer 关联的客户实体 (用于 GORM 预加载客户的详细信息)。
 	Customer Customer `gorm:"foreignKey:CustomerID"`
 
+	// Round 申请的提交轮次，从 1 开始。每次被退回后重新提交进入下一轮，历次内容见 ApplicationRevision。
+	Round int `gorm:"not null;default:1"`
+	// RoundSubmittedAt 当前轮次的提交时间，第一轮为空 (即 ApplicationTime)。
+	RoundSubmittedAt *time.Time
+
+	// Version 乐观锁版本号，每次修改申请都会加一，对外作为 ETag。
+	// 两个审批人基于同一版本同时操作时，只有先提交的一个成功。
+	Version int `gorm:"not null;default:1"`
+
 	// Status 申请的当前状态。
-	// 可选值：Pending (待处理), Approved (已批准), Rejected (已拒绝)。
-	Status string `gorm:"size:50;not null;index;default:'Pending'"`
+	// 可选值见 ApplicationStatus，只能通过状态机迁移。
+	Status ApplicationStatus `gorm:"size:50;not null;index;default:'Pending'"`
 
 	// Severity 违约事件的严重等级，用于风险评估。
 	// 可选值：High (高), Medium (中), Low (低)。
@@ -64,6 +142,11 @@ type DefaultApplication struct {
 	RejectionReason string `gorm:"type:text"` // 新增：用于存储拒绝原因
 	RebirthReason   string `gorm:"type:text"` // 新增：重生原因
 
+	// DefaultReasonCode / RebirthReasonCode 违约原因和重生原因在原因目录 (ReasonCatalogEntry) 中的编码，统计时按编码分组。
+	// 原因目录引入之前的历史申请可能为空。
+	DefaultReasonCode string `gorm:"size:50;index"`
+	RebirthReasonCode string `gorm:"size:50;index"`
+
 	// Remarks 申请人填写的额外备注信息 (可选)。
 	Remarks string `gorm:"type:text"`
 
@@ -76,11 +159,300 @@ type DefaultApplication struct {
 	Approver     *User      `gorm:"foreignKey:ApproverID"` // 关联到 User 模型
 	ApprovalTime *time.Time // 审核时间，使用指针类型以允许 NULL 值
 
+	// AssigneeID 负责审核该申请的审批人。创建时按分派规则自动分派，管理员可以改派；为空表示尚未分派。
+	AssigneeID *uuid.UUID `gorm:"type:uuid;index"`
+	Assignee   *User      `gorm:"foreignKey:AssigneeID"`
+	AssignedAt *time.Time
+	// AssignedByID 改派申请的管理员，自动分派时为空。
+	AssignedByID *uuid.UUID `gorm:"type:uuid"`
+	AssignedBy   *User      `gorm:"foreignKey:AssignedByID"`
+
 	// 新增：重生审批相关字段
 	RebirthApproverID   *uuid.UUID `gorm:"type:uuid"`
 	RebirthApprover     *User      `gorm:"foreignKey:RebirthApproverID"`
 	RebirthApprovalTime *time.Time
+	// RebirthApplicantID 发起重生的用户 ID。按四眼原则，发起人不能审批自己发起的重生。
+	RebirthApplicantID *uuid.UUID `gorm:"type:uuid"`
+	RebirthApplicant   *User      `gorm:"foreignKey:RebirthApplicantID"`
+	RebirthAppliedAt   *time.Time
+	// RebirthRejections 被驳回的历次重生申请。
+	RebirthRejections []RebirthRejection `gorm:"foreignKey:ApplicationID"`
+	// RebirthWithdrawals 发起人撤回的历次重生申请。
+	RebirthWithdrawals []RebirthWithdrawal `gorm:"foreignKey:ApplicationID"`
+
+	// 撤回相关字段：申请人撤回待审核的申请时记录撤回人、时间和原因。撤回重生不写这些字段，见 RebirthWithdrawal。
+	WithdrawnByID    *uuid.UUID `gorm:"type:uuid"`
+	WithdrawnBy      *User      `gorm:"foreignKey:WithdrawnByID"`
+	WithdrawnAt      *time.Time
+	WithdrawalReason string `gorm:"type:text"`
+
+	// SLA：当前审核环节 (Pending 或 RebirthPending) 的开始时间、到期时间和超时升级时间，由 SLA 调度器维护。
+	// SLAStartedAt 与环节实际的开始时间不一致，说明申请已进入新的环节 (例如退回后重新提交)，调度器会重新计算到期时间。
+	SLAStartedAt *time.Time
+	DueAt        *time.Time `gorm:"index"`
+	EscalatedAt  *time.Time
+
+	// Comments 申请下的讨论评论，让审批依据和决定保存在一起。
+	Comments []Comment `gorm:"foreignKey:ApplicationID"`
+
+	// ApprovalSteps 多级审批中逐级的审批记录 (违约认定和重生各自独立计数)。
+	ApprovalSteps []ApprovalStep `gorm:"foreignKey:ApplicationID"`
 
 	// ApplicationTime 申请被正式提交的时间戳。
 	ApplicationTime time.Time `gorm:"not null"`
+
+	// TriggerApplicationID 关联违约的触发申请 ID。
+	// 当集团内某成员的申请被批准并选择向集团传导时，为其他成员自动发起的申请会记录触发它的申请，
+	// 触发申请重生时据此反向解除这些成员的违约设定。
+	TriggerApplicationID *uuid.UUID `gorm:"type:uuid;index"`
+
+	// 违约认定批准时的敞口快照，取自客户当时最新的一条敞口记录。客户没有敞口记录时为空。
+	// 快照保证统计口径固定在认定时点，不受之后敞口变化的影响。
+	ExposurePrincipal *float64   `gorm:"type:numeric(20,2)"`
+	ExposureInterest  *float64   `gorm:"type:numeric(20,2)"`
+	ExposureCurrency  string     `gorm:"size:3"`
+	ExposureAsOfDate  *time.Time `gorm:"type:date"`
+}
+
+// DictionaryEntry 是行业、区域等参考数据字典中的一个条目。
+// 字典是一棵按 ParentCode 组织的树 (例如 门类 > 大类，省 > 市)，客户只记录条目编码，
+// 统计时可以沿 ParentCode 向上汇总到任意层级。
+type DictionaryEntry struct {
+	BaseModel
+	// Kind 字典类型：industry (行业) 或 region (区域)。同一类型内编码唯一。
+	Kind string `gorm:"size:50;not null;uniqueIndex:idx_dictionary_kind_code"`
+	Code string `gorm:"size:50;not null;uniqueIndex:idx_dictionary_kind_code"`
+	Name string `gorm:"size:100;not null"`
+	// ParentCode 上级条目的编码，为空表示顶层条目。
+	ParentCode string `gorm:"size:50;index"`
+	// Level 条目所在层级，顶层为 1，由上级条目的层级推导。
+	Level int `gorm:"not null"`
+}
+
+// CustomerMergeRecord 客户合并的审计记录。重复客户 (Loser) 被合并到保留客户 (Survivor) 后，
+// Loser 会被软删除，这里保留它合并前的关键信息以便追溯。
+type CustomerMergeRecord struct {
+	BaseModel
+	SurvivorID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	LoserID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	LoserName       string    `gorm:"size:255;not null"`
+	LoserCreditCode string    `gorm:"size:18"`
+	// MovedApplications 从 Loser 转移到 Survivor 的违约申请数量。
+	MovedApplications int64 `gorm:"not null"`
+	// SurvivorWasDefault / SurvivorIsDefault 合并前后 Survivor 的违约状态，便于核对重新计算的结果。
+	SurvivorWasDefault bool      `gorm:"not null"`
+	SurvivorIsDefault  bool      `gorm:"not null"`
+	Reason             string    `gorm:"type:text"`
+	OperatorID         uuid.UUID `gorm:"type:uuid;not null"`
+	Operator           User      `gorm:"foreignKey:OperatorID"`
+	MergedAt           time.Time `gorm:"not null"`
+}
+
+// ApprovalStep 是多级审批中的一级审批记录。
+// 需要几级审批由申请的严重等级决定，每一级必须由不同的审批人完成，最后一级审批完成后申请才会迁移状态。
+type ApprovalStep struct {
+	BaseModel
+	ApplicationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_approval_step"`
+	// Stage 审批环节：Approve (违约认定) 或 ApproveRebirth (重生)。
+	Stage ApplicationEvent `gorm:"size:50;not null;uniqueIndex:idx_approval_step"`
+	// Round 审批针对的申请轮次。申请被退回修改后，新一轮需要重新逐级审批。
+	Round int `gorm:"not null;default:1;uniqueIndex:idx_approval_step"`
+	// Level 本环节中的第几级审批，从 1 开始。唯一索引防止并发审批写入同一级。
+	Level      int       `gorm:"not null;uniqueIndex:idx_approval_step"`
+	ApproverID uuid.UUID `gorm:"type:uuid;not null"`
+	Approver   User      `gorm:"foreignKey:ApproverID"`
+	ApprovedAt time.Time `gorm:"not null"`
+}
+
+// RebirthRejection 是一次被驳回的重生申请的历史记录。
+// 重生被驳回后申请回到 Approved，申请上的重生字段会被清空以便重新发起，被驳回的内容保存在这里。
+type RebirthRejection struct {
+	BaseModel
+	ApplicationID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	RebirthReason      string     `gorm:"type:text"`
+	RebirthReasonCode  string     `gorm:"size:50"`
+	RebirthApplicantID *uuid.UUID `gorm:"type:uuid"`
+	RebirthAppliedAt   *time.Time
+	RejectedByID       uuid.UUID `gorm:"type:uuid;not null"`
+	RejectedBy         User      `gorm:"foreignKey:RejectedByID"`
+	RejectedAt         time.Time `gorm:"not null"`
+	RejectionReason    string    `gorm:"type:text;not null"`
+}
+
+// RebirthWithdrawal 是一次被发起人撤回的重生申请的历史记录。
+// 撤回重生后申请回到 Approved (仍处于违约状态)，与 RebirthRejection 一样，申请上的重生字段被清空，撤回的内容保存在这里。
+type RebirthWithdrawal struct {
+	BaseModel
+	ApplicationID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	RebirthReason     string    `gorm:"type:text"`
+	RebirthReasonCode string    `gorm:"size:50"`
+	RebirthAppliedAt  *time.Time
+	WithdrawnByID     uuid.UUID `gorm:"type:uuid;not null"`
+	WithdrawnBy       User      `gorm:"foreignKey:WithdrawnByID"`
+	WithdrawnAt       time.Time `gorm:"not null"`
+	WithdrawalReason  string    `gorm:"type:text"`
+}
+
+// ApplicationRevision 是申请某一轮提交内容的快照，在申请被退回时写入。
+// 申请人修改后重新提交进入下一轮，审批人可以逐轮比较申请人修改了什么。
+type ApplicationRevision struct {
+	BaseModel
+	ApplicationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_application_revision"`
+	Round         int       `gorm:"not null;uniqueIndex:idx_application_revision"`
+	Severity      string    `gorm:"size:50;not null"`
+	DefaultReason string    `gorm:"type:text;not null"`
+	Remarks       string    `gorm:"type:text"`
+	SubmittedAt   time.Time `gorm:"not null"`
+	// Questions 审批人退回时提出的问题。
+	Questions    string    `gorm:"type:text;not null"`
+	ReturnedByID uuid.UUID `gorm:"type:uuid;not null"`
+	ReturnedBy   User      `gorm:"foreignKey:ReturnedByID"`
+	ReturnedAt   time.Time `gorm:"not null"`
+
+	// DefaultReasonCode 本轮的违约原因编码
+	DefaultReasonCode string `gorm:"size:50"`
+}
+
+// AttachmentPurpose 区分附件是违约认定的证据还是重生的证据。
+type AttachmentPurpose string
+
+const (
+	// AttachmentPurposeDefault 违约认定的证据 (逾期通知、法院文书、评级报告等)，在申请审核期间上传
+	AttachmentPurposeDefault AttachmentPurpose = "Default"
+	// AttachmentPurposeRebirth 重生的证据 (结清证明等)，在申请认定违约之后上传
+	AttachmentPurposeRebirth AttachmentPurpose = "Rebirth"
+)
+
+// Attachment 是违约申请的证据附件。数据库中只保存元数据，文件内容保存在存储后端 StorageKey 处。
+type Attachment struct {
+	BaseModel
+	ApplicationID uuid.UUID         `gorm:"type:uuid;not null;index;uniqueIndex:idx_attachment_checksum"`
+	Purpose       AttachmentPurpose `gorm:"size:50;not null"`
+	FileName      string            `gorm:"size:255;not null"`
+	// ContentType 根据文件内容检测出的 MIME 类型，而不是客户端声明的类型。
+	ContentType string `gorm:"size:255;not null"`
+	Size        int64  `gorm:"not null"`
+	// SHA256 文件内容的校验和 (十六进制)。同一申请下不允许重复上传相同内容的文件。
+	SHA256       string    `gorm:"size:64;not null;uniqueIndex:idx_attachment_checksum"`
+	StorageKey   string    `gorm:"size:255;not null"`
+	UploadedByID uuid.UUID `gorm:"type:uuid;not null"`
+	UploadedBy   User      `gorm:"foreignKey:UploadedByID"`
+}
+
+// Comment 是申请人和审批人在违约申请下的一条讨论评论。
+type Comment struct {
+	BaseModel
+	ApplicationID uuid.UUID `gorm:"type:uuid;not null;index"`
+	AuthorID      uuid.UUID `gorm:"type:uuid;not null"`
+	Author        User      `gorm:"foreignKey:AuthorID"`
+	Body          string    `gorm:"type:text;not null"`
+	// Mentions 评论正文中 @用户名 提及的用户，编辑评论时按新的正文重新计算。
+	Mentions []User `gorm:"many2many:comment_mentions"`
+	// EditedAt 最后一次编辑的时间，从未编辑过时为空。
+	EditedAt *time.Time
+	// Edits 评论的编辑历史，每条记录保存一次编辑之前的正文。
+	Edits []CommentEdit `gorm:"foreignKey:CommentID"`
+}
+
+// CommentEdit 是评论被编辑之前的一个版本。
+type CommentEdit struct {
+	BaseModel
+	CommentID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Body      string    `gorm:"type:text;not null"`
+	// EditedAt 这个版本被替换的时间。
+	EditedAt time.Time `gorm:"not null"`
+}
+
+// RoutingRule 是审批人分派规则：客户的区域和行业与规则匹配时，申请分派给规则指定的审批人。
+// 规则按 Priority 从小到大匹配，同一优先级匹配到多个审批人时分派给其中手头待审申请最少的一位；
+// 没有任何规则匹配时，在全部审批人中按工作量轮流分派。
+type RoutingRule struct {
+	BaseModel
+	Priority int `gorm:"not null;default:0;index"`
+	// Region / Industry 匹配客户的区域和行业编码，为空表示不限。
+	Region     string    `gorm:"size:100"`
+	Industry   string    `gorm:"size:100"`
+	ApproverID uuid.UUID `gorm:"type:uuid;not null"`
+	Approver   User      `gorm:"foreignKey:ApproverID"`
+}
+
+// EscalationEvent 是一次超时升级的记录：申请在某个审核环节超过 SLA 到期时间仍未处理，被升级给主管角色。
+// 同一环节 (ApplicationID + Stage + StageStartedAt) 只升级一次，唯一索引防止多个调度器实例重复升级。
+type EscalationEvent struct {
+	BaseModel
+	ApplicationID  uuid.UUID          `gorm:"type:uuid;not null;uniqueIndex:idx_escalation_event"`
+	Application    DefaultApplication `gorm:"foreignKey:ApplicationID"`
+	Stage          ApplicationStatus  `gorm:"size:50;not null;uniqueIndex:idx_escalation_event"`
+	StageStartedAt time.Time          `gorm:"not null;uniqueIndex:idx_escalation_event"`
+	DueAt          time.Time          `gorm:"not null"`
+	EscalatedAt    time.Time          `gorm:"not null;index"`
+	// AssigneeID 超时时负责该申请的审批人，尚未分派时为空。
+	AssigneeID *uuid.UUID `gorm:"type:uuid"`
+	Assignee   *User      `gorm:"foreignKey:AssigneeID"`
+	// EscalatedTo 接收升级的主管角色。
+	EscalatedTo string `gorm:"size:50;not null"`
+}
+
+// ReasonKind 区分违约原因目录和重生原因目录。
+type ReasonKind string
+
+const (
+	// ReasonKindDefault 违约认定原因
+	ReasonKindDefault ReasonKind = "default"
+	// ReasonKindRebirth 违约重生原因
+	ReasonKindRebirth ReasonKind = "rebirth"
+)
+
+// ReasonCatalogEntry 是违约原因或重生原因目录中的一个条目。
+// 监管口径调整时由管理员维护目录，申请只记录原因编码，不需要重新发布系统。
+type ReasonCatalogEntry struct {
+	BaseModel
+	Kind    ReasonKind `gorm:"size:50;not null;uniqueIndex:idx_reason_kind_code"`
+	Code    string     `gorm:"size:50;not null;uniqueIndex:idx_reason_kind_code"`
+	LabelZh string     `gorm:"type:text;not null"`
+	LabelEn string     `gorm:"type:text"`
+	// ValidFrom / ValidTo 原因的生效期间 (含 ValidFrom，不含 ValidTo)，为空表示不限。
+	// 只有在生效期间内的原因可以用于新的申请，停用的原因通过设置 ValidTo 下线，历史申请保持不变。
+	ValidFrom *time.Time
+	ValidTo   *time.Time
+	// EvidenceHint 提示申请人使用该原因时需要上传哪些证据附件。
+	EvidenceHint string `gorm:"type:text"`
+}
+
+// ApplicationDraft 是申请人尚未提交的违约申请草稿，用于在填写过程中随时保存。
+// 草稿只校验已填写内容的格式，客户、严重等级和违约原因都可以暂时为空；
+// 提交时才按新申请的全部业务规则校验，通过后创建待审核 (Pending) 的申请，草稿从此不能再修改。
+type ApplicationDraft struct {
+	BaseModel
+	ApplicantID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Applicant   User      `gorm:"foreignKey:ApplicantID"`
+	// CustomerID / CustomerCreditCode / CustomerName 与提交申请时一样，按优先级使用第一个非空的值定位客户。
+	CustomerID         *uuid.UUID `gorm:"type:uuid"`
+	CustomerCreditCode string     `gorm:"size:18"`
+	CustomerName       string     `gorm:"size:255"`
+	Severity           string     `gorm:"size:50"`
+	DefaultReasonCode  string     `gorm:"size:50"`
+	DefaultReason      string     `gorm:"type:text"`
+	Remarks            string     `gorm:"type:text"`
+	// SubmittedApplicationID / SubmittedAt 草稿提交后创建的申请和提交时间，为空表示草稿仍在编辑中。
+	SubmittedApplicationID *uuid.UUID `gorm:"type:uuid"`
+	SubmittedAt            *time.Time
+}
+
+// IdempotencyRecord 记录一个带 Idempotency-Key 的写请求及其响应。
+// 客户端在网络不稳定时用同一个 Key 重试，服务端直接返回第一次的响应，不会重复执行；
+// 同一个 Key 用于不同的请求内容时拒绝。Key 按用户隔离，过期 (ExpiresAt) 后可以重新使用。
+type IdempotencyRecord struct {
+	BaseModel
+	UserID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_idempotency_user_key"`
+	Key    string    `gorm:"size:255;not null;uniqueIndex:idx_idempotency_user_key"`
+	// RequestHash 请求方法、路径和请求体的 SHA-256 摘要，用于识别同一个 Key 是否用于不同的请求。
+	RequestHash string `gorm:"size:64;not null"`
+	// StatusCode / ContentType / ETag / ResponseBody 第一次执行的响应，CompletedAt 为空表示请求仍在处理中。
+	StatusCode   int
+	ContentType  string `gorm:"size:255"`
+	ETag         string `gorm:"size:255"`
+	ResponseBody []byte
+	CompletedAt  *time.Time
+	ExpiresAt    time.Time `gorm:"not null;index"`
 }
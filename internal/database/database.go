@@ -21,6 +21,10 @@ import (
 // ==========================================================================================
 var DB *gorm.DB
 
+// TrigramSearch 表示 pg_trgm 扩展和客户名称的三元组索引是否已经就绪，由 Connect 设置。
+// 数据库账号没有创建扩展的权限时为 false，客户搜索退化为只按 ILIKE 匹配和排序 (见 repository.CustomerSearchOptions)。
+var TrigramSearch bool
+
 // ==========================================================================================
 // Connect 函数负责初始化到 PostgreSQL 数据库的连接，并执行自动化的数据模型迁移。
 // 它在 main 函数中被调用，是应用启动流程的关键一步。
@@ -48,15 +52,34 @@ func Connect(cfg config.Config) {
 
 	log.Println("Database connection established")
 
+	// 客户搜索的模糊匹配依赖 pg_trgm 扩展提供的 similarity() 函数和 gin_trgm_ops 索引。
+	// 扩展不可用只影响模糊匹配，不应阻止应用启动。
+	TrigramSearch = true
+	if err = DB.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		log.Printf("Warning: failed to enable pg_trgm extension, customer search falls back to ILIKE only: %v", err)
+		TrigramSearch = false
+	}
+
 	// 3. 自动迁移数据模型。
 	// DB.AutoMigrate 是 GORM 的一个强大功能。它会检查数据库中是否存在与模型（User, Customer, DefaultApplication）
 	// 对应的表。如果表不存在，它会自动创建。如果表存在但缺少字段，它会自动添加新字段。
 	// 注意：AutoMigrate 不会删除不再需要的字段或修改字段类型，以防数据丢失。
 	// 这对于开发阶段快速迭代模型非常方便。
-	err = DB.AutoMigrate(&core.User{}, &core.Customer{}, &core.DefaultApplication{})
+	err = DB.AutoMigrate(&core.User{}, &core.Customer{}, &core.DefaultApplication{}, &core.CustomerGroup{}, &core.ExternalRating{}, &core.RatingScaleEntry{}, &core.DictionaryEntry{}, &core.Exposure{}, &core.CustomerAlias{}, &core.CustomerMergeRecord{}, &core.ApprovalStep{}, &core.RebirthRejection{}, &core.ApplicationRevision{}, &core.Attachment{}, &core.Comment{}, &core.CommentEdit{}, &core.RoutingRule{}, &core.EscalationEvent{}, &core.ReasonCatalogEntry{}, &core.ApplicationDraft{}, &core.IdempotencyRecord{}, &core.RebirthWithdrawal{})
 	if err != nil {
 		// 如果迁移失败，同样是致命错误。
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
+	// 为客户名称建立三元组索引，加速子串和相似度查询
+	if TrigramSearch {
+		if err = DB.Exec("CREATE INDEX IF NOT EXISTS idx_customers_name_trgm ON customers USING gin (name gin_trgm_ops)").Error; err != nil {
+			log.Printf("Warning: failed to create customer name trigram index, customer search falls back to ILIKE only: %v", err)
+			TrigramSearch = false
+		}
+	}
+	// 原因目录为空时写入初始的违约原因和重生原因
+	if err = seedReasonCatalogs(DB); err != nil {
+		log.Fatalf("Failed to seed reason catalogs: %v", err)
+	}
 	log.Println("Database migrated")
 }
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"xquant-default-management/internal/core"
+
+	"gorm.io/gorm"
+)
+
+// defaultReasonCatalog 是违约原因目录的初始内容。
+// 编码 GROUP_CONTAGION 由关联集团传导违约时使用，必须与 service 包中的 groupDefaultReasonCode 保持一致，该条目不能删除或停用。
+var defaultReasonCatalog = []core.ReasonCatalogEntry{
+	{Code: "SETTLEMENT_SHORTFALL", LabelZh: "6 个月内，交易对手技术性或资金周转性等原因，给当天结算带来头寸缺口 2 次以上",
+		LabelEn:      "Caused settlement position shortfalls twice or more within 6 months for technical or liquidity reasons",
+		EvidenceHint: "结算失败记录或头寸缺口通知"},
+	{Code: "TRADE_CANCELLATION", LabelZh: "6 个月内因各种原因导致成交后撤单 2 次以上",
+		LabelEn:      "Cancelled executed trades twice or more within 6 months",
+		EvidenceHint: "撤单记录"},
+	{Code: "PAYMENT_DEFAULT", LabelZh: "未能按照合约规定支付或延期支付利息、本金或其他交付义务（不包括在宽限期内延期支付）",
+		LabelEn:      "Failed to pay, or delayed, interest, principal or other contractual obligations (excluding grace periods)",
+		EvidenceHint: "逾期通知、催收记录或还款流水"},
+	{Code: "GROUP_CONTAGION", LabelZh: "关联违约：集团（内部）客户中任意一家成员发生违约，导致关联成员同时违约",
+		LabelEn:      "Related default: another member of the customer's group has defaulted",
+		EvidenceHint: "集团内违约成员的认定结果"},
+	{Code: "DISTRESSED_RESTRUCTURING", LabelZh: "发生消极债务置换：债务人提供给债权人新的或重组的债务，或新证券组合、现金或资产低于原来金融义务；或为债务人未来避免发生破产或拖欠还款而进行的展期或重组",
+		LabelEn:      "Distressed debt exchange, or an extension or restructuring to help the obligor avoid bankruptcy or payment default",
+		EvidenceHint: "重组协议或展期协议"},
+	{Code: "BANKRUPTCY", LabelZh: "申请破产保护、发生法律接管，或者处于停业整顿、被吊销营业执照等状态",
+		LabelEn:      "Filed for bankruptcy protection, placed under legal receivership, or suspended or delicensed",
+		EvidenceHint: "法院文书或监管公告"},
+	{Code: "EXTERNAL_DEFAULT", LabelZh: "在其他金融机构违约（包括不限于：人行征信记录中显示贷款分类状态不良），或外部评级显示为违约级别",
+		LabelEn:      "Defaulted at other financial institutions (e.g. non-performing in the credit registry), or external rating at a default grade",
+		EvidenceHint: "征信报告或评级报告"},
+}
+
+// rebirthReasonCatalog 是重生原因目录的初始内容，即原先写在 api.RebirthApplyRequest 校验规则中的六个重生原因。
+// 编码 GROUP_MEMBER_REBORN 由关联集团成员自动重生时使用，必须与 service 包中的 groupRebirthReasonCode 保持一致，
+// 自动重生记录的原因名称取自该条目，该条目不能删除或停用。
+var rebirthReasonCatalog = []core.ReasonCatalogEntry{
+	{Code: "NORMAL_SETTLEMENT", LabelZh: "正常结算后解除",
+		LabelEn:      "Released after normal settlement",
+		EvidenceHint: "结清证明"},
+	{Code: "EXTERNAL_CURED", LabelZh: "在其他金融机构违约解除，或外部评级显示为非违约级别",
+		LabelEn:      "Default cured at other financial institutions, or external rating back to a non-default grade",
+		EvidenceHint: "征信报告或评级报告"},
+	{Code: "PROVISION_BELOW_THRESHOLD", LabelZh: "计提比例小于设置界限",
+		LabelEn:      "Provisioning ratio below the configured threshold",
+		EvidenceHint: "减值计提测算表"},
+	{Code: "TIMELY_PAYMENT_12M", LabelZh: "连续 12 个月内按时支付本金和利息",
+		LabelEn:      "Paid principal and interest on time for 12 consecutive months",
+		EvidenceHint: "近 12 个月还款流水"},
+	{Code: "RECOVERED_CAPACITY", LabelZh: "客户的还款意愿和还款能力明显好转，已偿付各项逾期本金、逾期利息和其他费用（包括罚息等），且连续 12 个月内按时支付本金、利息",
+		LabelEn:      "Willingness and ability to repay improved markedly, all overdue amounts settled and 12 months of timely payments",
+		EvidenceHint: "结清证明和近 12 个月还款流水"},
+	{Code: "GROUP_MEMBER_REBORN", LabelZh: "导致违约的关联集团内其他发生违约的客户已经违约重生，解除关联成员的违约设定",
+		LabelEn:      "The group member whose default caused this related default has been reborn",
+		EvidenceHint: "触发成员的重生审批结果"},
+}
+
+// seedReasonCatalogs 在原因目录为空时写入初始内容，之后目录完全由管理员维护。
+// 同时为目录引入之前的历史申请回填原因编码：原因文字与目录条目的中文名称完全一致时记录其编码。
+func seedReasonCatalogs(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for kind, entries := range map[core.ReasonKind][]core.ReasonCatalogEntry{
+			core.ReasonKindDefault: defaultReasonCatalog,
+			core.ReasonKindRebirth: rebirthReasonCatalog,
+		} {
+			var count int64
+			if err := tx.Model(&core.ReasonCatalogEntry{}).Where("kind = ?", kind).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+			seeded := make([]core.ReasonCatalogEntry, len(entries))
+			copy(seeded, entries)
+			for i := range seeded {
+				seeded[i].Kind = kind
+			}
+			if err := tx.Create(&seeded).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, backfill := range []struct {
+			kind                   core.ReasonKind
+			codeColumn, textColumn string
+		}{
+			{core.ReasonKindDefault, "default_reason_code", "default_reason"},
+			{core.ReasonKindRebirth, "rebirth_reason_code", "rebirth_reason"},
+		} {
+			err := tx.Exec("UPDATE default_applications AS da SET "+backfill.codeColumn+" = r.code "+
+				"FROM reason_catalog_entries AS r "+
+				"WHERE r.kind = ? AND r.deleted_at IS NULL AND r.label_zh = da."+backfill.textColumn+" "+
+				"AND (da."+backfill.codeColumn+" IS NULL OR da."+backfill.codeColumn+" = '')", backfill.kind).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
 	"xquant-default-management/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -21,18 +26,143 @@ func NewApplicationHandler(appService service.ApplicationService) *ApplicationHa
 	return &ApplicationHandler{appService: appService}
 }
 
+// actorFromContext 从认证中间件写入的上下文中取出当前用户及其角色
+func actorFromContext(c *gin.Context) (service.Actor, bool) {
+	userIDVal, _ := c.Get("userID")
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		return service.Actor{}, false
+	}
+	roleVal, _ := c.Get("role")
+	role, _ := roleVal.(string)
+	return service.Actor{ID: userID, Role: role}, true
+}
+
+// writeApprovalProgress 多级审批尚未完成时，返回已完成和需要的审批人数量
+func (h *ApplicationHandler) writeApprovalProgress(c *gin.Context, app *core.DefaultApplication) {
+	c.JSON(http.StatusAccepted, api.ApprovalProgressResponse{
+		Message:           "Approval recorded, further approvals are required",
+		Approvals:         len(app.ApprovalSteps),
+		RequiredApprovals: h.appService.RequiredApprovals(app),
+	})
+}
+
+// toApplicationResponse 将申请映射为列表项 DTO，now 用于计算 SLA 是否超时
+func toApplicationResponse(app *core.DefaultApplication, now time.Time) api.ApplicationResponse {
+	res := api.ApplicationResponse{
+		ID:              app.ID.String(),
+		CustomerName:    app.Customer.Name,
+		Status:          string(app.Status),
+		Severity:        app.Severity,
+		ApplicantName:   app.Applicant.Username,
+		ApplicationTime: app.ApplicationTime,
+		Version:         app.Version,
+	}
+	if app.Assignee != nil {
+		res.AssigneeName = app.Assignee.Username
+	}
+	res.DueAt, res.Overdue = service.SLAState(app, now)
+	return res
+}
+
+// applicationETag 以申请的版本号作为 ETag
+func applicationETag(app *core.DefaultApplication) string {
+	return `"` + strconv.Itoa(app.Version) + `"`
+}
+
+// ifMatchVersion 解析 If-Match 请求头中的申请版本号，接受弱 ETag (W/"3") 和不带引号的版本号。
+// 没有该请求头时 present 为 false；"*" 匹配任意版本，返回 0 (不校验)。
+func ifMatchVersion(c *gin.Context) (version int, present bool, err error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return 0, false, nil
+	}
+	if header == "*" {
+		return 0, true, nil
+	}
+	version, err = strconv.Atoi(strings.Trim(strings.TrimPrefix(header, "W/"), `"`))
+	if err != nil || version < 1 {
+		return 0, true, errors.New("invalid If-Match header, expected the application ETag")
+	}
+	return version, true, nil
+}
+
+// expectedVersion 读取客户端的 If-Match 版本号并写入错误响应，返回 false 时调用方直接返回。
+// required 为 true 时缺少 If-Match 返回 428，防止客户端在没有读取最新状态的情况下覆盖他人的操作。
+func expectedVersion(c *gin.Context, required bool) (int, bool) {
+	version, present, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return 0, false
+	}
+	if !present && required {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required"})
+		return 0, false
+	}
+	return version, true
+}
+
+// classifyTransitionError 将状态迁移返回的错误归类为 HTTP 状态码和错误类型，未识别的错误归为 500
+func classifyTransitionError(err error) (int, string) {
+	var invalid *service.InvalidTransitionError
+	var forbidden *service.ForbiddenTransitionError
+	var stale *service.StaleApplicationError
+	switch {
+	case errors.As(err, &stale):
+		// 412 Precondition Failed 表示客户端看到的申请版本已经过期
+		return http.StatusPreconditionFailed, "precondition_failed"
+	case errors.As(err, &invalid):
+		// 409 Conflict 表示请求与申请当前的状态冲突
+		return http.StatusConflict, "invalid_transition"
+	case errors.As(err, &forbidden), errors.Is(err, service.ErrNotApplicant):
+		return http.StatusForbidden, "forbidden"
+	case errors.Is(err, service.ErrSelfApproval):
+		return http.StatusForbidden, "self_approval"
+	case errors.Is(err, service.ErrDuplicateApprover):
+		return http.StatusConflict, "duplicate_approver"
+	case errors.Is(err, service.ErrUnknownReason), errors.Is(err, service.ErrInactiveReason):
+		return http.StatusBadRequest, "invalid_reason"
+	case err.Error() == "application not found":
+		return http.StatusNotFound, "not_found"
+	default:
+		return http.StatusInternalServerError, "internal_error"
+	}
+}
+
+// writeTransitionError 将状态迁移返回的错误映射为 HTTP 状态码。
+// 版本过期时返回申请的当前状态和 ETag，客户端可以据此刷新后重试。
+func writeTransitionError(c *gin.Context, err error, fallback string) {
+	var stale *service.StaleApplicationError
+	if errors.As(err, &stale) {
+		c.Header("ETag", applicationETag(stale.Current))
+		c.JSON(http.StatusPreconditionFailed, api.StaleApplicationResponse{
+			Error:   err.Error(),
+			Current: toApplicationResponse(stale.Current, time.Now()),
+		})
+		return
+	}
+	status, _ := classifyTransitionError(err)
+	if status == http.StatusInternalServerError {
+		c.JSON(status, gin.H{"error": fallback})
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
+
 // CreateApplication godoc
 // @Summary      Create a new default application
 // @Description  Create a new default application for a customer
 // @Tags         Applications
 // @Accept       json
 // @Produce      json
-// @Param        application  body      api.CreateApplicationRequest  true  "Application info"
-// @Success      201          {object}  api.ApplicationResponse
-// @Failure      400          {object}  api.ErrorResponse
-// @Failure      404          {object}  api.ErrorResponse
-// @Failure      409          {object}  api.ErrorResponse
-// @Failure      500          {object}  api.ErrorResponse
+// @Param        application      body      api.CreateApplicationRequest  true   "Application info"
+// @Param        Idempotency-Key  header    string                        false  "Key identifying the request; retries with the same key return the first response"
+// @Success      201              {object}  api.ApplicationResponse
+// @Failure      400              {object}  api.ErrorResponse
+// @Failure      404              {object}  api.ErrorResponse
+// @Failure      409              {object}  api.ErrorResponse
+// @Failure      422              {object}  api.ErrorResponse
+// @Failure      500              {object}  api.ErrorResponse
 // @Security     ApiKeyAuth
 // @Router       /applications [post]
 func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
@@ -63,15 +193,22 @@ func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
 	// 3. 调用核心业务逻辑
 	// 将通过验证的请求数据和申请人 ID 传递给 Service 层进行处理。
 	// 所有的业务规则（如检查客户是否存在、是否已有待处理申请等）都在 Service 层中执行。
-	app, err := h.appService.CreateApplication(req.CustomerName, req.Severity, req.Reason, req.Remarks, applicantID)
+	// 客户可以通过 ID、统一社会信用代码或名称指定，ID 的格式已由 binding 校验
+	ref := service.CustomerRef{CreditCode: req.CreditCode, Name: req.CustomerName}
+	if req.CustomerID != "" {
+		ref.ID, _ = uuid.Parse(req.CustomerID)
+	}
+	app, err := h.appService.CreateApplication(ref, req.Severity, req.ReasonCode, req.Reason, req.Remarks, applicantID)
 	if err != nil {
 		// 4. 精细化错误处理
 		// 根据 Service 层返回的不同错误类型，映射到不同的 HTTP 状态码，为前端提供更明确的反馈。
-		switch err.Error() {
-		case "customer not found":
+		switch {
+		case err.Error() == "customer not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()}) // 404 Not Found
-		case "customer is already in default status", "there is already a pending application for this customer":
+		case err.Error() == "customer is already in default status", err.Error() == "there is already a pending application for this customer":
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()}) // 409 Conflict，表示请求与当前服务器状态冲突
+		case errors.Is(err, service.ErrUnknownReason), errors.Is(err, service.ErrInactiveReason):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()}) // 400 Bad Request，原因编码不在目录中或已停用
 		default:
 			// 对于其他未知错误，返回通用的 500 服务器内部错误。
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create application"})
@@ -87,10 +224,11 @@ func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
 		// 注意：GORM 在 Create 后默认不会自动填充关联的 Customer 实体。
 		// CustomerName 可能为空。如果需要显示，我们应在 Service/Repository 层使用 Preload 预加载。
 		CustomerName:    app.Customer.Name,
-		Status:          app.Status,
+		Status:          string(app.Status),
 		Severity:        app.Severity,
 		ApplicantName:   app.Applicant.Username, // 同上
 		ApplicationTime: app.ApplicationTime,
+		Version:         app.Version,
 	}
 
 	// 返回 201 Created 状态码，表示资源创建成功，并在响应体中包含新创建的申请信息。
@@ -99,16 +237,23 @@ func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
 
 // ApproveApplication godoc
 // @Summary      Approve a default application
-// @Description  Approve a pending default application
+// @Description  Approve a pending default application. Depending on its severity the application may need several approvals by distinct approvers; until the last one it stays pending (202). Applicants cannot approve their own filings.
 // @Tags         Applications
 // @Accept       json
 // @Produce      json
-// @Param        approval  body      api.ApproveRequest  true  "Approval info"
-// @Success      200       {object}  api.SuccessResponse
-// @Failure      400       {object}  api.ErrorResponse
-// @Failure      404       {object}  api.ErrorResponse
-// @Failure      409       {object}  api.ErrorResponse
-// @Failure      500       {object}  api.ErrorResponse
+// @Param        approval         body      api.ApproveRequest  true   "Approval info"
+// @Param        If-Match         header    string              true   "Application ETag (version) the decision is based on"
+// @Param        Idempotency-Key  header    string              false  "Key identifying the request; retries with the same key return the first response"
+// @Success      200              {object}  api.SuccessResponse
+// @Success      202              {object}  api.ApprovalProgressResponse
+// @Failure      400              {object}  api.ErrorResponse
+// @Failure      403              {object}  api.ErrorResponse
+// @Failure      404              {object}  api.ErrorResponse
+// @Failure      409              {object}  api.ErrorResponse
+// @Failure      412              {object}  api.StaleApplicationResponse
+// @Failure      422              {object}  api.ErrorResponse
+// @Failure      428              {object}  api.ErrorResponse
+// @Failure      500              {object}  api.ErrorResponse
 // @Security     ApiKeyAuth
 // @Router       /applications/review/approve [post]
 func (h *ApplicationHandler) ApproveApplication(c *gin.Context) {
@@ -125,24 +270,26 @@ func (h *ApplicationHandler) ApproveApplication(c *gin.Context) {
 		return
 	}
 
-	// 从认证中间件的上下文中获取审核人 ID
-	approverIDVal, _ := c.Get("userID")
-	approverID, ok := approverIDVal.(uuid.UUID)
+	// 从认证中间件的上下文中获取审核人
+	actor, ok := actorFromContext(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
 		return
 	}
+	version, ok := expectedVersion(c, true)
+	if !ok {
+		return
+	}
 
-	err = h.appService.ApproveApplication(appID, approverID)
+	input := service.TransitionInput{PropagateToGroup: req.PropagateToGroup, ExpectedVersion: version}
+	app, err := h.appService.Transition(appID, core.EventApprove, actor, input)
 	if err != nil {
-		switch err.Error() {
-		case "application not found":
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		case "application is not in pending state":
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to approve application"})
-		}
+		writeTransitionError(c, err, "Failed to approve application")
+		return
+	}
+	c.Header("ETag", applicationETag(app))
+	if app.Status == core.StatusPending {
+		h.writeApprovalProgress(c, app)
 		return
 	}
 
@@ -151,16 +298,22 @@ func (h *ApplicationHandler) ApproveApplication(c *gin.Context) {
 
 // RejectApplication godoc
 // @Summary      Reject a default application
-// @Description  Reject a pending default application with a reason
+// @Description  Reject a pending default application with a reason. Applicants cannot reject their own filings.
 // @Tags         Applications
 // @Accept       json
 // @Produce      json
-// @Param        rejection  body      api.RejectRequest  true  "Rejection info"
-// @Success      200        {object}  api.SuccessResponse
-// @Failure      400        {object}  api.ErrorResponse
-// @Failure      404        {object}  api.ErrorResponse
-// @Failure      409        {object}  api.ErrorResponse
-// @Failure      500        {object}  api.ErrorResponse
+// @Param        rejection        body      api.RejectRequest  true   "Rejection info"
+// @Param        If-Match         header    string             true   "Application ETag (version) the decision is based on"
+// @Param        Idempotency-Key  header    string             false  "Key identifying the request; retries with the same key return the first response"
+// @Success      200              {object}  api.SuccessResponse
+// @Failure      400              {object}  api.ErrorResponse
+// @Failure      403              {object}  api.ErrorResponse
+// @Failure      404              {object}  api.ErrorResponse
+// @Failure      409              {object}  api.ErrorResponse
+// @Failure      412              {object}  api.StaleApplicationResponse
+// @Failure      422              {object}  api.ErrorResponse
+// @Failure      428              {object}  api.ErrorResponse
+// @Failure      500              {object}  api.ErrorResponse
 // @Security     ApiKeyAuth
 // @Router       /applications/review/reject [post]
 func (h *ApplicationHandler) RejectApplication(c *gin.Context) {
@@ -171,37 +324,125 @@ func (h *ApplicationHandler) RejectApplication(c *gin.Context) {
 	}
 
 	appID, _ := uuid.Parse(req.ApplicationID)
-	approverIDVal, _ := c.Get("userID")
-	approverID := approverIDVal.(uuid.UUID)
+	actor, _ := actorFromContext(c)
+	version, ok := expectedVersion(c, true)
+	if !ok {
+		return
+	}
 
-	err := h.appService.RejectApplication(appID, approverID, req.RejectionReason)
+	input := service.TransitionInput{Reason: req.RejectionReason, ExpectedVersion: version}
+	app, err := h.appService.Transition(appID, core.EventReject, actor, input)
 	if err != nil {
 		// 错误处理逻辑与 Approve 类似
-		switch err.Error() {
-		case "application not found":
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		case "application is not in pending state":
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reject application"})
-		}
+		writeTransitionError(c, err, "Failed to reject application")
 		return
 	}
 
+	c.Header("ETag", applicationETag(app))
+
 	c.JSON(http.StatusOK, gin.H{"message": "Application rejected successfully"})
 }
 
+// BatchReview godoc
+// @Summary      Approve or reject applications in batch
+// @Description  Apply approve/reject decisions to up to 100 applications. Each item runs in its own transaction under the same rules as the single review endpoints, so one failing item does not affect the others. Each item carries the application version it was decided on, like If-Match on the single endpoints. The response reports the outcome of every item in request order.
+// @Tags         Applications
+// @Accept       json
+// @Produce      json
+// @Param        batch            body      api.BatchReviewRequest  true   "Review decisions"
+// @Param        Idempotency-Key  header    string                  false  "Key identifying the request; retries with the same key return the first response"
+// @Success      200              {object}  api.BatchReviewResponse
+// @Failure      400              {object}  api.ErrorResponse
+// @Failure      409              {object}  api.ErrorResponse
+// @Failure      422              {object}  api.ErrorResponse
+// @Failure      500              {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/review/batch [post]
+func (h *ApplicationHandler) BatchReview(c *gin.Context) {
+	var req api.BatchReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	decisions := make([]service.ReviewDecision, 0, len(req.Items))
+	for _, item := range req.Items {
+		appID, _ := uuid.Parse(item.ApplicationID) // 格式已由 binding 校验
+		decision := service.ReviewDecision{ApplicationID: appID, Event: core.EventApprove}
+		decision.Input.ExpectedVersion = item.Version
+		if item.Decision == "reject" {
+			decision.Event = core.EventReject
+			decision.Input.Reason = item.Reason
+		} else {
+			decision.Input.PropagateToGroup = item.PropagateToGroup
+		}
+		decisions = append(decisions, decision)
+	}
+
+	results := h.appService.ReviewBatch(decisions, actor)
+
+	res := api.BatchReviewResponse{Results: make([]api.BatchReviewResult, 0, len(results))}
+	for i, result := range results {
+		item := api.BatchReviewResult{
+			ApplicationID: result.ApplicationID.String(),
+			Decision:      req.Items[i].Decision,
+		}
+		if result.Err != nil {
+			_, item.ErrorCode = classifyTransitionError(result.Err)
+			item.Error = result.Err.Error()
+			if item.ErrorCode == "internal_error" {
+				item.Error = "Failed to " + item.Decision + " application"
+			}
+			var stale *service.StaleApplicationError
+			if errors.As(result.Err, &stale) {
+				item.Version = stale.Current.Version
+			}
+			res.Failed++
+		} else {
+			item.Success = true
+			item.Status = string(result.Application.Status)
+			item.Version = result.Application.Version
+			if result.Application.Status == core.StatusPending {
+				item.Approvals = len(result.Application.ApprovalSteps)
+				item.RequiredApprovals = h.appService.RequiredApprovals(result.Application)
+			}
+			res.Succeeded++
+		}
+		res.Results = append(res.Results, item)
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
 // GetPendingApplications godoc
 // @Summary      Get pending applications
-// @Description  Get a list of all pending default applications for approval
+// @Description  Get the pending default applications for approval, oldest first. view=mine lists the applications assigned to the current approver, view=unassigned those not assigned to anyone. Each item carries its SLA due date and an overdue flag.
 // @Tags         Applications
 // @Produce      json
-// @Success      200  {array}   api.ApplicationResponse
-// @Failure      500  {object}  api.ErrorResponse
+// @Param        view  query     string  false  "Worklist view"  Enums(all, mine, unassigned)  default(all)
+// @Success      200   {array}   api.ApplicationResponse
+// @Failure      400   {object}  api.ErrorResponse
+// @Failure      500   {object}  api.ErrorResponse
 // @Security     ApiKeyAuth
 // @Router       /applications/pending [get]
 func (h *ApplicationHandler) GetPendingApplications(c *gin.Context) {
-	apps, err := h.appService.GetPendingApplications()
+	view := service.PendingView(c.DefaultQuery("view", string(service.PendingViewAll)))
+	if view != service.PendingViewAll && view != service.PendingViewMine && view != service.PendingViewUnassigned {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "view must be one of all, mine, unassigned"})
+		return
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	apps, err := h.appService.GetPendingApplications(view, actor)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve pending applications"})
 		return
@@ -209,15 +450,10 @@ func (h *ApplicationHandler) GetPendingApplications(c *gin.Context) {
 
 	// 将数据库模型列表映射到 API DTO 列表
 	var res []api.ApplicationResponse
+	now := time.Now()
 	for _, app := range apps {
-		res = append(res, api.ApplicationResponse{
-			ID:              app.ID.String(),
-			CustomerName:    app.Customer.Name, // 因为 Service->Repo 预加载了，这里可以直接用
-			Status:          app.Status,
-			Severity:        app.Severity,
-			ApplicantName:   app.Applicant.Username, // 同上
-			ApplicationTime: app.ApplicationTime,
-		})
+		// Customer / Applicant / Assignee 已由 Service->Repo 预加载
+		res = append(res, toApplicationResponse(&app, now))
 	}
 
 	c.JSON(http.StatusOK, res)
@@ -231,12 +467,18 @@ func (h *ApplicationHandler) GetPendingApplications(c *gin.Context) {
 // @Tags         Applications
 // @Accept       json
 // @Produce      json
-// @Param        rebirth_apply  body      api.RebirthApplyRequest  true  "Rebirth apply info"
-// @Success      200            {object}  api.SuccessResponse
-// @Failure      400            {object}  api.ErrorResponse
-// @Failure      404            {object}  api.ErrorResponse
-// @Failure      409            {object}  api.ErrorResponse
-// @Failure      500            {object}  api.ErrorResponse
+// @Param        rebirth_apply    body      api.RebirthApplyRequest  true   "Rebirth apply info"
+// @Param        If-Match         header    string                   true   "Application ETag (version) the decision is based on"
+// @Param        Idempotency-Key  header    string                   false  "Key identifying the request; retries with the same key return the first response"
+// @Success      200              {object}  api.SuccessResponse
+// @Failure      400              {object}  api.ErrorResponse
+// @Failure      403              {object}  api.ErrorResponse
+// @Failure      404              {object}  api.ErrorResponse
+// @Failure      409              {object}  api.ErrorResponse
+// @Failure      412              {object}  api.StaleApplicationResponse
+// @Failure      422              {object}  api.ErrorResponse
+// @Failure      428              {object}  api.ErrorResponse
+// @Failure      500              {object}  api.ErrorResponse
 // @Security     ApiKeyAuth
 // @Router       /applications/rebirth/apply [post]
 func (h *ApplicationHandler) ApplyForRebirth(c *gin.Context) {
@@ -252,43 +494,47 @@ func (h *ApplicationHandler) ApplyForRebirth(c *gin.Context) {
 		return
 	}
 
-	applicantIDVal, _ := c.Get("userID")
-	applicantID, ok := applicantIDVal.(uuid.UUID)
+	actor, ok := actorFromContext(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
 		return
 	}
 
-	err = h.appService.ApplyForRebirth(appID, applicantID, req.RebirthReason)
+	version, ok := expectedVersion(c, true)
+	if !ok {
+		return
+	}
+
+	input := service.TransitionInput{ReasonCode: req.ReasonCode, ExpectedVersion: version}
+	app, err := h.appService.Transition(appID, core.EventApplyRebirth, actor, input)
 	if err != nil {
-		// 根据 Service 返回的错误信息，返回不同的 HTTP 状态码
-		switch err.Error() {
-		case "application not found":
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		case "only approved applications can apply for rebirth":
-			// 409 Conflict 表示请求与服务器当前状态冲突
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-		default:
-			// 对于其他未知错误，返回 500 服务器内部错误
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit rebirth application"})
-		}
+		writeTransitionError(c, err, "Failed to submit rebirth application")
 		return
 	}
 
+	c.Header("ETag", applicationETag(app))
+
 	c.JSON(http.StatusOK, gin.H{"message": "Rebirth application submitted successfully"})
 }
 
 // ApproveRebirth godoc
 // @Summary      Approve a rebirth application
-// @Description  Approve a pending rebirth application
+// @Description  Approve a pending rebirth application. Multi-level approval and the four-eyes rule apply as for default approval.
 // @Tags         Applications
 // @Accept       json
 // @Produce      json
-// @Param        rebirth_approve  body      api.RebirthApproveRequest  true  "Rebirth approve info"
+// @Param        rebirth_approve  body      api.RebirthApproveRequest  true   "Rebirth approve info"
+// @Param        If-Match         header    string                     true   "Application ETag (version) the decision is based on"
+// @Param        Idempotency-Key  header    string                     false  "Key identifying the request; retries with the same key return the first response"
 // @Success      200              {object}  api.SuccessResponse
+// @Success      202              {object}  api.ApprovalProgressResponse
 // @Failure      400              {object}  api.ErrorResponse
+// @Failure      403              {object}  api.ErrorResponse
 // @Failure      404              {object}  api.ErrorResponse
 // @Failure      409              {object}  api.ErrorResponse
+// @Failure      412              {object}  api.StaleApplicationResponse
+// @Failure      422              {object}  api.ErrorResponse
+// @Failure      428              {object}  api.ErrorResponse
 // @Failure      500              {object}  api.ErrorResponse
 // @Security     ApiKeyAuth
 // @Router       /applications/rebirth/approve [post]
@@ -305,25 +551,309 @@ func (h *ApplicationHandler) ApproveRebirth(c *gin.Context) {
 		return
 	}
 
-	approverIDVal, _ := c.Get("userID")
-	approverID, ok := approverIDVal.(uuid.UUID)
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	version, ok := expectedVersion(c, true)
+	if !ok {
+		return
+	}
+
+	app, err := h.appService.Transition(appID, core.EventApproveRebirth, actor, service.TransitionInput{ExpectedVersion: version})
+	if err != nil {
+		writeTransitionError(c, err, "Failed to approve rebirth")
+		return
+	}
+	c.Header("ETag", applicationETag(app))
+	if app.Status == core.StatusRebirthPending {
+		h.writeApprovalProgress(c, app)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Rebirth approved successfully"})
+}
+
+// RejectRebirth godoc
+// @Summary      Reject a rebirth application
+// @Description  Reject a pending rebirth application with a reason. The application returns to approved, the customer stays in default and the rejected rebirth is kept as history. The rebirth applicant cannot reject their own rebirth.
+// @Tags         Applications
+// @Accept       json
+// @Produce      json
+// @Param        rebirth_reject   body      api.RebirthRejectRequest  true   "Rebirth reject info"
+// @Param        If-Match         header    string                    true   "Application ETag (version) the decision is based on"
+// @Param        Idempotency-Key  header    string                    false  "Key identifying the request; retries with the same key return the first response"
+// @Success      200              {object}  api.SuccessResponse
+// @Failure      400              {object}  api.ErrorResponse
+// @Failure      403              {object}  api.ErrorResponse
+// @Failure      404              {object}  api.ErrorResponse
+// @Failure      409              {object}  api.ErrorResponse
+// @Failure      412              {object}  api.StaleApplicationResponse
+// @Failure      422              {object}  api.ErrorResponse
+// @Failure      428              {object}  api.ErrorResponse
+// @Failure      500              {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/rebirth/reject [post]
+func (h *ApplicationHandler) RejectRebirth(c *gin.Context) {
+	var req api.RebirthRejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	appID, _ := uuid.Parse(req.ApplicationID) // 格式已由 binding 校验
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	version, ok := expectedVersion(c, true)
+	if !ok {
+		return
+	}
+
+	input := service.TransitionInput{Reason: req.RejectionReason, ExpectedVersion: version}
+	app, err := h.appService.Transition(appID, core.EventRejectRebirth, actor, input)
+	if err != nil {
+		writeTransitionError(c, err, "Failed to reject rebirth")
+		return
+	}
+
+	c.Header("ETag", applicationETag(app))
+
+	c.JSON(http.StatusOK, gin.H{"message": "Rebirth rejected successfully"})
+}
+
+// ReturnApplication godoc
+// @Summary      Return an application for more information
+// @Description  Send a pending application back to the applicant with questions. The applicant amends and resubmits it as a new round. Applicants cannot return their own filings.
+// @Tags         Applications
+// @Accept       json
+// @Produce      json
+// @Param        return           body      api.ReturnRequest  true   "Return info"
+// @Param        If-Match         header    string             true   "Application ETag (version) the decision is based on"
+// @Param        Idempotency-Key  header    string             false  "Key identifying the request; retries with the same key return the first response"
+// @Success      200              {object}  api.SuccessResponse
+// @Failure      400              {object}  api.ErrorResponse
+// @Failure      403              {object}  api.ErrorResponse
+// @Failure      404              {object}  api.ErrorResponse
+// @Failure      409              {object}  api.ErrorResponse
+// @Failure      412              {object}  api.StaleApplicationResponse
+// @Failure      422              {object}  api.ErrorResponse
+// @Failure      428              {object}  api.ErrorResponse
+// @Failure      500              {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/review/return [post]
+func (h *ApplicationHandler) ReturnApplication(c *gin.Context) {
+	var req api.ReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	appID, _ := uuid.Parse(req.ApplicationID) // 格式已由 binding 校验
+	actor, ok := actorFromContext(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
 		return
 	}
 
-	err = h.appService.ApproveRebirth(appID, approverID)
+	version, ok := expectedVersion(c, true)
+	if !ok {
+		return
+	}
+
+	input := service.TransitionInput{Questions: req.Questions, ExpectedVersion: version}
+	app, err := h.appService.Transition(appID, core.EventReturn, actor, input)
 	if err != nil {
-		switch err.Error() {
-		case "application not found":
+		writeTransitionError(c, err, "Failed to return application")
+		return
+	}
+
+	c.Header("ETag", applicationETag(app))
+
+	c.JSON(http.StatusOK, gin.H{"message": "Application returned to the applicant"})
+}
+
+// ResubmitApplication godoc
+// @Summary      Resubmit a returned application
+// @Description  The applicant amends the reason, remarks or severity of a returned application and resubmits it for review as a new round
+// @Tags         Applications
+// @Accept       json
+// @Produce      json
+// @Param        resubmit         body      api.ResubmitRequest  true   "Amendments"
+// @Param        If-Match         header    string               false  "Application ETag (version), checked when present"
+// @Param        Idempotency-Key  header    string               false  "Key identifying the request; retries with the same key return the first response"
+// @Success      200              {object}  api.SuccessResponse
+// @Failure      400              {object}  api.ErrorResponse
+// @Failure      403              {object}  api.ErrorResponse
+// @Failure      404              {object}  api.ErrorResponse
+// @Failure      409              {object}  api.ErrorResponse
+// @Failure      412              {object}  api.StaleApplicationResponse
+// @Failure      422              {object}  api.ErrorResponse
+// @Failure      500              {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/resubmit [post]
+func (h *ApplicationHandler) ResubmitApplication(c *gin.Context) {
+	var req api.ResubmitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	appID, _ := uuid.Parse(req.ApplicationID)
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	// 申请人修改自己的申请，If-Match 可选
+	version, ok := expectedVersion(c, false)
+	if !ok {
+		return
+	}
+
+	input := service.TransitionInput{Amendment: service.ApplicationAmendment{
+		Severity:          req.Severity,
+		DefaultReasonCode: req.ReasonCode,
+		DefaultReason:     req.Reason,
+		Remarks:           req.Remarks,
+	}, ExpectedVersion: version}
+	app, err := h.appService.Transition(appID, core.EventResubmit, actor, input)
+	if err != nil {
+		writeTransitionError(c, err, "Failed to resubmit application")
+		return
+	}
+
+	c.Header("ETag", applicationETag(app))
+
+	c.JSON(http.StatusOK, gin.H{"message": "Application resubmitted successfully"})
+}
+
+// GetApplicationRounds godoc
+// @Summary      Get application rounds
+// @Description  Get every submitted round of an application with the approver's questions and the fields changed since the previous round. Applicants can only see applications they submitted or requested rebirth for.
+// @Tags         Applications
+// @Produce      json
+// @Param        id   path      string  true  "Application ID"
+// @Success      200  {array}   api.ApplicationRoundResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/{id}/rounds [get]
+func (h *ApplicationHandler) GetApplicationRounds(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID format"})
+		return
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	rounds, err := h.appService.GetRounds(appID, actor)
+	if err != nil {
+		if err.Error() == "application not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		case "application is not pending for rebirth approval":
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to approve rebirth"})
+			return
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve application rounds"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Rebirth approved successfully"})
+	res := make([]api.ApplicationRoundResponse, 0, len(rounds))
+	for _, round := range rounds {
+		item := api.ApplicationRoundResponse{
+			Round:         round.Round,
+			Severity:      round.Severity,
+			ReasonCode:    round.DefaultReasonCode,
+			DefaultReason: round.DefaultReason,
+			Remarks:       round.Remarks,
+			SubmittedAt:   round.SubmittedAt,
+			Questions:     round.Questions,
+			ReturnedAt:    round.ReturnedAt,
+		}
+		if round.ReturnedBy != nil {
+			item.ReturnedBy = round.ReturnedBy.Username
+		}
+		for _, change := range round.Changes {
+			item.Changes = append(item.Changes, api.FieldChangeResponse{Field: change.Field, From: change.From, To: change.To})
+		}
+		res = append(res, item)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// WithdrawApplication godoc
+// @Summary      Withdraw an application
+// @Description  The original applicant withdraws a pending default application, or a pending rebirth which returns the application to approved
+// @Tags         Applications
+// @Accept       json
+// @Produce      json
+// @Param        withdrawal       body      api.WithdrawRequest  true   "Withdrawal info"
+// @Param        If-Match         header    string               false  "Application ETag (version), checked when present"
+// @Param        Idempotency-Key  header    string               false  "Key identifying the request; retries with the same key return the first response"
+// @Success      200              {object}  api.SuccessResponse
+// @Failure      400              {object}  api.ErrorResponse
+// @Failure      403              {object}  api.ErrorResponse
+// @Failure      404              {object}  api.ErrorResponse
+// @Failure      409              {object}  api.ErrorResponse
+// @Failure      412              {object}  api.StaleApplicationResponse
+// @Failure      422              {object}  api.ErrorResponse
+// @Failure      500              {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/withdraw [post]
+func (h *ApplicationHandler) WithdrawApplication(c *gin.Context) {
+	var req api.WithdrawRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	appID, _ := uuid.Parse(req.ApplicationID) // 格式已由 binding 校验
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	// 申请人撤回自己的申请，If-Match 可选
+	version, ok := expectedVersion(c, false)
+	if !ok {
+		return
+	}
+
+	input := service.TransitionInput{Reason: req.Reason, ExpectedVersion: version}
+	app, err := h.appService.Transition(appID, core.EventWithdraw, actor, input)
+	if err != nil {
+		writeTransitionError(c, err, "Failed to withdraw application")
+		return
+	}
+
+	c.Header("ETag", applicationETag(app))
+
+	if app.Status == core.StatusApproved {
+		c.JSON(http.StatusOK, gin.H{"message": "Rebirth application withdrawn successfully"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Application withdrawn successfully"})
+}
+
+// GetStateGraph godoc
+// @Summary      Get the application state machine
+// @Description  Get the default application state machine as a Mermaid stateDiagram, including the roles allowed to perform each transition
+// @Tags         Applications
+// @Produce      plain
+// @Success      200  {string}  string
+// @Security     ApiKeyAuth
+// @Router       /applications/state-machine [get]
+func (h *ApplicationHandler) GetStateGraph(c *gin.Context) {
+	c.String(http.StatusOK, h.appService.StateGraph())
 }
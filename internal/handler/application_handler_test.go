@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// batchReviewStub 只实现批量审核用到的方法 (mocks 包依赖 service 包，不能为 ApplicationService 生成 mock)
+type batchReviewStub struct {
+	service.ApplicationService
+	decisions []service.ReviewDecision
+	results   []service.ReviewResult
+}
+
+func (s *batchReviewStub) ReviewBatch(decisions []service.ReviewDecision, actor service.Actor) []service.ReviewResult {
+	s.decisions = decisions
+	return s.results
+}
+
+func (s *batchReviewStub) RequiredApprovals(app *core.DefaultApplication) int {
+	return 2
+}
+
+func TestApplicationHandler_BatchReview(t *testing.T) {
+	rejected, partial, missing, conflict, failing, stale := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	stub := &batchReviewStub{results: []service.ReviewResult{
+		{ApplicationID: rejected, Application: &core.DefaultApplication{Status: core.StatusRejected, Version: 2}},
+		{ApplicationID: partial, Application: &core.DefaultApplication{Status: core.StatusPending, Version: 2, ApprovalSteps: []core.ApprovalStep{{Level: 1}}}},
+		{ApplicationID: missing, Err: errors.New("application not found")},
+		{ApplicationID: conflict, Err: &service.InvalidTransitionError{From: core.StatusApproved, Event: core.EventApprove}},
+		{ApplicationID: failing, Err: errors.New("connection reset")},
+		{ApplicationID: stale, Err: &service.StaleApplicationError{Current: &core.DefaultApplication{Status: core.StatusPending, Version: 4}}},
+	}}
+	h := NewApplicationHandler(stub)
+
+	router := setupRouter()
+	router.POST("/review/batch", func(c *gin.Context) {
+		c.Set("userID", uuid.New())
+		c.Set("role", core.RoleApprover)
+		h.BatchReview(c)
+	})
+
+	t.Run("reports the outcome of every item", func(t *testing.T) {
+		body, _ := json.Marshal(api.BatchReviewRequest{Items: []api.BatchReviewItem{
+			{ApplicationID: rejected.String(), Decision: "reject", Reason: "材料不足", Version: 1},
+			{ApplicationID: partial.String(), Decision: "approve", PropagateToGroup: true, Version: 1},
+			{ApplicationID: missing.String(), Decision: "approve", Version: 1},
+			{ApplicationID: conflict.String(), Decision: "approve", Version: 1},
+			{ApplicationID: failing.String(), Decision: "approve", Version: 1},
+			{ApplicationID: stale.String(), Decision: "approve", Version: 3},
+		}})
+		req, _ := http.NewRequest(http.MethodPost, "/review/batch", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res api.BatchReviewResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, 2, res.Succeeded)
+		assert.Equal(t, 4, res.Failed)
+		if assert.Len(t, res.Results, 6) {
+			assert.Equal(t, api.BatchReviewResult{ApplicationID: rejected.String(), Decision: "reject", Success: true, Status: "Rejected", Version: 2}, res.Results[0])
+			assert.Equal(t, 1, res.Results[1].Approvals)
+			assert.Equal(t, 2, res.Results[1].RequiredApprovals)
+			assert.Equal(t, "not_found", res.Results[2].ErrorCode)
+			assert.Equal(t, "invalid_transition", res.Results[3].ErrorCode)
+			assert.Equal(t, "internal_error", res.Results[4].ErrorCode)
+			assert.Equal(t, "Failed to approve application", res.Results[4].Error)
+			assert.Equal(t, "precondition_failed", res.Results[5].ErrorCode)
+			assert.Equal(t, 4, res.Results[5].Version)
+		}
+
+		if assert.Len(t, stub.decisions, 6) {
+			assert.Equal(t, core.EventReject, stub.decisions[0].Event)
+			assert.Equal(t, "材料不足", stub.decisions[0].Input.Reason)
+			assert.Equal(t, core.EventApprove, stub.decisions[1].Event)
+			assert.True(t, stub.decisions[1].Input.PropagateToGroup)
+			assert.Equal(t, 3, stub.decisions[5].Input.ExpectedVersion)
+		}
+	})
+
+	t.Run("every item requires the version", func(t *testing.T) {
+		body, _ := json.Marshal(api.BatchReviewRequest{Items: []api.BatchReviewItem{
+			{ApplicationID: partial.String(), Decision: "approve"},
+		}})
+		req, _ := http.NewRequest(http.MethodPost, "/review/batch", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("reject requires a reason", func(t *testing.T) {
+		body, _ := json.Marshal(api.BatchReviewRequest{Items: []api.BatchReviewItem{
+			{ApplicationID: rejected.String(), Decision: "reject", Version: 1},
+		}})
+		req, _ := http.NewRequest(http.MethodPost, "/review/batch", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// transitionStub 只实现单个审核用到的方法，记录收到的输入并返回预设的结果
+type transitionStub struct {
+	service.ApplicationService
+	input service.TransitionInput
+	app   *core.DefaultApplication
+	err   error
+}
+
+func (s *transitionStub) Transition(appID uuid.UUID, event core.ApplicationEvent, actor service.Actor, input service.TransitionInput) (*core.DefaultApplication, error) {
+	s.input = input
+	return s.app, s.err
+}
+
+func TestApplicationHandler_RejectApplication_IfMatch(t *testing.T) {
+	appID := uuid.New()
+	body, _ := json.Marshal(api.RejectRequest{ApplicationID: appID.String(), RejectionReason: "材料不足"})
+	send := func(stub *transitionStub, ifMatch string) *httptest.ResponseRecorder {
+		h := NewApplicationHandler(stub)
+		router := setupRouter()
+		router.POST("/review/reject", func(c *gin.Context) {
+			c.Set("userID", uuid.New())
+			c.Set("role", core.RoleApprover)
+			h.RejectApplication(c)
+		})
+		req, _ := http.NewRequest(http.MethodPost, "/review/reject", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("passes the version and returns the new ETag", func(t *testing.T) {
+		stub := &transitionStub{app: &core.DefaultApplication{Status: core.StatusRejected, Version: 4}}
+
+		w := send(stub, `W/"3"`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 3, stub.input.ExpectedVersion)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("missing If-Match", func(t *testing.T) {
+		w := send(&transitionStub{}, "")
+
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	})
+
+	t.Run("malformed If-Match", func(t *testing.T) {
+		w := send(&transitionStub{}, `"abc"`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("stale version returns the current state", func(t *testing.T) {
+		current := &core.DefaultApplication{BaseModel: core.BaseModel{ID: appID}, Status: core.StatusApproved, Version: 5}
+		stub := &transitionStub{err: &service.StaleApplicationError{Current: current}}
+
+		w := send(stub, `"3"`)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+		var res api.StaleApplicationResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, appID.String(), res.Current.ID)
+		assert.Equal(t, "Approved", res.Current.Status)
+		assert.Equal(t, 5, res.Current.Version)
+	})
+}
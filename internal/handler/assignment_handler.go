@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AssignmentHandler 封装了审批人分派规则和申请改派相关的 HTTP 请求处理器。
+type AssignmentHandler struct {
+	assignmentService service.AssignmentService
+}
+
+// NewAssignmentHandler 是 AssignmentHandler 的构造函数。
+func NewAssignmentHandler(assignmentService service.AssignmentService) *AssignmentHandler {
+	return &AssignmentHandler{assignmentService: assignmentService}
+}
+
+// toRoutingRuleResponse 将分派规则映射为响应 DTO
+func toRoutingRuleResponse(rule *core.RoutingRule) api.RoutingRuleResponse {
+	return api.RoutingRuleResponse{
+		ID:           rule.ID.String(),
+		Priority:     rule.Priority,
+		Region:       rule.Region,
+		Industry:     rule.Industry,
+		ApproverID:   rule.ApproverID.String(),
+		ApproverName: rule.Approver.Username,
+	}
+}
+
+// ListRoutingRules godoc
+// @Summary      List routing rules
+// @Description  List the rules that assign new applications to approvers, in matching order
+// @Tags         Assignment
+// @Produce      json
+// @Success      200  {array}   api.RoutingRuleResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /routing-rules [get]
+func (h *AssignmentHandler) ListRoutingRules(c *gin.Context) {
+	rules, err := h.assignmentService.ListRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve routing rules"})
+		return
+	}
+
+	res := make([]api.RoutingRuleResponse, 0, len(rules))
+	for i := range rules {
+		res = append(res, toRoutingRuleResponse(&rules[i]))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateRoutingRule godoc
+// @Summary      Create a routing rule
+// @Description  Route applications of customers in a region and/or industry to an approver. Rules are matched by ascending priority; applications matching no rule are assigned round-robin to the least busy approver.
+// @Tags         Assignment
+// @Accept       json
+// @Produce      json
+// @Param        rule  body      api.RoutingRuleRequest  true  "Routing rule"
+// @Success      201   {object}  api.RoutingRuleResponse
+// @Failure      400   {object}  api.ErrorResponse
+// @Failure      404   {object}  api.ErrorResponse
+// @Failure      500   {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /routing-rules [post]
+func (h *AssignmentHandler) CreateRoutingRule(c *gin.Context) {
+	var req api.RoutingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	approverID, _ := uuid.Parse(req.ApproverID) // 格式已由 binding 校验
+
+	rule, err := h.assignmentService.CreateRule(req.Priority, req.Region, req.Industry, approverID)
+	if err != nil {
+		if err.Error() == "approver not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create routing rule"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRoutingRuleResponse(rule))
+}
+
+// DeleteRoutingRule godoc
+// @Summary      Delete a routing rule
+// @Description  Remove a routing rule. Applications already assigned keep their assignee.
+// @Tags         Assignment
+// @Produce      json
+// @Param        id   path      string  true  "Routing rule ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /routing-rules/{id} [delete]
+func (h *AssignmentHandler) DeleteRoutingRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid routing rule ID format"})
+		return
+	}
+
+	if err := h.assignmentService.DeleteRule(id); err != nil {
+		if err.Error() == "routing rule not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete routing rule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Routing rule deleted successfully"})
+}
+
+// AssignApplication godoc
+// @Summary      Reassign an application
+// @Description  Assign an application under review to another approver. The applicant cannot be assigned their own application.
+// @Tags         Assignment
+// @Accept       json
+// @Produce      json
+// @Param        assignment  body      api.AssignRequest  true  "Application and new assignee"
+// @Success      200         {object}  api.SuccessResponse
+// @Failure      400         {object}  api.ErrorResponse
+// @Failure      404         {object}  api.ErrorResponse
+// @Failure      409         {object}  api.ErrorResponse
+// @Failure      412         {object}  api.StaleApplicationResponse
+// @Failure      500         {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/assign [post]
+func (h *AssignmentHandler) AssignApplication(c *gin.Context) {
+	var req api.AssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	appID, _ := uuid.Parse(req.ApplicationID)
+	assigneeID, _ := uuid.Parse(req.AssigneeID)
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	if _, err := h.assignmentService.Reassign(appID, assigneeID, actor); err != nil {
+		var stale *service.StaleApplicationError
+		if errors.As(err, &stale) {
+			// 改派期间申请被审批人并发处理
+			writeTransitionError(c, err, "Failed to assign application")
+			return
+		}
+		switch err.Error() {
+		case "application not found", "approver not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "only applications under review can be reassigned", "cannot assign an application to its own applicant":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign application"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Application assigned successfully"})
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// multipartOverhead 是上传请求中除文件内容外的部分 (multipart 边界、表单字段) 允许的字节数
+const multipartOverhead int64 = 1 << 20
+
+// AttachmentHandler 封装了违约申请证据附件相关的 HTTP 请求处理器。
+type AttachmentHandler struct {
+	attachmentService service.AttachmentService
+}
+
+// NewAttachmentHandler 是 AttachmentHandler 的构造函数。
+func NewAttachmentHandler(attachmentService service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService}
+}
+
+// toAttachmentResponse 将附件元数据映射为响应 DTO
+func toAttachmentResponse(attachment *core.Attachment) api.AttachmentResponse {
+	return api.AttachmentResponse{
+		ID:            attachment.ID.String(),
+		ApplicationID: attachment.ApplicationID.String(),
+		Purpose:       string(attachment.Purpose),
+		FileName:      attachment.FileName,
+		ContentType:   attachment.ContentType,
+		Size:          attachment.Size,
+		SHA256:        attachment.SHA256,
+		UploadedBy:    attachment.UploadedBy.Username,
+		UploadedAt:    attachment.CreatedAt,
+	}
+}
+
+// writeAttachmentError 将 Service 层返回的业务错误映射为 HTTP 状态码
+func writeAttachmentError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "application not found", "attachment not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "attachment already exists", "attachments of this purpose cannot be added in the current application status":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case "attachment exceeds the size limit":
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case "attachment type is not allowed":
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case "attachment is empty":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// UploadAttachment godoc
+// @Summary      Upload an evidence attachment
+// @Description  Attach a supporting document to an application. Default evidence can be added while the application is Pending or Returned, rebirth evidence while it is Approved or RebirthPending. The file type is detected from its content; PDF, images, Office documents and plain text are accepted.
+// @Tags         Attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id       path      string  true   "Application ID"
+// @Param        file     formData  file    true   "Attachment file"
+// @Param        purpose  formData  string  false  "Default (default) or Rebirth"
+// @Success      201      {object}  api.AttachmentResponse
+// @Failure      400      {object}  api.ErrorResponse
+// @Failure      404      {object}  api.ErrorResponse
+// @Failure      409      {object}  api.ErrorResponse
+// @Failure      413      {object}  api.ErrorResponse
+// @Failure      415      {object}  api.ErrorResponse
+// @Failure      500      {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID format"})
+		return
+	}
+
+	// 在解析 multipart 请求体之前限制其大小：FormFile 会读完整个请求体 (超出内存上限的部分写入临时文件)，
+	// 只靠 Service 层的大小检查无法保护内存和磁盘
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.attachmentService.MaxSize()+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "attachment exceeds the size limit"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Attachment is required in the 'file' form field"})
+		return
+	}
+
+	purpose := core.AttachmentPurpose(c.DefaultPostForm("purpose", string(core.AttachmentPurposeDefault)))
+	if purpose != core.AttachmentPurposeDefault && purpose != core.AttachmentPurposeRebirth {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "purpose must be Default or Rebirth"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	attachment, err := h.attachmentService.Upload(appID, purpose, fileHeader.Filename, file, actor)
+	if err != nil {
+		writeAttachmentError(c, err, "Failed to upload attachment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toAttachmentResponse(attachment))
+}
+
+// ListAttachments godoc
+// @Summary      List evidence attachments
+// @Description  List the attachments of an application the current user can see
+// @Tags         Attachments
+// @Produce      json
+// @Param        id   path      string  true  "Application ID"
+// @Success      200  {array}   api.AttachmentResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/{id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID format"})
+		return
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	attachments, err := h.attachmentService.List(appID, actor)
+	if err != nil {
+		writeAttachmentError(c, err, "Failed to retrieve attachments")
+		return
+	}
+
+	res := make([]api.AttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		res = append(res, toAttachmentResponse(&attachments[i]))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// DownloadAttachment godoc
+// @Summary      Download an evidence attachment
+// @Description  Download the content of an attachment. The X-Content-SHA256 header carries the checksum recorded at upload time.
+// @Tags         Attachments
+// @Produce      octet-stream
+// @Param        id             path      string  true  "Application ID"
+// @Param        attachment_id  path      string  true  "Attachment ID"
+// @Success      200            {file}    file
+// @Failure      400            {object}  api.ErrorResponse
+// @Failure      404            {object}  api.ErrorResponse
+// @Failure      500            {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/{id}/attachments/{attachment_id} [get]
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID format"})
+		return
+	}
+	attachmentID, err := uuid.Parse(c.Param("attachment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID format"})
+		return
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	attachment, content, err := h.attachmentService.Open(appID, attachmentID, actor)
+	if err != nil {
+		writeAttachmentError(c, err, "Failed to download attachment")
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-SHA256":       attachment.SHA256,
+		"X-Content-Type-Options": "nosniff",
+	})
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// uploadStub 只实现上传用到的方法，记录是否调用了 Upload
+type uploadStub struct {
+	service.AttachmentService
+	maxSize  int64
+	uploaded bool
+}
+
+func (s *uploadStub) MaxSize() int64 {
+	return s.maxSize
+}
+
+func (s *uploadStub) Upload(appID uuid.UUID, purpose core.AttachmentPurpose, fileName string, r io.Reader, actor service.Actor) (*core.Attachment, error) {
+	s.uploaded = true
+	return &core.Attachment{ApplicationID: appID, Purpose: purpose, FileName: fileName, UploadedBy: core.User{Username: "alice"}}, nil
+}
+
+func TestAttachmentHandler_UploadAttachment_SizeLimit(t *testing.T) {
+	send := func(stub *uploadStub, content []byte) *httptest.ResponseRecorder {
+		h := NewAttachmentHandler(stub)
+		router := setupRouter()
+		router.POST("/applications/:id/attachments", func(c *gin.Context) {
+			c.Set("userID", uuid.New())
+			c.Set("role", core.RoleApplicant)
+			h.UploadAttachment(c)
+		})
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, _ := writer.CreateFormFile("file", "evidence.pdf")
+		part.Write(content)
+		writer.Close()
+		req, _ := http.NewRequest(http.MethodPost, "/applications/"+uuid.New().String()+"/attachments", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("body within the limit reaches the service", func(t *testing.T) {
+		stub := &uploadStub{maxSize: 1024}
+
+		w := send(stub, []byte("%PDF-1.4 evidence"))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.True(t, stub.uploaded)
+	})
+
+	t.Run("oversized body is rejected before parsing completes", func(t *testing.T) {
+		stub := &uploadStub{maxSize: 1024}
+
+		w := send(stub, bytes.Repeat([]byte("x"), int(stub.maxSize+multipartOverhead)))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.False(t, stub.uploaded)
+	})
+}
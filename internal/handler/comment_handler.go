@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"net/http"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CommentHandler 封装了违约申请讨论评论相关的 HTTP 请求处理器。
+type CommentHandler struct {
+	commentService service.CommentService
+}
+
+// NewCommentHandler 是 CommentHandler 的构造函数。
+func NewCommentHandler(commentService service.CommentService) *CommentHandler {
+	return &CommentHandler{commentService: commentService}
+}
+
+// toCommentResponse 将评论映射为响应 DTO
+func toCommentResponse(comment *core.Comment) api.CommentResponse {
+	res := api.CommentResponse{
+		ID:        comment.ID.String(),
+		Author:    comment.Author.Username,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+		EditedAt:  comment.EditedAt,
+	}
+	for _, user := range comment.Mentions {
+		res.Mentions = append(res.Mentions, user.Username)
+	}
+	for _, edit := range comment.Edits {
+		res.Edits = append(res.Edits, api.CommentEditResponse{Body: edit.Body, EditedAt: edit.EditedAt})
+	}
+	return res
+}
+
+// toCommentResponses 将评论列表映射为响应 DTO 列表
+func toCommentResponses(comments []core.Comment) []api.CommentResponse {
+	res := make([]api.CommentResponse, 0, len(comments))
+	for i := range comments {
+		res = append(res, toCommentResponse(&comments[i]))
+	}
+	return res
+}
+
+// writeCommentError 将 Service 层返回的业务错误映射为 HTTP 状态码
+func writeCommentError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "application not found", "comment not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "only the author can edit this comment":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// AddComment godoc
+// @Summary      Comment on an application
+// @Description  Post a comment on an application. Users mentioned as @username are recorded if they can see the application.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string              true  "Application ID"
+// @Param        comment  body      api.CommentRequest  true  "Comment"
+// @Success      201      {object}  api.CommentResponse
+// @Failure      400      {object}  api.ErrorResponse
+// @Failure      404      {object}  api.ErrorResponse
+// @Failure      500      {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/{id}/comments [post]
+func (h *CommentHandler) AddComment(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID format"})
+		return
+	}
+	var req api.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	comment, err := h.commentService.AddComment(appID, req.Body, actor)
+	if err != nil {
+		writeCommentError(c, err, "Failed to add comment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCommentResponse(comment))
+}
+
+// ListComments godoc
+// @Summary      List comments of an application
+// @Description  List the discussion on an application, oldest first, including edit history
+// @Tags         Comments
+// @Produce      json
+// @Param        id   path      string  true  "Application ID"
+// @Success      200  {array}   api.CommentResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/{id}/comments [get]
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID format"})
+		return
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	comments, err := h.commentService.ListComments(appID, actor)
+	if err != nil {
+		writeCommentError(c, err, "Failed to retrieve comments")
+		return
+	}
+
+	c.JSON(http.StatusOK, toCommentResponses(comments))
+}
+
+// EditComment godoc
+// @Summary      Edit a comment
+// @Description  Change the body of your own comment. The previous body is kept in the edit history and mentions are recomputed.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string              true  "Application ID"
+// @Param        comment_id  path      string              true  "Comment ID"
+// @Param        comment     body      api.CommentRequest  true  "New comment body"
+// @Success      200         {object}  api.CommentResponse
+// @Failure      400         {object}  api.ErrorResponse
+// @Failure      403         {object}  api.ErrorResponse
+// @Failure      404         {object}  api.ErrorResponse
+// @Failure      500         {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/{id}/comments/{comment_id} [put]
+func (h *CommentHandler) EditComment(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID format"})
+		return
+	}
+	commentID, err := uuid.Parse(c.Param("comment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID format"})
+		return
+	}
+	var req api.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	comment, err := h.commentService.EditComment(appID, commentID, req.Body, actor)
+	if err != nil {
+		writeCommentError(c, err, "Failed to edit comment")
+		return
+	}
+
+	c.JSON(http.StatusOK, toCommentResponse(comment))
+}
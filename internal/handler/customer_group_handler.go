@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"net/http"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CustomerGroupHandler 封装了关联集团相关的 HTTP 请求处理器。
+type CustomerGroupHandler struct {
+	groupService service.CustomerGroupService
+}
+
+// NewCustomerGroupHandler 是 CustomerGroupHandler 的构造函数。
+func NewCustomerGroupHandler(groupService service.CustomerGroupService) *CustomerGroupHandler {
+	return &CustomerGroupHandler{groupService: groupService}
+}
+
+// toCustomerGroupResponse 将核心模型映射为响应 DTO
+func toCustomerGroupResponse(group *core.CustomerGroup) api.CustomerGroupResponse {
+	res := api.CustomerGroupResponse{
+		ID:      group.ID.String(),
+		Name:    group.Name,
+		Members: make([]api.CustomerResponse, 0, len(group.Members)),
+	}
+	if group.ParentCustomerID != nil {
+		res.ParentCustomerID = group.ParentCustomerID.String()
+	}
+	if group.Parent != nil {
+		res.ParentCustomerName = group.Parent.Name
+	}
+	for i := range group.Members {
+		res.Members = append(res.Members, toCustomerResponse(&group.Members[i]))
+	}
+	return res
+}
+
+// writeCustomerGroupError 将 Service 层返回的业务错误映射为 HTTP 状态码
+func writeCustomerGroupError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "customer group not found", "customer not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "customer group name already exists", "customer already belongs to another group", "customer is not a member of this group":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// CreateGroup godoc
+// @Summary      Create a customer group
+// @Description  Create an affiliated customer group, optionally with a parent customer
+// @Tags         CustomerGroups
+// @Accept       json
+// @Produce      json
+// @Param        group  body      api.CreateCustomerGroupRequest  true  "Group info"
+// @Success      201    {object}  api.CustomerGroupResponse
+// @Failure      400    {object}  api.ErrorResponse
+// @Failure      404    {object}  api.ErrorResponse
+// @Failure      409    {object}  api.ErrorResponse
+// @Failure      500    {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customer-groups [post]
+func (h *CustomerGroupHandler) CreateGroup(c *gin.Context) {
+	var req api.CreateCustomerGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var parentID *uuid.UUID
+	if req.ParentCustomerID != "" {
+		id, _ := uuid.Parse(req.ParentCustomerID) // 格式已由 binding 校验
+		parentID = &id
+	}
+
+	group, err := h.groupService.CreateGroup(req.Name, parentID)
+	if err != nil {
+		writeCustomerGroupError(c, err, "Failed to create customer group")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCustomerGroupResponse(group))
+}
+
+// ListGroups godoc
+// @Summary      List customer groups
+// @Description  List all affiliated customer groups with their members
+// @Tags         CustomerGroups
+// @Produce      json
+// @Success      200  {array}   api.CustomerGroupResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customer-groups [get]
+func (h *CustomerGroupHandler) ListGroups(c *gin.Context) {
+	groups, err := h.groupService.ListGroups()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve customer groups"})
+		return
+	}
+
+	res := make([]api.CustomerGroupResponse, 0, len(groups))
+	for i := range groups {
+		res = append(res, toCustomerGroupResponse(&groups[i]))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetGroup godoc
+// @Summary      Get a customer group
+// @Description  Get an affiliated customer group with its members
+// @Tags         CustomerGroups
+// @Produce      json
+// @Param        id   path      string  true  "Group ID"
+// @Success      200  {object}  api.CustomerGroupResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customer-groups/{id} [get]
+func (h *CustomerGroupHandler) GetGroup(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID format"})
+		return
+	}
+
+	group, err := h.groupService.GetGroup(id)
+	if err != nil {
+		writeCustomerGroupError(c, err, "Failed to retrieve customer group")
+		return
+	}
+
+	c.JSON(http.StatusOK, toCustomerGroupResponse(group))
+}
+
+// UpdateGroup godoc
+// @Summary      Update a customer group
+// @Description  Rename a customer group or change its parent customer
+// @Tags         CustomerGroups
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                          true  "Group ID"
+// @Param        group  body      api.UpdateCustomerGroupRequest  true  "Fields to update"
+// @Success      200    {object}  api.CustomerGroupResponse
+// @Failure      400    {object}  api.ErrorResponse
+// @Failure      404    {object}  api.ErrorResponse
+// @Failure      409    {object}  api.ErrorResponse
+// @Failure      500    {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customer-groups/{id} [put]
+func (h *CustomerGroupHandler) UpdateGroup(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID format"})
+		return
+	}
+
+	var req api.UpdateCustomerGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var parentID *uuid.UUID
+	if req.ParentCustomerID != nil {
+		pid, _ := uuid.Parse(*req.ParentCustomerID)
+		parentID = &pid
+	}
+
+	group, err := h.groupService.UpdateGroup(id, req.Name, parentID)
+	if err != nil {
+		writeCustomerGroupError(c, err, "Failed to update customer group")
+		return
+	}
+
+	c.JSON(http.StatusOK, toCustomerGroupResponse(group))
+}
+
+// DeleteGroup godoc
+// @Summary      Delete a customer group
+// @Description  Delete a customer group. Members are detached but not deleted.
+// @Tags         CustomerGroups
+// @Produce      json
+// @Param        id   path      string  true  "Group ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customer-groups/{id} [delete]
+func (h *CustomerGroupHandler) DeleteGroup(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID format"})
+		return
+	}
+
+	if err := h.groupService.DeleteGroup(id); err != nil {
+		writeCustomerGroupError(c, err, "Failed to delete customer group")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Customer group deleted successfully"})
+}
+
+// AddMember godoc
+// @Summary      Add a member to a customer group
+// @Description  Attach a customer to an affiliated group. A customer can belong to at most one group.
+// @Tags         CustomerGroups
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                     true  "Group ID"
+// @Param        member  body      api.AddGroupMemberRequest  true  "Member info"
+// @Success      200     {object}  api.CustomerGroupResponse
+// @Failure      400     {object}  api.ErrorResponse
+// @Failure      404     {object}  api.ErrorResponse
+// @Failure      409     {object}  api.ErrorResponse
+// @Failure      500     {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customer-groups/{id}/members [post]
+func (h *CustomerGroupHandler) AddMember(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID format"})
+		return
+	}
+
+	var req api.AddGroupMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	customerID, _ := uuid.Parse(req.CustomerID)
+
+	group, err := h.groupService.AddMember(groupID, customerID)
+	if err != nil {
+		writeCustomerGroupError(c, err, "Failed to add group member")
+		return
+	}
+
+	c.JSON(http.StatusOK, toCustomerGroupResponse(group))
+}
+
+// RemoveMember godoc
+// @Summary      Remove a member from a customer group
+// @Description  Detach a customer from an affiliated group
+// @Tags         CustomerGroups
+// @Produce      json
+// @Param        id           path      string  true  "Group ID"
+// @Param        customer_id  path      string  true  "Customer ID"
+// @Success      200          {object}  api.CustomerGroupResponse
+// @Failure      400          {object}  api.ErrorResponse
+// @Failure      404          {object}  api.ErrorResponse
+// @Failure      409          {object}  api.ErrorResponse
+// @Failure      500          {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customer-groups/{id}/members/{customer_id} [delete]
+func (h *CustomerGroupHandler) RemoveMember(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID format"})
+		return
+	}
+	customerID, err := uuid.Parse(c.Param("customer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID format"})
+		return
+	}
+
+	group, err := h.groupService.RemoveMember(groupID, customerID)
+	if err != nil {
+		writeCustomerGroupError(c, err, "Failed to remove group member")
+		return
+	}
+
+	c.JSON(http.StatusOK, toCustomerGroupResponse(group))
+}
@@ -0,0 +1,441 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CustomerHandler 封装了客户主数据相关的 HTTP 请求处理器。
+type CustomerHandler struct {
+	customerService service.CustomerService
+}
+
+// NewCustomerHandler 是 CustomerHandler 的构造函数。
+func NewCustomerHandler(customerService service.CustomerService) *CustomerHandler {
+	return &CustomerHandler{customerService: customerService}
+}
+
+// toCustomerResponse 将核心模型映射为响应 DTO
+func toCustomerResponse(customer *core.Customer) api.CustomerResponse {
+	res := api.CustomerResponse{
+		ID:             customer.ID.String(),
+		Name:           customer.Name,
+		Industry:       customer.Industry,
+		Region:         customer.Region,
+		IsDefault:      customer.IsDefault,
+		LatestExtGrade: customer.LatestExtGrade,
+		CreatedAt:      customer.CreatedAt,
+		UpdatedAt:      customer.UpdatedAt,
+	}
+	if customer.CreditCode != nil {
+		res.CreditCode = *customer.CreditCode
+	}
+	if customer.GroupID != nil {
+		res.GroupID = customer.GroupID.String()
+	}
+	return res
+}
+
+// CreateCustomer godoc
+// @Summary      Create a customer
+// @Description  Create a new customer master data record. A soft-deleted customer with the same name is restored instead of duplicated, unless it was merged into another customer.
+// @Tags         Customers
+// @Accept       json
+// @Produce      json
+// @Param        customer  body      api.CreateCustomerRequest  true  "Customer info"
+// @Success      201       {object}  api.CustomerResponse
+// @Failure      400       {object}  api.ErrorResponse
+// @Failure      409       {object}  api.ErrorResponse
+// @Failure      422       {object}  api.ErrorResponse
+// @Failure      500       {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers [post]
+func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
+	var req api.CreateCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := h.customerService.CreateCustomer(req.Name, req.CreditCode, req.Industry, req.Region)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownDictionaryCode) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		switch err.Error() {
+		case "invalid unified social credit code":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case "customer name already exists", "credit code already exists",
+			"credit code belongs to a deleted customer", "customer was merged into another customer":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create customer"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCustomerResponse(customer))
+}
+
+// GetCustomer godoc
+// @Summary      Get a customer
+// @Description  Get a customer by ID
+// @Tags         Customers
+// @Produce      json
+// @Param        id   path      string  true  "Customer ID"
+// @Success      200  {object}  api.CustomerResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/{id} [get]
+func (h *CustomerHandler) GetCustomer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID format"})
+		return
+	}
+
+	customer, err := h.customerService.GetCustomer(id)
+	if err != nil {
+		if err.Error() == "customer not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve customer"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toCustomerResponse(customer))
+}
+
+// matchTypes 将匹配等级映射为对外展示的匹配类型
+var matchTypes = map[int]string{
+	repository.MatchRankExact:     "exact",
+	repository.MatchRankPrefix:    "prefix",
+	repository.MatchRankSubstring: "substring",
+	repository.MatchRankFuzzy:     "fuzzy",
+}
+
+// SearchCustomers godoc
+// @Summary      Search customers
+// @Description  Search customers by name prefix, substring or trigram similarity (or exact credit code) for autocomplete. Results are ranked by match quality and flag customers in default or with a pending application.
+// @Tags         Customers
+// @Produce      json
+// @Param        q      query     string  true   "Keyword"
+// @Param        limit  query     int     false  "Max results"  default(10)
+// @Success      200    {array}   api.CustomerSearchResult
+// @Failure      400    {object}  api.ErrorResponse
+// @Failure      500    {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/search [get]
+func (h *CustomerHandler) SearchCustomers(c *gin.Context) {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Query parameter 'q' is required"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	matches, err := h.customerService.SearchCustomers(q, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search customers"})
+		return
+	}
+
+	res := make([]api.CustomerSearchResult, 0, len(matches))
+	for _, match := range matches {
+		result := api.CustomerSearchResult{
+			ID:                    match.ID.String(),
+			Name:                  match.Name,
+			Industry:              match.Industry,
+			Region:                match.Region,
+			IsDefault:             match.IsDefault,
+			HasPendingApplication: match.HasPending,
+			MatchType:             matchTypes[match.MatchRank],
+			Score:                 math.Round(match.Score*1000) / 1000,
+		}
+		if match.CreditCode != nil {
+			result.CreditCode = *match.CreditCode
+		}
+		res = append(res, result)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetCustomerAliases godoc
+// @Summary      Get a customer's former names
+// @Description  List the names a customer used before being renamed, newest first
+// @Tags         Customers
+// @Produce      json
+// @Param        id   path      string  true  "Customer ID"
+// @Success      200  {array}   api.CustomerAliasResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/{id}/aliases [get]
+func (h *CustomerHandler) GetCustomerAliases(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID format"})
+		return
+	}
+
+	aliases, err := h.customerService.GetAliases(id)
+	if err != nil {
+		if err.Error() == "customer not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve customer aliases"})
+		return
+	}
+
+	res := make([]api.CustomerAliasResponse, 0, len(aliases))
+	for _, alias := range aliases {
+		res = append(res, api.CustomerAliasResponse{Name: alias.Name, ValidUntil: alias.ValidUntil})
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetCustomerTimeline godoc
+// @Summary      Get a customer's default-status timeline
+// @Description  List every default and rebirth period of a customer, derived from its default applications. With the optional date parameter the response also tells whether the customer was in default on that day.
+// @Tags         Customers
+// @Produce      json
+// @Param        id    path      string  true   "Customer ID"
+// @Param        date  query     string  false  "Date to check (YYYY-MM-DD)"
+// @Success      200   {object}  api.CustomerTimelineResponse
+// @Failure      400   {object}  api.ErrorResponse
+// @Failure      404   {object}  api.ErrorResponse
+// @Failure      500   {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/{id}/timeline [get]
+func (h *CustomerHandler) GetCustomerTimeline(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID format"})
+		return
+	}
+
+	var on *time.Time
+	if dateStr := c.Query("date"); dateStr != "" {
+		date, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format, expected YYYY-MM-DD"})
+			return
+		}
+		on = &date
+	}
+
+	timeline, err := h.customerService.GetTimeline(id, on)
+	if err != nil {
+		if err.Error() == "customer not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve customer timeline"})
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
+
+// ListCustomers godoc
+// @Summary      List customers
+// @Description  List customers with optional filters for industry, region and default status, with pagination support.
+// @Tags         Customers
+// @Produce      json
+// @Param        industry    query     string  false  "Industry"
+// @Param        region      query     string  false  "Region"
+// @Param        is_default  query     bool    false  "Default status"
+// @Param        page        query     int     false  "Page number"  default(1)
+// @Param        pageSize    query     int     false  "Page size"    default(10)
+// @Success      200         {object}  api.PaginatedCustomersResponse
+// @Failure      400         {object}  api.ErrorResponse
+// @Failure      500         {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers [get]
+func (h *CustomerHandler) ListCustomers(c *gin.Context) {
+	var params repository.CustomerQueryParams
+
+	industry := c.Query("industry")
+	if industry != "" {
+		params.Industry = &industry
+	}
+	region := c.Query("region")
+	if region != "" {
+		params.Region = &region
+	}
+	if isDefaultStr := c.Query("is_default"); isDefaultStr != "" {
+		isDefault, err := strconv.ParseBool(isDefaultStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Query parameter 'is_default' must be a boolean"})
+			return
+		}
+		params.IsDefault = &isDefault
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	params.Page = page
+	params.PageSize = pageSize
+
+	customers, total, err := h.customerService.ListCustomers(params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query customers"})
+		return
+	}
+
+	data := make([]api.CustomerResponse, 0, len(customers))
+	for i := range customers {
+		data = append(data, toCustomerResponse(&customers[i]))
+	}
+
+	c.JSON(http.StatusOK, api.PaginatedCustomersResponse{
+		Total: total,
+		Page:  page,
+		Data:  data,
+	})
+}
+
+// UpdateCustomer godoc
+// @Summary      Update a customer
+// @Description  Partially update a customer's master data. The default flag cannot be changed here.
+// @Tags         Customers
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                     true  "Customer ID"
+// @Param        customer  body      api.UpdateCustomerRequest  true  "Fields to update"
+// @Success      200       {object}  api.CustomerResponse
+// @Failure      400       {object}  api.ErrorResponse
+// @Failure      404       {object}  api.ErrorResponse
+// @Failure      409       {object}  api.ErrorResponse
+// @Failure      422       {object}  api.ErrorResponse
+// @Failure      500       {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/{id} [put]
+func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID format"})
+		return
+	}
+
+	var req api.UpdateCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := h.customerService.UpdateCustomer(id, req.Name, req.CreditCode, req.Industry, req.Region)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownDictionaryCode) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		switch err.Error() {
+		case "customer not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "invalid unified social credit code":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "customer name already exists", "credit code already exists",
+			"customer name belongs to a deleted customer", "credit code belongs to a deleted customer":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update customer"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toCustomerResponse(customer))
+}
+
+// DeleteCustomer godoc
+// @Summary      Delete a customer
+// @Description  Soft-delete a customer that is not in default and has no pending application
+// @Tags         Customers
+// @Produce      json
+// @Param        id   path      string  true  "Customer ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      409  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/{id} [delete]
+func (h *CustomerHandler) DeleteCustomer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID format"})
+		return
+	}
+
+	if err := h.customerService.DeleteCustomer(id); err != nil {
+		switch err.Error() {
+		case "customer not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "customer is in default status", "there is a pending application for this customer":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete customer"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Customer deleted successfully"})
+}
+
+// ImportCustomers godoc
+// @Summary      Bulk import customers from CSV
+// @Description  Upload a CSV with Name/CreditCode/Industry/Region columns. Rows are upserted by name and a per-row report is returned. The latest external grade is derived from ingested ratings only, so a LatestExtGrade column is ignored.
+// @Tags         Customers
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "CSV file"
+// @Success      200   {object}  api.CustomerImportReport
+// @Failure      400   {object}  api.ErrorResponse
+// @Failure      500   {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/import [post]
+func (h *CustomerHandler) ImportCustomers(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Form field 'file' is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	report, err := h.customerService.ImportCustomers(file)
+	if err != nil {
+		// 文件本身无法解析 (空文件、缺少表头等) 属于客户端错误，其余为服务端错误
+		if errors.Is(err, service.ErrInvalidCSV) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import customers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// defaultDuplicateThreshold 是重复客户检测的默认相似度阈值
+const defaultDuplicateThreshold = 0.6
+
+// CustomerMergeHandler 封装了重复客户检测与合并相关的 HTTP 请求处理器。
+type CustomerMergeHandler struct {
+	mergeService service.CustomerMergeService
+}
+
+// NewCustomerMergeHandler 是 CustomerMergeHandler 的构造函数。
+func NewCustomerMergeHandler(mergeService service.CustomerMergeService) *CustomerMergeHandler {
+	return &CustomerMergeHandler{mergeService: mergeService}
+}
+
+// toCustomerMergeRecordResponse 将合并审计记录映射为响应 DTO
+func toCustomerMergeRecordResponse(record *core.CustomerMergeRecord) api.CustomerMergeRecordResponse {
+	return api.CustomerMergeRecordResponse{
+		ID:                 record.ID.String(),
+		SurvivorID:         record.SurvivorID.String(),
+		LoserID:            record.LoserID.String(),
+		LoserName:          record.LoserName,
+		LoserCreditCode:    record.LoserCreditCode,
+		MovedApplications:  record.MovedApplications,
+		SurvivorWasDefault: record.SurvivorWasDefault,
+		SurvivorIsDefault:  record.SurvivorIsDefault,
+		Reason:             record.Reason,
+		OperatorName:       record.Operator.Username,
+		MergedAt:           record.MergedAt,
+	}
+}
+
+// FindDuplicates godoc
+// @Summary      Duplicate customer report
+// @Description  List pairs of customers whose normalized names are similar enough to be the same entity, most similar first
+// @Tags         Customers
+// @Produce      json
+// @Param        threshold  query     number  false  "Minimum similarity between 0 and 1 (default 0.6)"
+// @Success      200        {array}   api.DuplicateCustomerPair
+// @Failure      400        {object}  api.ErrorResponse
+// @Failure      500        {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/duplicates [get]
+func (h *CustomerMergeHandler) FindDuplicates(c *gin.Context) {
+	threshold := defaultDuplicateThreshold
+	if raw := c.Query("threshold"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed <= 0 || parsed > 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid threshold, must be between 0 and 1"})
+			return
+		}
+		threshold = parsed
+	}
+
+	candidates, err := h.mergeService.FindDuplicates(threshold)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to detect duplicate customers"})
+		return
+	}
+
+	res := make([]api.DuplicateCustomerPair, 0, len(candidates))
+	for i := range candidates {
+		res = append(res, api.DuplicateCustomerPair{
+			Customer:  toCustomerResponse(&candidates[i].Customer),
+			Duplicate: toCustomerResponse(&candidates[i].Duplicate),
+			Score:     candidates[i].Score,
+		})
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// MergeCustomers godoc
+// @Summary      Merge duplicate customers
+// @Description  Merge the loser customer into the survivor: move its applications, ratings, exposures and former names, recompute the default status, soft-delete the loser and record an audit entry, all in one transaction
+// @Tags         Customers
+// @Accept       json
+// @Produce      json
+// @Param        merge  body      api.MergeCustomersRequest  true  "Merge info"
+// @Success      200    {object}  api.CustomerMergeRecordResponse
+// @Failure      400    {object}  api.ErrorResponse
+// @Failure      404    {object}  api.ErrorResponse
+// @Failure      409    {object}  api.ErrorResponse
+// @Failure      500    {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/merge [post]
+func (h *CustomerMergeHandler) MergeCustomers(c *gin.Context) {
+	var req api.MergeCustomersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	survivorID, _ := uuid.Parse(req.SurvivorID) // 格式已由 binding 校验
+	loserID, _ := uuid.Parse(req.LoserID)
+
+	userID, _ := c.Get("userID")
+	record, err := h.mergeService.MergeCustomers(survivorID, loserID, userID.(uuid.UUID), req.Reason)
+	if err != nil {
+		switch err.Error() {
+		case "cannot merge a customer into itself":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "customer not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "both customers have pending applications":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to merge customers"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toCustomerMergeRecordResponse(record))
+}
+
+// ListMergeRecords godoc
+// @Summary      List customer merge records
+// @Description  List the customer merge audit trail, newest first. Optionally filter by a customer that took part as survivor or loser.
+// @Tags         Customers
+// @Produce      json
+// @Param        customer_id  query     string  false  "Customer ID"
+// @Success      200          {array}   api.CustomerMergeRecordResponse
+// @Failure      400          {object}  api.ErrorResponse
+// @Failure      500          {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/merges [get]
+func (h *CustomerMergeHandler) ListMergeRecords(c *gin.Context) {
+	var customerID *uuid.UUID
+	if raw := c.Query("customer_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID format"})
+			return
+		}
+		customerID = &id
+	}
+
+	records, err := h.mergeService.ListMergeRecords(customerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve merge records"})
+		return
+	}
+
+	res := make([]api.CustomerMergeRecordResponse, 0, len(records))
+	for i := range records {
+		res = append(res, toCustomerMergeRecordResponse(&records[i]))
+	}
+	c.JSON(http.StatusOK, res)
+}
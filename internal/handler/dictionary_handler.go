@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"net/http"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DictionaryHandler 封装了行业、区域参考数据字典相关的 HTTP 请求处理器。
+type DictionaryHandler struct {
+	dictService service.DictionaryService
+}
+
+// NewDictionaryHandler 是 DictionaryHandler 的构造函数。
+func NewDictionaryHandler(dictService service.DictionaryService) *DictionaryHandler {
+	return &DictionaryHandler{dictService: dictService}
+}
+
+// toDictionaryEntryResponse 将字典条目映射为响应 DTO
+func toDictionaryEntryResponse(entry *core.DictionaryEntry) api.DictionaryEntryResponse {
+	return api.DictionaryEntryResponse{
+		Kind:       entry.Kind,
+		Code:       entry.Code,
+		Name:       entry.Name,
+		ParentCode: entry.ParentCode,
+		Level:      entry.Level,
+	}
+}
+
+// writeDictionaryError 将 Service 层返回的业务错误映射为 HTTP 状态码
+func writeDictionaryError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "unknown dictionary kind":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "dictionary entry not found", "parent dictionary entry not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "dictionary code already exists", "dictionary entry has child entries", "dictionary entry is in use by customers":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// ListEntries godoc
+// @Summary      List dictionary entries
+// @Description  List all entries of a reference-data dictionary (industry or region), ordered by level and code
+// @Tags         Dictionaries
+// @Produce      json
+// @Param        kind  path      string  true  "Dictionary kind"  Enums(industry, region)
+// @Success      200   {array}   api.DictionaryEntryResponse
+// @Failure      400   {object}  api.ErrorResponse
+// @Failure      500   {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /dictionaries/{kind} [get]
+func (h *DictionaryHandler) ListEntries(c *gin.Context) {
+	entries, err := h.dictService.ListEntries(c.Param("kind"))
+	if err != nil {
+		writeDictionaryError(c, err, "Failed to retrieve dictionary entries")
+		return
+	}
+
+	res := make([]api.DictionaryEntryResponse, 0, len(entries))
+	for i := range entries {
+		res = append(res, toDictionaryEntryResponse(&entries[i]))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateEntry godoc
+// @Summary      Create a dictionary entry
+// @Description  Add an entry to a reference-data dictionary. The level is derived from the parent entry.
+// @Tags         Dictionaries
+// @Accept       json
+// @Produce      json
+// @Param        kind   path      string                      true  "Dictionary kind"  Enums(industry, region)
+// @Param        entry  body      api.DictionaryEntryRequest  true  "Entry info"
+// @Success      201    {object}  api.DictionaryEntryResponse
+// @Failure      400    {object}  api.ErrorResponse
+// @Failure      404    {object}  api.ErrorResponse
+// @Failure      409    {object}  api.ErrorResponse
+// @Failure      500    {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /dictionaries/{kind} [post]
+func (h *DictionaryHandler) CreateEntry(c *gin.Context) {
+	var req api.DictionaryEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.dictService.CreateEntry(c.Param("kind"), req.Code, req.Name, req.ParentCode)
+	if err != nil {
+		writeDictionaryError(c, err, "Failed to create dictionary entry")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toDictionaryEntryResponse(entry))
+}
+
+// UpdateEntry godoc
+// @Summary      Rename a dictionary entry
+// @Description  Change the display name of a dictionary entry. Codes and parents cannot be changed.
+// @Tags         Dictionaries
+// @Accept       json
+// @Produce      json
+// @Param        kind   path      string                            true  "Dictionary kind"  Enums(industry, region)
+// @Param        code   path      string                            true  "Entry code"
+// @Param        entry  body      api.UpdateDictionaryEntryRequest  true  "New name"
+// @Success      200    {object}  api.DictionaryEntryResponse
+// @Failure      400    {object}  api.ErrorResponse
+// @Failure      404    {object}  api.ErrorResponse
+// @Failure      500    {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /dictionaries/{kind}/{code} [put]
+func (h *DictionaryHandler) UpdateEntry(c *gin.Context) {
+	var req api.UpdateDictionaryEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.dictService.UpdateEntry(c.Param("kind"), c.Param("code"), req.Name)
+	if err != nil {
+		writeDictionaryError(c, err, "Failed to update dictionary entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, toDictionaryEntryResponse(entry))
+}
+
+// DeleteEntry godoc
+// @Summary      Delete a dictionary entry
+// @Description  Delete a dictionary entry that has no child entries and is not referenced by any customer
+// @Tags         Dictionaries
+// @Produce      json
+// @Param        kind  path      string  true  "Dictionary kind"  Enums(industry, region)
+// @Param        code  path      string  true  "Entry code"
+// @Success      200   {object}  api.SuccessResponse
+// @Failure      400   {object}  api.ErrorResponse
+// @Failure      404   {object}  api.ErrorResponse
+// @Failure      409   {object}  api.ErrorResponse
+// @Failure      500   {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /dictionaries/{kind}/{code} [delete]
+func (h *DictionaryHandler) DeleteEntry(c *gin.Context) {
+	if err := h.dictService.DeleteEntry(c.Param("kind"), c.Param("code")); err != nil {
+		writeDictionaryError(c, err, "Failed to delete dictionary entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Dictionary entry deleted successfully"})
+}
@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DraftHandler 封装了违约申请草稿相关的 HTTP 请求处理器。
+type DraftHandler struct {
+	draftService service.DraftService
+}
+
+// NewDraftHandler 是 DraftHandler 的构造函数。
+func NewDraftHandler(draftService service.DraftService) *DraftHandler {
+	return &DraftHandler{draftService: draftService}
+}
+
+// toDraftResponse 将草稿映射为响应 DTO
+func toDraftResponse(draft *core.ApplicationDraft) api.DraftResponse {
+	res := api.DraftResponse{
+		ID:           draft.ID.String(),
+		CreditCode:   draft.CustomerCreditCode,
+		CustomerName: draft.CustomerName,
+		Severity:     draft.Severity,
+		ReasonCode:   draft.DefaultReasonCode,
+		Reason:       draft.DefaultReason,
+		Remarks:      draft.Remarks,
+		CreatedAt:    draft.CreatedAt,
+		UpdatedAt:    draft.UpdatedAt,
+		SubmittedAt:  draft.SubmittedAt,
+	}
+	if draft.CustomerID != nil {
+		res.CustomerID = draft.CustomerID.String()
+	}
+	if draft.SubmittedApplicationID != nil {
+		res.ApplicationID = draft.SubmittedApplicationID.String()
+	} else {
+		res.Missing = service.DraftMissingFields(draft)
+	}
+	return res
+}
+
+// toDraftInput 将请求体映射为 Service 层的输入，客户 ID 的格式已由 binding 校验
+func toDraftInput(req *api.DraftRequest) service.DraftInput {
+	input := service.DraftInput{
+		Customer:   service.CustomerRef{CreditCode: req.CreditCode, Name: req.CustomerName},
+		Severity:   req.Severity,
+		ReasonCode: req.ReasonCode,
+		Reason:     req.Reason,
+		Remarks:    req.Remarks,
+	}
+	if req.CustomerID != "" {
+		input.Customer.ID, _ = uuid.Parse(req.CustomerID)
+	}
+	return input
+}
+
+// writeDraftError 将 Service 层返回的业务错误映射为 HTTP 状态码。
+// 提交草稿时还可能返回创建申请的业务错误，映射方式与直接提交申请相同。
+func writeDraftError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrIncompleteDraft), errors.Is(err, service.ErrUnknownReason), errors.Is(err, service.ErrInactiveReason):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err.Error() == "draft not found", err.Error() == "customer not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err.Error() == "draft has already been submitted", err.Error() == "customer is already in default status",
+		err.Error() == "there is already a pending application for this customer":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// CreateDraft godoc
+// @Summary      Create an application draft
+// @Description  Save a partially filled default application as a draft. Only the format of the filled fields is validated.
+// @Tags         Drafts
+// @Accept       json
+// @Produce      json
+// @Param        draft  body      api.DraftRequest  true  "Draft content"
+// @Success      201    {object}  api.DraftResponse
+// @Failure      400    {object}  api.ErrorResponse
+// @Failure      500    {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/drafts [post]
+func (h *DraftHandler) CreateDraft(c *gin.Context) {
+	var req api.DraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	draft, err := h.draftService.CreateDraft(toDraftInput(&req), actor)
+	if err != nil {
+		writeDraftError(c, err, "Failed to create draft")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toDraftResponse(draft))
+}
+
+// UpdateDraft godoc
+// @Summary      Autosave an application draft
+// @Description  Replace the content of one of the caller's drafts with the submitted form. Submitted drafts cannot be changed.
+// @Tags         Drafts
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string            true  "Draft ID"
+// @Param        draft  body      api.DraftRequest  true  "Draft content"
+// @Success      200    {object}  api.DraftResponse
+// @Failure      400    {object}  api.ErrorResponse
+// @Failure      404    {object}  api.ErrorResponse
+// @Failure      409    {object}  api.ErrorResponse
+// @Failure      500    {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/drafts/{id} [put]
+func (h *DraftHandler) UpdateDraft(c *gin.Context) {
+	draftID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid draft ID format"})
+		return
+	}
+
+	var req api.DraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	draft, err := h.draftService.UpdateDraft(draftID, toDraftInput(&req), actor)
+	if err != nil {
+		writeDraftError(c, err, "Failed to save draft")
+		return
+	}
+
+	c.JSON(http.StatusOK, toDraftResponse(draft))
+}
+
+// ListDrafts godoc
+// @Summary      List my drafts
+// @Description  List the caller's drafts that have not been submitted yet, most recently saved first
+// @Tags         Drafts
+// @Produce      json
+// @Success      200  {array}   api.DraftResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/drafts [get]
+func (h *DraftHandler) ListDrafts(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	drafts, err := h.draftService.ListDrafts(actor)
+	if err != nil {
+		writeDraftError(c, err, "Failed to retrieve drafts")
+		return
+	}
+
+	res := make([]api.DraftResponse, 0, len(drafts))
+	for i := range drafts {
+		res = append(res, toDraftResponse(&drafts[i]))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetDraft godoc
+// @Summary      Get a draft
+// @Description  Get one of the caller's drafts. Submitted drafts report the application they created.
+// @Tags         Drafts
+// @Produce      json
+// @Param        id   path      string  true  "Draft ID"
+// @Success      200  {object}  api.DraftResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/drafts/{id} [get]
+func (h *DraftHandler) GetDraft(c *gin.Context) {
+	draftID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid draft ID format"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	draft, err := h.draftService.GetDraft(draftID, actor)
+	if err != nil {
+		writeDraftError(c, err, "Failed to retrieve draft")
+		return
+	}
+
+	c.JSON(http.StatusOK, toDraftResponse(draft))
+}
+
+// DeleteDraft godoc
+// @Summary      Discard a draft
+// @Description  Delete one of the caller's drafts that has not been submitted
+// @Tags         Drafts
+// @Produce      json
+// @Param        id   path      string  true  "Draft ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      409  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/drafts/{id} [delete]
+func (h *DraftHandler) DeleteDraft(c *gin.Context) {
+	draftID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid draft ID format"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	if err := h.draftService.DeleteDraft(draftID, actor); err != nil {
+		writeDraftError(c, err, "Failed to delete draft")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Draft deleted successfully"})
+}
+
+// SubmitDraft godoc
+// @Summary      Submit a draft
+// @Description  Submit a complete draft as a pending default application. Runs the same checks as creating an application directly (customer exists, not already in default, no pending application, active default reason).
+// @Tags         Drafts
+// @Produce      json
+// @Param        id               path      string  true   "Draft ID"
+// @Param        Idempotency-Key  header    string  false  "Key identifying the request; retries with the same key return the first response"
+// @Success      201              {object}  api.ApplicationResponse
+// @Failure      400              {object}  api.ErrorResponse
+// @Failure      404              {object}  api.ErrorResponse
+// @Failure      409              {object}  api.ErrorResponse
+// @Failure      422              {object}  api.ErrorResponse
+// @Failure      500              {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/drafts/{id}/submit [post]
+func (h *DraftHandler) SubmitDraft(c *gin.Context) {
+	draftID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid draft ID format"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	app, err := h.draftService.SubmitDraft(draftID, actor)
+	if err != nil {
+		writeDraftError(c, err, "Failed to submit draft")
+		return
+	}
+
+	c.JSON(http.StatusCreated, api.ApplicationResponse{
+		ID:              app.ID.String(),
+		CustomerName:    app.Customer.Name,
+		Status:          string(app.Status),
+		Severity:        app.Severity,
+		ApplicantName:   app.Applicant.Username,
+		ApplicationTime: app.ApplicationTime,
+		Version:         app.Version,
+	})
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EscalationHandler 封装了 SLA 超时升级记录相关的 HTTP 请求处理器。
+type EscalationHandler struct {
+	slaService service.SLAService
+}
+
+// NewEscalationHandler 是 EscalationHandler 的构造函数。
+func NewEscalationHandler(slaService service.SLAService) *EscalationHandler {
+	return &EscalationHandler{slaService: slaService}
+}
+
+// ListEscalations godoc
+// @Summary      List SLA escalations
+// @Description  List the applications escalated to supervisors because they stayed under review past their SLA due date, newest first
+// @Tags         Assignment
+// @Produce      json
+// @Success      200  {array}   api.EscalationResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /escalations [get]
+func (h *EscalationHandler) ListEscalations(c *gin.Context) {
+	events, err := h.slaService.ListEscalations()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve escalations"})
+		return
+	}
+
+	res := make([]api.EscalationResponse, 0, len(events))
+	for _, event := range events {
+		item := api.EscalationResponse{
+			ID:            event.ID.String(),
+			ApplicationID: event.ApplicationID.String(),
+			CustomerName:  event.Application.Customer.Name,
+			Severity:      event.Application.Severity,
+			Stage:         string(event.Stage),
+			DueAt:         event.DueAt,
+			EscalatedAt:   event.EscalatedAt,
+			EscalatedTo:   event.EscalatedTo,
+		}
+		if event.Assignee != nil {
+			item.AssigneeName = event.Assignee.Username
+		}
+		res = append(res, item)
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ExposureHandler 封装了客户信用敞口相关的 HTTP 请求处理器。
+type ExposureHandler struct {
+	exposureService service.ExposureService
+}
+
+// NewExposureHandler 是 ExposureHandler 的构造函数。
+func NewExposureHandler(exposureService service.ExposureService) *ExposureHandler {
+	return &ExposureHandler{exposureService: exposureService}
+}
+
+// toExposureResponse 将敞口记录映射为响应 DTO
+func toExposureResponse(exposure *core.Exposure) api.ExposureResponse {
+	return api.ExposureResponse{
+		ID:        exposure.ID.String(),
+		Principal: exposure.Principal,
+		Interest:  exposure.Interest,
+		Currency:  exposure.Currency,
+		AsOfDate:  exposure.AsOfDate,
+	}
+}
+
+// toExposureSnapshot 提取申请上的敞口快照，没有快照时返回 nil
+func toExposureSnapshot(app *core.DefaultApplication) *api.ExposureSnapshot {
+	if app.ExposurePrincipal == nil {
+		return nil
+	}
+	snapshot := &api.ExposureSnapshot{
+		Principal: *app.ExposurePrincipal,
+		Currency:  app.ExposureCurrency,
+		AsOfDate:  app.ExposureAsOfDate,
+	}
+	if app.ExposureInterest != nil {
+		snapshot.Interest = *app.ExposureInterest
+	}
+	return snapshot
+}
+
+// RecordExposure godoc
+// @Summary      Record a customer exposure
+// @Description  Append an exposure record (outstanding principal and interest as of a date) to a customer
+// @Tags         Exposures
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                     true  "Customer ID"
+// @Param        exposure  body      api.RecordExposureRequest  true  "Exposure info"
+// @Success      201       {object}  api.ExposureResponse
+// @Failure      400       {object}  api.ErrorResponse
+// @Failure      404       {object}  api.ErrorResponse
+// @Failure      500       {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/{id}/exposures [post]
+func (h *ExposureHandler) RecordExposure(c *gin.Context) {
+	customerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID format"})
+		return
+	}
+
+	var req api.RecordExposureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	asOfDate, _ := time.Parse("2006-01-02", req.AsOfDate) // 格式已由 binding 校验
+
+	exposure, err := h.exposureService.RecordExposure(customerID, req.Principal, req.Interest, req.Currency, asOfDate)
+	if err != nil {
+		if err.Error() == "customer not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record exposure"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toExposureResponse(exposure))
+}
+
+// GetExposures godoc
+// @Summary      Get customer exposures
+// @Description  List a customer's exposure records, newest first
+// @Tags         Exposures
+// @Produce      json
+// @Param        id   path      string  true  "Customer ID"
+// @Success      200  {array}   api.ExposureResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/{id}/exposures [get]
+func (h *ExposureHandler) GetExposures(c *gin.Context) {
+	customerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID format"})
+		return
+	}
+
+	exposures, err := h.exposureService.GetExposures(customerID)
+	if err != nil {
+		if err.Error() == "customer not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve exposures"})
+		return
+	}
+
+	res := make([]api.ExposureResponse, 0, len(exposures))
+	for i := range exposures {
+		res = append(res, toExposureResponse(&exposures[i]))
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -3,7 +3,9 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"time"
 	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
 	"xquant-default-management/internal/repository"
 	"xquant-default-management/internal/service"
 
@@ -21,7 +23,7 @@ func NewQueryHandler(queryService service.QueryService) *QueryHandler {
 
 // FindApplications godoc
 // @Summary      Find applications
-// @Description  Find applications with optional filters for customer name and status, with pagination support.
+// @Description  Find applications with optional filters for customer name and status, with pagination support. Applications under review carry their SLA due date and an overdue flag. Applicants only see applications they submitted or requested rebirth for.
 // @Tags         Applications
 // @Produce      json
 // @Param        customer_name  query     string  false  "Customer Name"
@@ -54,8 +56,13 @@ func (h *QueryHandler) FindApplications(c *gin.Context) {
 	params.Page = page
 	params.PageSize = pageSize
 
-	// 2. 调用 Service 层执行查询
-	apps, total, err := h.queryService.FindApplications(params)
+	// 2. 调用 Service 层执行查询，申请人只能查到自己的申请
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+	apps, total, err := h.queryService.FindApplications(params, actor)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query applications"})
 		return
@@ -64,41 +71,9 @@ func (h *QueryHandler) FindApplications(c *gin.Context) {
 	// 3. 将核心模型列表 (apps) 映射到响应 DTO 列表 (data)
 	// 这是“海关”步骤，确保我们只暴露安全和必要的信息。
 	var data []api.ApplicationDetailResponse
-	for _, app := range apps {
-		// --- 安全的指针处理 ---
-		// 在访问指针字段之前，必须检查它是否为 nil。
-
-		var approverName *string
-		// 如果 app.Approver 不是 nil (即 ApproverID 存在且已 Preload 成功)
-		if app.Approver != nil {
-			approverName = &app.Approver.Username
-		}
-
-		// 注意：DTO 中的字段也应该是指针类型，才能正确地表示 null。
-		// 我们需要修改一下 ApplicationDetailResponse DTO。
-
-		// --- 构建单个 DTO 对象 ---
-		detail := api.ApplicationDetailResponse{
-			ID:              app.ID.String(),
-			CustomerName:    app.Customer.Name,
-			LatestExtGrade:  app.Customer.LatestExtGrade,
-			Status:          app.Status,
-			DefaultReason:   app.DefaultReason,
-			Severity:        app.Severity,
-			ApplicationTime: app.ApplicationTime,
-			RebirthReason:   app.RebirthReason, // 修正：应该是 RebirthReason
-
-			// 安全地赋值
-			ApprovalTime: app.ApprovalTime,
-			ApproverName: approverName,
-		}
-
-		// Applicant 也可能由于某些原因（如用户被删除）加载失败，做个保护是好习惯
-		if app.Applicant.ID != uuid.Nil {
-			detail.ApplicantName = app.Applicant.Username
-		}
-
-		data = append(data, detail)
+	now := time.Now()
+	for i := range apps {
+		data = append(data, toApplicationDetailResponse(&apps[i], now))
 	}
 
 	// 4. 返回包含分页信息的最终响应
@@ -108,3 +83,154 @@ func (h *QueryHandler) FindApplications(c *gin.Context) {
 		Data:  data,
 	})
 }
+
+// GetApplication godoc
+// @Summary      Get an application
+// @Description  Get the complete record of an application: the related customer, every actor and timestamp of its lifecycle, rebirth rejections, comments and approval steps. Applicants can only see applications they submitted or requested rebirth for. The ETag header carries the version to send in If-Match.
+// @Tags         Applications
+// @Produce      json
+// @Param        id   path      string  true  "Application ID"
+// @Success      200  {object}  api.ApplicationDetailResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /applications/{id} [get]
+func (h *QueryHandler) GetApplication(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID format"})
+		return
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+		return
+	}
+
+	app, err := h.queryService.GetApplication(appID, actor)
+	if err != nil {
+		if err.Error() == "application not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve application"})
+		return
+	}
+
+	c.Header("ETag", applicationETag(app))
+	c.JSON(http.StatusOK, toApplicationDetailResponse(app, time.Now()))
+}
+
+// toApplicationDetailResponse 将申请映射为详情 DTO，now 用于计算 SLA 是否超时。
+// 关联数据 (经办人、评论、审批记录等) 只有在预加载后才会出现在响应中。
+func toApplicationDetailResponse(app *core.DefaultApplication, now time.Time) api.ApplicationDetailResponse {
+	// --- 安全的指针处理 ---
+	// 在访问指针字段之前，必须检查它是否为 nil。
+
+	var approverName *string
+	// 如果 app.Approver 不是 nil (即 ApproverID 存在且已 Preload 成功)
+	if app.Approver != nil {
+		approverName = &app.Approver.Username
+	}
+
+	// 注意：DTO 中的字段也应该是指针类型，才能正确地表示 null。
+	// 我们需要修改一下 ApplicationDetailResponse DTO。
+
+	// --- 构建单个 DTO 对象 ---
+	detail := api.ApplicationDetailResponse{
+		ID:              app.ID.String(),
+		CustomerName:    app.Customer.Name,
+		LatestExtGrade:  app.Customer.LatestExtGrade,
+		Status:          string(app.Status),
+		DefaultReason:   app.DefaultReason,
+		Severity:        app.Severity,
+		Round:           app.Round,
+		ApplicationTime: app.ApplicationTime,
+		RebirthReason:   app.RebirthReason, // 修正：应该是 RebirthReason
+
+		// 安全地赋值
+		ApprovalTime: app.ApprovalTime,
+		ApproverName: approverName,
+
+		WithdrawnAt:      app.WithdrawnAt,
+		WithdrawalReason: app.WithdrawalReason,
+
+		DefaultReasonCode: app.DefaultReasonCode,
+		RebirthReasonCode: app.RebirthReasonCode,
+		Version:           app.Version,
+
+		Customer:            toCustomerResponse(&app.Customer),
+		CreatedAt:           app.CreatedAt,
+		UpdatedAt:           app.UpdatedAt,
+		RoundSubmittedAt:    app.RoundSubmittedAt,
+		RejectionReason:     app.RejectionReason,
+		Remarks:             app.Remarks,
+		RebirthAppliedAt:    app.RebirthAppliedAt,
+		RebirthApprovalTime: app.RebirthApprovalTime,
+	}
+	detail.Exposure = toExposureSnapshot(app)
+	if app.Assignee != nil {
+		detail.AssigneeName = app.Assignee.Username
+		detail.AssignedAt = app.AssignedAt
+	}
+	detail.DueAt, detail.Overdue = service.SLAState(app, now)
+	detail.EscalatedAt = app.EscalatedAt
+	for _, rejection := range app.RebirthRejections {
+		detail.RebirthRejections = append(detail.RebirthRejections, api.RebirthRejectionResponse{
+			RebirthReason:    rejection.RebirthReason,
+			RebirthAppliedAt: rejection.RebirthAppliedAt,
+			RejectedBy:       rejection.RejectedBy.Username,
+			RejectedAt:       rejection.RejectedAt,
+			RejectionReason:  rejection.RejectionReason,
+
+			RebirthReasonCode: rejection.RebirthReasonCode,
+		})
+	}
+	for _, withdrawal := range app.RebirthWithdrawals {
+		detail.RebirthWithdrawals = append(detail.RebirthWithdrawals, api.RebirthWithdrawalResponse{
+			RebirthReason:     withdrawal.RebirthReason,
+			RebirthReasonCode: withdrawal.RebirthReasonCode,
+			RebirthAppliedAt:  withdrawal.RebirthAppliedAt,
+			WithdrawnBy:       withdrawal.WithdrawnBy.Username,
+			WithdrawnAt:       withdrawal.WithdrawnAt,
+			WithdrawalReason:  withdrawal.WithdrawalReason,
+		})
+	}
+	if len(app.Comments) > 0 {
+		detail.Comments = toCommentResponses(app.Comments)
+	}
+	if len(app.Customer.Ratings) > 0 {
+		detail.RatingHistory = toRatingResponses(app.Customer.Ratings)
+	}
+
+	// Applicant 也可能由于某些原因（如用户被删除）加载失败，做个保护是好习惯
+	if app.Applicant.ID != uuid.Nil {
+		detail.ApplicantName = app.Applicant.Username
+	}
+	if app.AssignedBy != nil {
+		detail.AssignedByName = app.AssignedBy.Username
+	}
+	if app.RebirthApplicant != nil {
+		detail.RebirthApplicantName = app.RebirthApplicant.Username
+	}
+	if app.RebirthApprover != nil {
+		detail.RebirthApproverName = &app.RebirthApprover.Username
+	}
+	if app.WithdrawnBy != nil {
+		detail.WithdrawnByName = app.WithdrawnBy.Username
+	}
+	if app.TriggerApplicationID != nil {
+		detail.TriggerApplicationID = app.TriggerApplicationID.String()
+	}
+	for _, step := range app.ApprovalSteps {
+		detail.ApprovalSteps = append(detail.ApprovalSteps, api.ApprovalStepResponse{
+			Stage:        string(step.Stage),
+			Round:        step.Round,
+			Level:        step.Level,
+			ApproverName: step.Approver.Username,
+			ApprovedAt:   step.ApprovedAt,
+		})
+	}
+	return detail
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// queryStub 只实现申请详情用到的方法，记录收到的操作者并返回预设的结果
+type queryStub struct {
+	service.QueryService
+	actor service.Actor
+	app   *core.DefaultApplication
+	err   error
+}
+
+func (s *queryStub) GetApplication(id uuid.UUID, actor service.Actor) (*core.DefaultApplication, error) {
+	s.actor = actor
+	return s.app, s.err
+}
+
+func TestQueryHandler_GetApplication(t *testing.T) {
+	userID := uuid.New()
+	send := func(stub *queryStub, id string) *httptest.ResponseRecorder {
+		h := NewQueryHandler(stub)
+		router := setupRouter()
+		router.GET("/applications/:id", func(c *gin.Context) {
+			c.Set("userID", userID)
+			c.Set("role", core.RoleApplicant)
+			h.GetApplication(c)
+		})
+		req, _ := http.NewRequest(http.MethodGet, "/applications/"+id, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("returns the full lifecycle", func(t *testing.T) {
+		appID := uuid.New()
+		approvedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+		rebirthApprovedAt := approvedAt.AddDate(0, 6, 0)
+		app := &core.DefaultApplication{
+			BaseModel:           core.BaseModel{ID: appID},
+			Customer:            core.Customer{Name: "Acme", Industry: "C13", Region: "310000"},
+			Status:              core.StatusReborn,
+			Version:             7,
+			Remarks:             "补充材料已上传",
+			Applicant:           core.User{BaseModel: core.BaseModel{ID: userID}, Username: "alice"},
+			Approver:            &core.User{Username: "bob"},
+			ApprovalTime:        &approvedAt,
+			RebirthApplicant:    &core.User{Username: "alice"},
+			RebirthApprover:     &core.User{Username: "carol"},
+			RebirthApprovalTime: &rebirthApprovedAt,
+			ApprovalSteps: []core.ApprovalStep{
+				{Stage: core.EventApprove, Round: 1, Level: 1, Approver: core.User{Username: "bob"}, ApprovedAt: approvedAt},
+			},
+		}
+		stub := &queryStub{app: app}
+
+		w := send(stub, appID.String())
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"7"`, w.Header().Get("ETag"))
+		assert.Equal(t, service.Actor{ID: userID, Role: core.RoleApplicant}, stub.actor)
+		var res api.ApplicationDetailResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, "C13", res.Customer.Industry)
+		assert.Equal(t, "310000", res.Customer.Region)
+		assert.Equal(t, "补充材料已上传", res.Remarks)
+		assert.Equal(t, "alice", res.ApplicantName)
+		assert.Equal(t, "alice", res.RebirthApplicantName)
+		if assert.NotNil(t, res.RebirthApproverName) {
+			assert.Equal(t, "carol", *res.RebirthApproverName)
+		}
+		if assert.NotNil(t, res.RebirthApprovalTime) {
+			assert.True(t, rebirthApprovedAt.Equal(*res.RebirthApprovalTime))
+		}
+		if assert.Len(t, res.ApprovalSteps, 1) {
+			assert.Equal(t, "bob", res.ApprovalSteps[0].ApproverName)
+		}
+	})
+
+	t.Run("invisible application", func(t *testing.T) {
+		w := send(&queryStub{err: errors.New("application not found")}, uuid.New().String())
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid application ID", func(t *testing.T) {
+		w := send(&queryStub{}, "not-a-uuid")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RatingHandler 封装了外部评级历史和评级映射表相关的 HTTP 请求处理器。
+type RatingHandler struct {
+	ratingService service.RatingService
+}
+
+// NewRatingHandler 是 RatingHandler 的构造函数。
+func NewRatingHandler(ratingService service.RatingService) *RatingHandler {
+	return &RatingHandler{ratingService: ratingService}
+}
+
+// toRatingResponse 将一条评级记录映射为响应 DTO
+func toRatingResponse(rating *core.ExternalRating) api.RatingResponse {
+	return api.RatingResponse{
+		ID:             rating.ID.String(),
+		Agency:         rating.Agency,
+		Grade:          rating.Grade,
+		Ordinal:        rating.Ordinal,
+		IsDefaultGrade: rating.IsDefaultGrade,
+		EffectiveDate:  rating.EffectiveDate,
+		Source:         rating.Source,
+	}
+}
+
+// toRatingResponses 将评级历史映射为响应 DTO 列表
+func toRatingResponses(ratings []core.ExternalRating) []api.RatingResponse {
+	res := make([]api.RatingResponse, 0, len(ratings))
+	for i := range ratings {
+		res = append(res, toRatingResponse(&ratings[i]))
+	}
+	return res
+}
+
+// toRatingScaleEntryResponse 将映射项映射为响应 DTO
+func toRatingScaleEntryResponse(entry *core.RatingScaleEntry) api.RatingScaleEntryResponse {
+	return api.RatingScaleEntryResponse{
+		ID:        entry.ID.String(),
+		Agency:    entry.Agency,
+		Grade:     entry.Grade,
+		Ordinal:   entry.Ordinal,
+		IsDefault: entry.IsDefault,
+	}
+}
+
+// IngestRating godoc
+// @Summary      Ingest an external rating
+// @Description  Append an external rating record to a customer's history. The customer's latest grade is derived from the newest record.
+// @Tags         Ratings
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                   true  "Customer ID"
+// @Param        rating  body      api.IngestRatingRequest  true  "Rating info"
+// @Success      201     {object}  api.RatingResponse
+// @Failure      400     {object}  api.ErrorResponse
+// @Failure      404     {object}  api.ErrorResponse
+// @Failure      422     {object}  api.ErrorResponse
+// @Failure      500     {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/{id}/ratings [post]
+func (h *RatingHandler) IngestRating(c *gin.Context) {
+	customerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID format"})
+		return
+	}
+
+	var req api.IngestRatingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	effectiveDate, _ := time.Parse("2006-01-02", req.EffectiveDate) // 格式已由 binding 校验
+
+	rating, err := h.ratingService.IngestRating(customerID, req.Agency, req.Grade, effectiveDate, req.Source)
+	if err != nil {
+		switch err.Error() {
+		case "customer not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "rating grade is not defined in the rating scale":
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to ingest rating"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRatingResponse(rating))
+}
+
+// GetRatingHistory godoc
+// @Summary      Get rating history
+// @Description  Get a customer's external rating history, newest first
+// @Tags         Ratings
+// @Produce      json
+// @Param        id   path      string  true  "Customer ID"
+// @Success      200  {array}   api.RatingResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /customers/{id}/ratings [get]
+func (h *RatingHandler) GetRatingHistory(c *gin.Context) {
+	customerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID format"})
+		return
+	}
+
+	ratings, err := h.ratingService.GetRatingHistory(customerID)
+	if err != nil {
+		if err.Error() == "customer not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve rating history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toRatingResponses(ratings))
+}
+
+// ListScale godoc
+// @Summary      List the rating scale
+// @Description  List the configured mapping of agency grades to ordinals and default flags
+// @Tags         Ratings
+// @Produce      json
+// @Success      200  {array}   api.RatingScaleEntryResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /rating-scale [get]
+func (h *RatingHandler) ListScale(c *gin.Context) {
+	entries, err := h.ratingService.ListScale()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve rating scale"})
+		return
+	}
+
+	res := make([]api.RatingScaleEntryResponse, 0, len(entries))
+	for i := range entries {
+		res = append(res, toRatingScaleEntryResponse(&entries[i]))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// UpsertScaleEntry godoc
+// @Summary      Create or update a rating scale entry
+// @Description  Map an agency grade to an ordinal and a default flag. Existing rating records keep their original snapshot.
+// @Tags         Ratings
+// @Accept       json
+// @Produce      json
+// @Param        entry  body      api.RatingScaleEntryRequest  true  "Scale entry"
+// @Success      200    {object}  api.RatingScaleEntryResponse
+// @Failure      400    {object}  api.ErrorResponse
+// @Failure      500    {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /rating-scale [put]
+func (h *RatingHandler) UpsertScaleEntry(c *gin.Context) {
+	var req api.RatingScaleEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.ratingService.UpsertScaleEntry(req.Agency, req.Grade, req.Ordinal, req.IsDefault)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save rating scale entry"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toRatingScaleEntryResponse(entry))
+}
+
+// DeleteScaleEntry godoc
+// @Summary      Delete a rating scale entry
+// @Description  Remove an agency grade from the rating scale
+// @Tags         Ratings
+// @Produce      json
+// @Param        id   path      string  true  "Scale entry ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /rating-scale/{id} [delete]
+func (h *RatingHandler) DeleteScaleEntry(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scale entry ID format"})
+		return
+	}
+
+	if err := h.ratingService.DeleteScaleEntry(id); err != nil {
+		if err.Error() == "rating scale entry not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete rating scale entry"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Rating scale entry deleted successfully"})
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReasonHandler 封装了违约原因、重生原因目录相关的 HTTP 请求处理器。
+type ReasonHandler struct {
+	reasonService service.ReasonService
+}
+
+// NewReasonHandler 是 ReasonHandler 的构造函数。
+func NewReasonHandler(reasonService service.ReasonService) *ReasonHandler {
+	return &ReasonHandler{reasonService: reasonService}
+}
+
+// toReasonResponse 将原因目录条目映射为响应 DTO
+func toReasonResponse(entry *core.ReasonCatalogEntry, now time.Time) api.ReasonResponse {
+	return api.ReasonResponse{
+		Kind:         string(entry.Kind),
+		Code:         entry.Code,
+		LabelZh:      entry.LabelZh,
+		LabelEn:      entry.LabelEn,
+		ValidFrom:    entry.ValidFrom,
+		ValidTo:      entry.ValidTo,
+		EvidenceHint: entry.EvidenceHint,
+		Active:       service.ReasonActive(entry, now),
+	}
+}
+
+// toReasonInput 将请求体映射为 Service 层的输入
+func toReasonInput(req *api.UpdateReasonRequest) service.ReasonInput {
+	return service.ReasonInput{
+		LabelZh:      req.LabelZh,
+		LabelEn:      req.LabelEn,
+		ValidFrom:    req.ValidFrom,
+		ValidTo:      req.ValidTo,
+		EvidenceHint: req.EvidenceHint,
+	}
+}
+
+// writeReasonError 将 Service 层返回的业务错误映射为 HTTP 状态码
+func writeReasonError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "unknown reason kind", "valid_to must be after valid_from":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "reason not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "reason code already exists", "reason is in use by applications",
+		"built-in reason cannot be deleted", "built-in reason cannot be retired":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// ListReasons godoc
+// @Summary      List reason catalog entries
+// @Description  List the entries of the default-reason or rebirth-reason catalog ordered by code. Set active=true to list only the reasons that can be used on new applications.
+// @Tags         Reasons
+// @Produce      json
+// @Param        kind    path      string  true   "Catalog kind"  Enums(default, rebirth)
+// @Param        active  query     bool    false  "Only list reasons that are currently active"
+// @Success      200     {array}   api.ReasonResponse
+// @Failure      400     {object}  api.ErrorResponse
+// @Failure      500     {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /reasons/{kind} [get]
+func (h *ReasonHandler) ListReasons(c *gin.Context) {
+	activeOnly, _ := strconv.ParseBool(c.Query("active"))
+	entries, err := h.reasonService.ListReasons(c.Param("kind"), activeOnly)
+	if err != nil {
+		writeReasonError(c, err, "Failed to retrieve reasons")
+		return
+	}
+
+	now := time.Now()
+	res := make([]api.ReasonResponse, 0, len(entries))
+	for i := range entries {
+		res = append(res, toReasonResponse(&entries[i], now))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateReason godoc
+// @Summary      Create a reason catalog entry
+// @Description  Add a reason to the default-reason or rebirth-reason catalog
+// @Tags         Reasons
+// @Accept       json
+// @Produce      json
+// @Param        kind    path      string             true  "Catalog kind"  Enums(default, rebirth)
+// @Param        reason  body      api.ReasonRequest  true  "Reason info"
+// @Success      201     {object}  api.ReasonResponse
+// @Failure      400     {object}  api.ErrorResponse
+// @Failure      409     {object}  api.ErrorResponse
+// @Failure      500     {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /reasons/{kind} [post]
+func (h *ReasonHandler) CreateReason(c *gin.Context) {
+	var req api.ReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.reasonService.CreateReason(c.Param("kind"), req.Code, toReasonInput(&req.UpdateReasonRequest))
+	if err != nil {
+		writeReasonError(c, err, "Failed to create reason")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toReasonResponse(entry, time.Now()))
+}
+
+// UpdateReason godoc
+// @Summary      Update a reason catalog entry
+// @Description  Change the labels, validity period and evidence hint of a reason. Codes cannot be changed; retire a reason by setting valid_to. The built-in group reasons (GROUP_CONTAGION, GROUP_MEMBER_REBORN) cannot be retired.
+// @Tags         Reasons
+// @Accept       json
+// @Produce      json
+// @Param        kind    path      string                   true  "Catalog kind"  Enums(default, rebirth)
+// @Param        code    path      string                   true  "Reason code"
+// @Param        reason  body      api.UpdateReasonRequest  true  "Reason info"
+// @Success      200     {object}  api.ReasonResponse
+// @Failure      400     {object}  api.ErrorResponse
+// @Failure      404     {object}  api.ErrorResponse
+// @Failure      409     {object}  api.ErrorResponse
+// @Failure      500     {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /reasons/{kind}/{code} [put]
+func (h *ReasonHandler) UpdateReason(c *gin.Context) {
+	var req api.UpdateReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.reasonService.UpdateReason(c.Param("kind"), c.Param("code"), toReasonInput(&req))
+	if err != nil {
+		writeReasonError(c, err, "Failed to update reason")
+		return
+	}
+
+	c.JSON(http.StatusOK, toReasonResponse(entry, time.Now()))
+}
+
+// DeleteReason godoc
+// @Summary      Delete a reason catalog entry
+// @Description  Delete a reason that no application has ever used. Reasons in use can only be retired by setting valid_to. The built-in group reasons cannot be deleted.
+// @Tags         Reasons
+// @Produce      json
+// @Param        kind  path      string  true  "Catalog kind"  Enums(default, rebirth)
+// @Param        code  path      string  true  "Reason code"
+// @Success      200   {object}  api.SuccessResponse
+// @Failure      400   {object}  api.ErrorResponse
+// @Failure      404   {object}  api.ErrorResponse
+// @Failure      409   {object}  api.ErrorResponse
+// @Failure      500   {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /reasons/{kind}/{code} [delete]
+func (h *ReasonHandler) DeleteReason(c *gin.Context) {
+	if err := h.reasonService.DeleteReason(c.Param("kind"), c.Param("code")); err != nil {
+		writeReasonError(c, err, "Failed to delete reason")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reason deleted successfully"})
+}
@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 	"xquant-default-management/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
+// defaultStatisticsCurrency 是金额加权统计的默认币种
+const defaultStatisticsCurrency = "CNY"
+
 // StatisticsHandler 封装了所有与统计相关的 HTTP 处理器
 type StatisticsHandler struct {
 	statsService service.StatisticsService
@@ -50,13 +54,28 @@ func (h *StatisticsHandler) getStatistics(c *gin.Context, dimension, status stri
 	// c.Query() 返回字符串，我们将其转换为布尔值。
 	// 如果参数不存在或值为 "false"、"0" 等，strconv.ParseBool 会返回 false。
 	includeHistorical, _ := strconv.ParseBool(c.Query("include_historical"))
+	// 解析 'level' 参数：按字典层级汇总，缺省为 0 (不汇总)
+	level := 0
+	if levelStr := c.Query("level"); levelStr != "" {
+		level, err = strconv.Atoi(levelStr)
+		if err != nil || level < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Query parameter 'level' must be a positive number"})
+			return
+		}
+	}
+	// 解析 'currency' 参数：金额加权统计的币种，缺省为 defaultStatisticsCurrency
+	currency := strings.ToUpper(c.DefaultQuery("currency", defaultStatisticsCurrency))
+	if len(currency) != 3 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Query parameter 'currency' must be a 3-letter currency code"})
+		return
+	}
 	// 3. 调用 Service 层获取经过计算的统计数据
 	// 3. 根据参数选择调用哪个 Service 方法
 	var stats interface{} // 使用 interface{} 来接收不同方法返回的相同 DTO 类型
 	if includeHistorical {
-		stats, err = h.statsService.GetStatisticsByDimensionIncludeHistorical(year, dimension, status)
+		stats, err = h.statsService.GetStatisticsByDimensionIncludeHistorical(year, dimension, status, level, currency)
 	} else {
-		stats, err = h.statsService.GetStatisticsByDimension(year, dimension, status)
+		stats, err = h.statsService.GetStatisticsByDimension(year, dimension, status, level, currency)
 	}
 	if err != nil {
 		// 如果 Service 层返回错误，这通常是服务器内部问题（例如数据库连接失败），
@@ -74,11 +93,13 @@ func (h *StatisticsHandler) getStatistics(c *gin.Context, dimension, status stri
 
 // GetDefaultsByIndustry godoc
 // @Summary      Get default statistics by industry
-// @Description  Get default statistics by industry for a given year. Can include historical data.
+// @Description  Get default statistics by industry for a given year. Can include historical data and roll up to a level of the dictionary hierarchy. Reports exposure amounts alongside counts.
 // @Tags         Statistics
 // @Produce      json
-// @Param        year                query     int   true  "Year"
-// @Param        include_historical  query     bool  false  "Include historical data"
+// @Param        year                query     int     true   "Year"
+// @Param        include_historical  query     bool    false  "Include historical data"
+// @Param        level               query     int     false  "Roll up to this dictionary level"
+// @Param        currency            query     string  false  "Currency of the amount-weighted figures"  default(CNY)
 // @Success      200                 {array}   api.StatisticsResponse
 // @Failure      400                 {object}  api.ErrorResponse
 // @Failure      500                 {object}  api.ErrorResponse
@@ -90,11 +111,13 @@ func (h *StatisticsHandler) GetDefaultsByIndustry(c *gin.Context) {
 
 // GetRebirthsByIndustry godoc
 // @Summary      Get rebirth statistics by industry
-// @Description  Get rebirth statistics by industry for a given year. Can include historical data.
+// @Description  Get rebirth statistics by industry for a given year. Can include historical data and roll up to a level of the dictionary hierarchy. Reports exposure amounts alongside counts.
 // @Tags         Statistics
 // @Produce      json
-// @Param        year                query     int   true  "Year"
-// @Param        include_historical  query     bool  false  "Include historical data"
+// @Param        year                query     int     true   "Year"
+// @Param        include_historical  query     bool    false  "Include historical data"
+// @Param        level               query     int     false  "Roll up to this dictionary level"
+// @Param        currency            query     string  false  "Currency of the amount-weighted figures"  default(CNY)
 // @Success      200                 {array}   api.StatisticsResponse
 // @Failure      400                 {object}  api.ErrorResponse
 // @Failure      500                 {object}  api.ErrorResponse
@@ -108,11 +131,13 @@ func (h *StatisticsHandler) GetRebirthsByIndustry(c *gin.Context) {
 
 // GetDefaultsByRegion godoc
 // @Summary      Get default statistics by region
-// @Description  Get default statistics by region for a given year. Can include historical data.
+// @Description  Get default statistics by region for a given year. Can include historical data and roll up to a level of the dictionary hierarchy. Reports exposure amounts alongside counts.
 // @Tags         Statistics
 // @Produce      json
-// @Param        year                query     int   true  "Year"
-// @Param        include_historical  query     bool  false  "Include historical data"
+// @Param        year                query     int     true   "Year"
+// @Param        include_historical  query     bool    false  "Include historical data"
+// @Param        level               query     int     false  "Roll up to this dictionary level"
+// @Param        currency            query     string  false  "Currency of the amount-weighted figures"  default(CNY)
 // @Success      200                 {array}   api.StatisticsResponse
 // @Failure      400                 {object}  api.ErrorResponse
 // @Failure      500                 {object}  api.ErrorResponse
@@ -124,11 +149,13 @@ func (h *StatisticsHandler) GetDefaultsByRegion(c *gin.Context) {
 
 // GetRebirthsByRegion godoc
 // @Summary      Get rebirth statistics by region
-// @Description  Get rebirth statistics by region for a given year. Can include historical data.
+// @Description  Get rebirth statistics by region for a given year. Can include historical data and roll up to a level of the dictionary hierarchy. Reports exposure amounts alongside counts.
 // @Tags         Statistics
 // @Produce      json
-// @Param        year                query     int   true  "Year"
-// @Param        include_historical  query     bool  false  "Include historical data"
+// @Param        year                query     int     true   "Year"
+// @Param        include_historical  query     bool    false  "Include historical data"
+// @Param        level               query     int     false  "Roll up to this dictionary level"
+// @Param        currency            query     string  false  "Currency of the amount-weighted figures"  default(CNY)
 // @Success      200                 {array}   api.StatisticsResponse
 // @Failure      400                 {object}  api.ErrorResponse
 // @Failure      500                 {object}  api.ErrorResponse
@@ -137,3 +164,39 @@ func (h *StatisticsHandler) GetDefaultsByRegion(c *gin.Context) {
 func (h *StatisticsHandler) GetRebirthsByRegion(c *gin.Context) {
 	h.getStatistics(c, "region", "Reborn")
 }
+
+// --- 按原因 (Reason) 统计 ---
+
+// GetDefaultsByReason godoc
+// @Summary      Get default statistics by reason
+// @Description  Get default statistics by the default-reason code recorded on each application for a given year. Can include historical data. Reports exposure amounts alongside counts.
+// @Tags         Statistics
+// @Produce      json
+// @Param        year                query     int     true   "Year"
+// @Param        include_historical  query     bool    false  "Include historical data"
+// @Param        currency            query     string  false  "Currency of the amount-weighted figures"  default(CNY)
+// @Success      200                 {array}   api.StatisticsResponse
+// @Failure      400                 {object}  api.ErrorResponse
+// @Failure      500                 {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /statistics/defaults/by-reason [get]
+func (h *StatisticsHandler) GetDefaultsByReason(c *gin.Context) {
+	h.getStatistics(c, "reason", "Approved")
+}
+
+// GetRebirthsByReason godoc
+// @Summary      Get rebirth statistics by reason
+// @Description  Get rebirth statistics by the rebirth-reason code recorded on each application for a given year. Can include historical data. Reports exposure amounts alongside counts.
+// @Tags         Statistics
+// @Produce      json
+// @Param        year                query     int     true   "Year"
+// @Param        include_historical  query     bool    false  "Include historical data"
+// @Param        currency            query     string  false  "Currency of the amount-weighted figures"  default(CNY)
+// @Success      200                 {array}   api.StatisticsResponse
+// @Failure      400                 {object}  api.ErrorResponse
+// @Failure      500                 {object}  api.ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /statistics/rebirths/by-reason [get]
+func (h *StatisticsHandler) GetRebirthsByReason(c *gin.Context) {
+	h.getStatistics(c, "reason", "Reborn")
+}
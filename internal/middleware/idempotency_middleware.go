@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader 是客户端标识一次写操作的请求头，重试时使用同一个值。
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength 是 Idempotency-Key 的最大长度，与 IdempotencyRecord.Key 的列宽一致
+const maxIdempotencyKeyLength = 255
+
+// retryableStatuses 是客户端修正请求头后应当用同一个 Key 重试的响应，这些响应不保存：
+// 请求摘要不包含 If-Match，缺少 If-Match (428) 或版本过期 (412) 后带上正确的 ETag 重试时必须重新执行。
+var retryableStatuses = map[int]bool{
+	http.StatusBadRequest:           true,
+	http.StatusPreconditionFailed:   true,
+	http.StatusPreconditionRequired: true,
+}
+
+// responseRecorder 在写出响应的同时保留一份响应体，用于保存到 Idempotency-Key 记录
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware 为写操作提供 Idempotency-Key 支持。
+// 请求带有 Idempotency-Key 时，同一用户使用同一个 Key 的重试直接返回第一次的响应 (响应头 Idempotent-Replayed: true)，
+// 不会再次执行；Key 用于内容 (方法、路径、请求体) 不同的请求时返回 422，第一次请求仍在处理中时返回 409。
+// 没有 Idempotency-Key 的请求照常处理。
+// 5xx 响应以及 400、412、428 (见 retryableStatuses) 不保存，客户端可以用同一个 Key 重试。
+// 这个中间件必须在 AuthMiddleware 之后运行。
+func IdempotencyMiddleware(idempotencyService service.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must not be longer than 255 characters"})
+			return
+		}
+
+		userIDVal, _ := c.Get("userID")
+		userID, ok := userIDVal.(uuid.UUID)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID in context"})
+			return
+		}
+
+		// 读取请求体计算摘要，再放回去供 Handler 解析
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		hash := sha256.New()
+		hash.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n"))
+		hash.Write(body)
+
+		record, err := idempotencyService.Begin(userID, key, hex.EncodeToString(hash.Sum(nil)))
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrIdempotencyKeyReused):
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			case errors.Is(err, service.ErrIdempotencyKeyInProgress):
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			default:
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check Idempotency-Key"})
+			}
+			return
+		}
+
+		// 重试：返回第一次的响应
+		if record.CompletedAt != nil {
+			c.Header("Idempotent-Replayed", "true")
+			if record.ETag != "" {
+				c.Header("ETag", record.ETag)
+			}
+			c.Data(record.StatusCode, record.ContentType, record.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		completed := false
+		defer func() {
+			// Handler 出错 (5xx 或 panic) 或要求客户端修正请求时放弃记录，客户端可以用同一个 Key 重试
+			if !completed {
+				if err := idempotencyService.Release(record); err != nil {
+					c.Error(err)
+				}
+			}
+		}()
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError || retryableStatuses[status] {
+			return
+		}
+		err = idempotencyService.Complete(record, service.IdempotencyResponse{
+			StatusCode:  status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			ETag:        recorder.Header().Get("ETag"),
+			Body:        recorder.body.Bytes(),
+		})
+		// 响应已经写出，保存失败时只能放弃记录
+		completed = err == nil
+		if err != nil {
+			c.Error(err)
+		}
+	}
+}
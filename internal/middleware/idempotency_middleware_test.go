@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// memoryIdempotencyRepository 是内存中的 IdempotencyRepository，让测试经过真实的 IdempotencyService
+type memoryIdempotencyRepository struct {
+	records map[string]*core.IdempotencyRecord
+}
+
+func (r *memoryIdempotencyRepository) Create(record *core.IdempotencyRecord) (bool, error) {
+	id := record.UserID.String() + "/" + record.Key
+	if _, ok := r.records[id]; ok {
+		return false, nil
+	}
+	r.records[id] = record
+	return true, nil
+}
+
+func (r *memoryIdempotencyRepository) GetByKey(userID uuid.UUID, key string) (*core.IdempotencyRecord, error) {
+	if record, ok := r.records[userID.String()+"/"+key]; ok {
+		return record, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryIdempotencyRepository) Complete(record *core.IdempotencyRecord) error {
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Delete(record *core.IdempotencyRecord) error {
+	delete(r.records, record.UserID.String()+"/"+record.Key)
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) DeleteExpired(before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	repo := &memoryIdempotencyRepository{records: map[string]*core.IdempotencyRecord{}}
+	userID := uuid.New()
+	calls := 0
+	failing := true
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Next()
+	})
+	router.Use(IdempotencyMiddleware(service.NewIdempotencyService(repo, time.Hour)))
+	router.POST("/applications", func(c *gin.Context) {
+		calls++
+		c.Header("ETag", `"1"`)
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+	router.POST("/flaky", func(c *gin.Context) {
+		calls++
+		if failing {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create application"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"call": calls})
+	})
+
+	send := func(path, key, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("retry returns the first response", func(t *testing.T) {
+		calls = 0
+		first := send("/applications", "create-1", `{"customer_name":"Acme"}`)
+		retry := send("/applications", "create-1", `{"customer_name":"Acme"}`)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.JSONEq(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, `"1"`, retry.Header().Get("ETag"))
+	})
+
+	t.Run("key reused with a different payload", func(t *testing.T) {
+		calls = 0
+		send("/applications", "create-2", `{"customer_name":"Acme"}`)
+		w := send("/applications", "create-2", `{"customer_name":"Other"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("requests without a key are not deduplicated", func(t *testing.T) {
+		calls = 0
+		send("/applications", "", `{"customer_name":"Acme"}`)
+		send("/applications", "", `{"customer_name":"Acme"}`)
+
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("server errors are not saved", func(t *testing.T) {
+		calls = 0
+		failing = true
+		first := send("/flaky", "flaky-1", `{}`)
+		failing = false
+		retry := send("/flaky", "flaky-1", `{}`)
+
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, http.StatusOK, retry.Code)
+		assert.Equal(t, 2, calls)
+		assert.Empty(t, retry.Header().Get("Idempotent-Replayed"))
+	})
+
+	t.Run("precondition responses are not saved", func(t *testing.T) {
+		calls = 0
+		router.POST("/approve", func(c *gin.Context) {
+			calls++
+			switch c.GetHeader("If-Match") {
+			case "":
+				c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required"})
+			case `"1"`:
+				c.JSON(http.StatusPreconditionFailed, gin.H{"error": "application has been modified"})
+			default:
+				c.JSON(http.StatusOK, gin.H{"call": calls})
+			}
+		})
+		sendIfMatch := func(ifMatch string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest(http.MethodPost, "/approve", strings.NewReader(`{"id":"1"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(IdempotencyKeyHeader, "approve-1")
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		// 缺少 If-Match → 带上过期的 ETag → 重新读取后带上当前 ETag，每一次都会执行
+		missing := sendIfMatch("")
+		stale := sendIfMatch(`"1"`)
+		current := sendIfMatch(`"2"`)
+
+		assert.Equal(t, http.StatusPreconditionRequired, missing.Code)
+		assert.Equal(t, http.StatusPreconditionFailed, stale.Code)
+		assert.Equal(t, http.StatusOK, current.Code)
+		assert.Empty(t, current.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, 3, calls)
+
+		// 成功的响应照常保存
+		replay := sendIfMatch(`"2"`)
+		assert.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("key too long", func(t *testing.T) {
+		w := send("/applications", strings.Repeat("k", 256), `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
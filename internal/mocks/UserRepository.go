@@ -31,6 +31,36 @@ func (_m *UserRepository) Create(user *core.User) error {
 	return r0
 }
 
+// FindByUsernames provides a mock function with given fields: usernames
+func (_m *UserRepository) FindByUsernames(usernames []string) ([]core.User, error) {
+	ret := _m.Called(usernames)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUsernames")
+	}
+
+	var r0 []core.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]core.User, error)); ok {
+		return rf(usernames)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []core.User); ok {
+		r0 = rf(usernames)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]core.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(usernames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByUsername provides a mock function with given fields: username
 func (_m *UserRepository) GetByUsername(username string) (*core.User, error) {
 	ret := _m.Called(username)
@@ -4,18 +4,34 @@
 package repository
 
 import (
+	"errors"
 	"xquant-default-management/internal/core"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// ErrVersionConflict 表示申请在读取之后已被其他请求修改 (乐观锁版本号不一致)。
+var ErrVersionConflict = errors.New("application has been modified by another request")
+
+// pendingApplicationStatuses 是尚未结束审核的申请状态。客户有这些状态的申请时不能再提交新的申请。
+var pendingApplicationStatuses = []core.ApplicationStatus{core.StatusPending, core.StatusReturned}
+
 // QueryParams 定义了查询申请的过滤条件
 type QueryParams struct {
 	CustomerName *string // 使用指针以区分 "未提供" 和 "空字符串"
 	Status       *string
 	Page         int
 	PageSize     int
+
+	// VisibleTo 不为空时只查询该用户提交或发起重生的申请 (申请人的可见范围)
+	VisibleTo *uuid.UUID
+}
+
+// AssigneeFilter 按分派情况过滤申请，零值表示不过滤
+type AssigneeFilter struct {
+	AssigneeID *uuid.UUID // 只查询分派给该审批人的申请
+	Unassigned bool       // 只查询尚未分派的申请
 }
 
 // ApplicationRepository 定义了与 DefaultApplication 模型相关的数据操作接口。
@@ -24,15 +40,37 @@ type ApplicationRepository interface {
 	// Create 插入一个新的违约申请记录。
 	Create(app *core.DefaultApplication) error
 
-	// FindPendingByCustomerID 根据客户 ID 查找一个状态为 "Pending" 的申请。
+	// FindPendingByCustomerID 根据客户 ID 查找一个尚未结束审核 (Pending 或 Returned) 的申请。
 	// 如果没有找到，它会返回 (nil, nil)，表示“未找到”是一个正常的业务场景，而非错误。
 	FindPendingByCustomerID(customerID uuid.UUID) (*core.DefaultApplication, error)
 	GetByID(id uuid.UUID) (*core.DefaultApplication, error) // 新增
+	// GetDetailByID 查询申请的完整记录，预加载客户、全部经办人、评论和审批记录。
+	GetDetailByID(id uuid.UUID) (*core.DefaultApplication, error)
 	// Update(app *core.DefaultApplication, updates map[string]interface{}) error // 修改接口
+	// Update 只更新指定的字段，并校验、递增乐观锁版本号。
 	Update(app *core.DefaultApplication, fields ...string) error
-	FindAllByStatus(status string) ([]core.DefaultApplication, error)     // 新增
-	FindAll(params QueryParams) ([]core.DefaultApplication, int64, error) // 新增
+	FindAllByStatus(status core.ApplicationStatus, filter AssigneeFilter) ([]core.DefaultApplication, error) // 新增
+	FindAll(params QueryParams) ([]core.DefaultApplication, int64, error)                                    // 新增
+	// FindByTriggerApplicationID 查找由某个申请触发的全部关联违约申请。
+	FindByTriggerApplicationID(triggerID uuid.UUID) ([]core.DefaultApplication, error)
+	// FindByCustomerID 查找某个客户的全部违约申请，并预加载审批人信息。
+	FindByCustomerID(customerID uuid.UUID) ([]core.DefaultApplication, error)
+
+	// CreateApprovalStep 记录多级审批中的一级审批。
+	CreateApprovalStep(step *core.ApprovalStep) error
+	// FindApprovalSteps 查询申请在某个审批环节、某一轮次中已有的审批记录，按级别排序。
+	FindApprovalSteps(appID uuid.UUID, stage core.ApplicationEvent, round int) ([]core.ApprovalStep, error)
+	// DeleteApprovalSteps 删除申请在某个审批环节中的全部审批记录。
+	DeleteApprovalSteps(appID uuid.UUID, stage core.ApplicationEvent) error
+	// CreateRebirthRejection 记录一次被驳回的重生申请。
+	CreateRebirthRejection(rejection *core.RebirthRejection) error
+	// CreateRebirthWithdrawal 记录一次被撤回的重生申请。
+	CreateRebirthWithdrawal(withdrawal *core.RebirthWithdrawal) error
 
+	// CreateRevision 保存申请某一轮提交内容的快照。
+	CreateRevision(revision *core.ApplicationRevision) error
+	// FindRevisions 查询申请的历轮快照 (按轮次排序)，并预加载退回人。
+	FindRevisions(appID uuid.UUID) ([]core.ApplicationRevision, error)
 }
 
 // applicationRepository 是 ApplicationRepository 接口的具体实现。
@@ -54,12 +92,13 @@ func (r *applicationRepository) Create(app *core.DefaultApplication) error {
 }
 
 // FindPendingByCustomerID 在数据库中查找特定客户的、状态为 "Pending" 的违约申请。
+// 被退回 (Returned) 的申请仍在审核流程中，同样视为待处理。
 func (r *applicationRepository) FindPendingByCustomerID(customerID uuid.UUID) (*core.DefaultApplication, error) {
 	var app core.DefaultApplication
 
 	// 使用 GORM 构建查询，条件为 customer_id 匹配且 status 为 "Pending"。
 	// First() 方法会查找第一条匹配的记录。
-	err := r.db.Where("customer_id = ? AND status = ?", customerID, "Pending").First(&app).Error
+	err := r.db.Where("customer_id = ? AND status IN ?", customerID, pendingApplicationStatuses).First(&app).Error
 
 	// 关键的错误处理逻辑：
 	// 在业务上，“找不到一个待处理的申请”是一个非常正常的、预期内的结果，而不是一个需要上报的“系统错误”。
@@ -82,21 +121,94 @@ func (r *applicationRepository) GetByID(id uuid.UUID) (*core.DefaultApplication,
 	return &app, err
 }
 
+// GetDetailByID 查询申请详情。除列表查询的预加载外，还会加载讨论评论和逐级审批记录 (按环节、轮次、级别排序)。
+// 评论只在详情中返回，由 Service 层先校验操作者能否查看申请。
+func (r *applicationRepository) GetDetailByID(id uuid.UUID) (*core.DefaultApplication, error) {
+	var app core.DefaultApplication
+	err := preloadApplicationDetails(r.db).
+		// 预加载讨论评论 (从早到晚) 及其作者、提及的用户和编辑历史
+		Preload("Comments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc")
+		}).
+		Preload("Comments.Author").
+		Preload("Comments.Mentions").
+		Preload("Comments.Edits", func(db *gorm.DB) *gorm.DB {
+			return db.Order("edited_at asc")
+		}).
+		Preload("ApprovalSteps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("stage asc, round asc, level asc")
+		}).
+		Preload("ApprovalSteps.Approver").
+		First(&app, id).Error
+	return &app, err
+}
+
+// preloadApplicationDetails 预加载申请详情展示需要的关联数据：客户及其评级历史、各环节的经办人和
+// 被驳回、被撤回的重生申请。只用于详情查询，列表查询只预加载生成列表行需要的关联。
+func preloadApplicationDetails(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Customer").
+		// 预加载客户的评级历史 (从新到旧)，用于在查询结果中展示评级轨迹
+		Preload("Customer.Ratings", func(db *gorm.DB) *gorm.DB {
+			return db.Order("effective_date desc, created_at desc")
+		}).
+		Preload("Applicant").
+		Preload("Approver").
+		Preload("Assignee").
+		Preload("AssignedBy").
+		Preload("RebirthApplicant").
+		Preload("RebirthApprover").
+		Preload("WithdrawnBy").
+		// 预加载被驳回的重生申请历史 (从早到晚)
+		Preload("RebirthRejections", func(db *gorm.DB) *gorm.DB {
+			return db.Order("rejected_at asc")
+		}).
+		Preload("RebirthRejections.RejectedBy").
+		// 预加载被撤回的重生申请历史 (从早到晚)
+		Preload("RebirthWithdrawals", func(db *gorm.DB) *gorm.DB {
+			return db.Order("withdrawn_at asc")
+		}).
+		Preload("RebirthWithdrawals.WithdrawnBy")
+}
+
 // Update 方法现在只更新传入的 map 中指定的字段
 // func (r *applicationRepository) Update(app *core.DefaultApplication, updates map[string]interface{}) error {
 // 	return r.db.Model(app).Updates(updates).Error
 // }
 
-// Update 只更新指定的字段
+// Update 只更新指定的字段，并以读取时的版本号为条件把版本号加一 (乐观锁)。
+// 申请在读取之后已被其他请求修改时不会更新任何记录，返回 ErrVersionConflict，app.Version 保持不变。
 func (r *applicationRepository) Update(app *core.DefaultApplication, fields ...string) error {
-	return r.db.Model(app).Select(fields).Updates(app).Error
+	current := app.Version
+	app.Version = current + 1
+	result := r.db.Model(app).
+		Where("version = ?", current).
+		Select(append(append([]string{}, fields...), "Version")).
+		Updates(app)
+	if result.Error != nil {
+		app.Version = current
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		app.Version = current
+		return ErrVersionConflict
+	}
+	return nil
 }
 
 // FindAllByStatus 根据状态查找所有申请单
-func (r *applicationRepository) FindAllByStatus(status string) ([]core.DefaultApplication, error) {
+// 可以按分派情况进一步过滤 (我的 / 未分派)。
+func (r *applicationRepository) FindAllByStatus(status core.ApplicationStatus, filter AssigneeFilter) ([]core.DefaultApplication, error) {
 	var apps []core.DefaultApplication
-	// 为了在列表中显示客户和申请人信息，我们必须在这里预加载它们
-	err := r.db.Preload("Customer").Preload("Applicant").Where("status = ?", status).Find(&apps).Error
+	// 为了在列表中显示客户、申请人和审批人信息，我们必须在这里预加载它们
+	query := r.db.Preload("Customer").Preload("Applicant").Preload("Assignee").Where("status = ?", status)
+	switch {
+	case filter.AssigneeID != nil:
+		query = query.Where("assignee_id = ?", *filter.AssigneeID)
+	case filter.Unassigned:
+		query = query.Where("assignee_id IS NULL")
+	}
+	err := query.Order("application_time asc").Find(&apps).Error
 	return apps, err
 }
 
@@ -115,6 +227,9 @@ func (r *applicationRepository) buildFilteredQuery(params QueryParams) *gorm.DB
 	if params.Status != nil && *params.Status != "" {
 		query = query.Where("status = ?", *params.Status)
 	}
+	if params.VisibleTo != nil {
+		query = query.Where("applicant_id = ? OR rebirth_applicant_id = ?", *params.VisibleTo, *params.VisibleTo)
+	}
 
 	return query
 }
@@ -146,6 +261,7 @@ func (r *applicationRepository) FindAll(params QueryParams) ([]core.DefaultAppli
 		Preload("Customer").
 		Preload("Applicant").
 		Preload("Approver").
+		Preload("Assignee").
 		Offset(offset).
 		Limit(params.PageSize).
 		Order("application_time desc").
@@ -153,3 +269,61 @@ func (r *applicationRepository) FindAll(params QueryParams) ([]core.DefaultAppli
 
 	return apps, total, err
 }
+
+// FindByTriggerApplicationID 查找由指定申请触发的关联违约申请，并预加载客户信息
+func (r *applicationRepository) FindByTriggerApplicationID(triggerID uuid.UUID) ([]core.DefaultApplication, error) {
+	var apps []core.DefaultApplication
+	err := r.db.Preload("Customer").Where("trigger_application_id = ?", triggerID).Find(&apps).Error
+	return apps, err
+}
+
+// FindByCustomerID 查找客户的全部违约申请 (按提交时间从早到晚)，并预加载认定和重生的审批人
+func (r *applicationRepository) FindByCustomerID(customerID uuid.UUID) ([]core.DefaultApplication, error) {
+	var apps []core.DefaultApplication
+	err := r.db.Preload("Approver").
+		Preload("RebirthApprover").
+		Where("customer_id = ?", customerID).
+		Order("application_time asc").
+		Find(&apps).Error
+	return apps, err
+}
+
+// CreateApprovalStep 写入一条审批记录
+func (r *applicationRepository) CreateApprovalStep(step *core.ApprovalStep) error {
+	return r.db.Create(step).Error
+}
+
+// FindApprovalSteps 查询申请在某个审批环节、某一轮次中的审批记录 (按级别从低到高)
+func (r *applicationRepository) FindApprovalSteps(appID uuid.UUID, stage core.ApplicationEvent, round int) ([]core.ApprovalStep, error) {
+	var steps []core.ApprovalStep
+	err := r.db.Where("application_id = ? AND stage = ? AND round = ?", appID, stage, round).Order("level asc").Find(&steps).Error
+	return steps, err
+}
+
+// DeleteApprovalSteps 删除审批记录。
+// 使用硬删除 (Unscoped)，否则软删除的记录仍会占用 (application_id, stage, level) 唯一索引。
+func (r *applicationRepository) DeleteApprovalSteps(appID uuid.UUID, stage core.ApplicationEvent) error {
+	return r.db.Unscoped().Where("application_id = ? AND stage = ?", appID, stage).Delete(&core.ApprovalStep{}).Error
+}
+
+// CreateRebirthRejection 写入一条重生驳回记录
+func (r *applicationRepository) CreateRebirthRejection(rejection *core.RebirthRejection) error {
+	return r.db.Create(rejection).Error
+}
+
+// CreateRebirthWithdrawal 写入一条重生撤回记录
+func (r *applicationRepository) CreateRebirthWithdrawal(withdrawal *core.RebirthWithdrawal) error {
+	return r.db.Create(withdrawal).Error
+}
+
+// CreateRevision 写入一轮提交内容的快照
+func (r *applicationRepository) CreateRevision(revision *core.ApplicationRevision) error {
+	return r.db.Create(revision).Error
+}
+
+// FindRevisions 查询申请的历轮快照
+func (r *applicationRepository) FindRevisions(appID uuid.UUID) ([]core.ApplicationRevision, error) {
+	var revisions []core.ApplicationRevision
+	err := r.db.Preload("ReturnedBy").Where("application_id = ?", appID).Order("round asc").Find(&revisions).Error
+	return revisions, err
+}
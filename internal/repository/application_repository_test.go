@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"xquant-default-management/internal/core"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplicationRepository_Update(t *testing.T) {
+	updateSQL := regexp.QuoteMeta(`UPDATE "default_applications" SET "updated_at"=$1,"version"=$2,"status"=$3 WHERE version = $4 AND "default_applications"."deleted_at" IS NULL AND "id" = $5`)
+
+	t.Run("increments the version", func(t *testing.T) {
+		gormDB, mock := setupMockDB(t)
+		repo := NewApplicationRepository(gormDB)
+		app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Version: 3, Status: core.StatusApproved}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateSQL).
+			WithArgs(AnyTime{}, 4, core.StatusApproved, 3, app.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Update(app, "Status")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, app.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		gormDB, mock := setupMockDB(t)
+		repo := NewApplicationRepository(gormDB)
+		app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Version: 3, Status: core.StatusApproved}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateSQL).
+			WithArgs(AnyTime{}, 4, core.StatusApproved, 3, app.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.Update(app, "Status")
+
+		assert.ErrorIs(t, err, ErrVersionConflict)
+		assert.Equal(t, 3, app.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestApplicationRepository_FindAll_VisibleTo(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	repo := NewApplicationRepository(gormDB)
+	applicantID := uuid.New()
+
+	// 申请人只能查到自己提交或发起重生的申请，其他申请人的申请不会被计入
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "default_applications" WHERE (applicant_id = $1 OR rebirth_applicant_id = $2) AND "default_applications"."deleted_at" IS NULL`)).
+		WithArgs(applicantID, applicantID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	apps, total, err := repo.FindAll(QueryParams{VisibleTo: &applicantID, Page: 1, PageSize: 10})
+
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, apps)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"xquant-default-management/internal/core"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository 定义了违约申请证据附件元数据相关的数据操作接口。
+type AttachmentRepository interface {
+	// Create 插入一条附件元数据。
+	Create(attachment *core.Attachment) error
+	// GetByID 根据 ID 查询附件，并预加载上传人。
+	GetByID(id uuid.UUID) (*core.Attachment, error)
+	// FindByApplicationID 查询申请的全部附件，按上传时间排序，并预加载上传人。
+	FindByApplicationID(appID uuid.UUID) ([]core.Attachment, error)
+	// FindByChecksum 查询申请下内容相同的附件。没有时返回 (nil, nil)。
+	FindByChecksum(appID uuid.UUID, sha256 string) (*core.Attachment, error)
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository 是 attachmentRepository 的构造函数。
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+// Create 插入一条附件元数据
+func (r *attachmentRepository) Create(attachment *core.Attachment) error {
+	return r.db.Create(attachment).Error
+}
+
+// GetByID 根据 ID 查询附件
+func (r *attachmentRepository) GetByID(id uuid.UUID) (*core.Attachment, error) {
+	var attachment core.Attachment
+	err := r.db.Preload("UploadedBy").First(&attachment, "id = ?", id).Error
+	return &attachment, err
+}
+
+// FindByApplicationID 查询申请的全部附件
+func (r *attachmentRepository) FindByApplicationID(appID uuid.UUID) ([]core.Attachment, error) {
+	var attachments []core.Attachment
+	err := r.db.Preload("UploadedBy").
+		Where("application_id = ?", appID).
+		Order("created_at asc").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// FindByChecksum 查询申请下内容相同的附件
+func (r *attachmentRepository) FindByChecksum(appID uuid.UUID, sha256 string) (*core.Attachment, error) {
+	var attachment core.Attachment
+	err := r.db.Where("application_id = ? AND sha256 = ?", appID, sha256).First(&attachment).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &attachment, err
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"xquant-default-management/internal/core"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CommentRepository 定义了违约申请讨论评论相关的数据操作接口。
+type CommentRepository interface {
+	// Create 插入一条评论，并写入其提及的用户。
+	Create(comment *core.Comment) error
+	// GetByID 根据 ID 查询评论，并预加载作者、提及的用户和编辑历史。
+	GetByID(id uuid.UUID) (*core.Comment, error)
+	// FindByApplicationID 查询申请下的全部评论 (从早到晚)，预加载内容同 GetByID。
+	FindByApplicationID(appID uuid.UUID) ([]core.Comment, error)
+	// Update 只更新评论的指定字段。
+	Update(comment *core.Comment, fields ...string) error
+	// ReplaceMentions 用新的用户列表替换评论提及的用户。
+	ReplaceMentions(comment *core.Comment, users []core.User) error
+	// CreateEdit 保存评论编辑之前的版本。
+	CreateEdit(edit *core.CommentEdit) error
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository 是 commentRepository 的构造函数。
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+// Create 插入一条评论。Omit("Mentions.*") 只写入关联表，不回写被提及的用户记录本身。
+func (r *commentRepository) Create(comment *core.Comment) error {
+	return r.db.Omit("Mentions.*").Create(comment).Error
+}
+
+// preloadComment 预加载评论的作者、提及的用户和编辑历史 (从早到晚)
+func preloadComment(db *gorm.DB) *gorm.DB {
+	return db.Preload("Author").
+		Preload("Mentions").
+		Preload("Edits", func(db *gorm.DB) *gorm.DB {
+			return db.Order("edited_at asc")
+		})
+}
+
+// GetByID 根据 ID 查询评论
+func (r *commentRepository) GetByID(id uuid.UUID) (*core.Comment, error) {
+	var comment core.Comment
+	err := preloadComment(r.db).First(&comment, "id = ?", id).Error
+	return &comment, err
+}
+
+// FindByApplicationID 查询申请下的全部评论
+func (r *commentRepository) FindByApplicationID(appID uuid.UUID) ([]core.Comment, error) {
+	var comments []core.Comment
+	err := preloadComment(r.db).
+		Where("application_id = ?", appID).
+		Order("created_at asc").
+		Find(&comments).Error
+	return comments, err
+}
+
+// Update 只更新指定的字段
+func (r *commentRepository) Update(comment *core.Comment, fields ...string) error {
+	return r.db.Model(comment).Select(fields).Updates(comment).Error
+}
+
+// ReplaceMentions 替换评论提及的用户
+func (r *commentRepository) ReplaceMentions(comment *core.Comment, users []core.User) error {
+	return r.db.Model(comment).Association("Mentions").Replace(users)
+}
+
+// CreateEdit 保存评论编辑之前的版本
+func (r *commentRepository) CreateEdit(edit *core.CommentEdit) error {
+	return r.db.Create(edit).Error
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"xquant-default-management/internal/core"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomerGroupRepository 定义了与关联集团 (CustomerGroup) 相关的数据操作接口。
+type CustomerGroupRepository interface {
+	Create(group *core.CustomerGroup) error
+	// GetByID 获取集团，并预加载母公司和全部成员。
+	GetByID(id uuid.UUID) (*core.CustomerGroup, error)
+	GetByName(name string) (*core.CustomerGroup, error)
+	FindAll() ([]core.CustomerGroup, error)
+	Update(group *core.CustomerGroup, fields ...string) error
+	// Delete 软删除集团，并解除所有成员与该集团的关联。
+	Delete(id uuid.UUID) error
+}
+
+type customerGroupRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomerGroupRepository 是 customerGroupRepository 的构造函数。
+func NewCustomerGroupRepository(db *gorm.DB) CustomerGroupRepository {
+	return &customerGroupRepository{db: db}
+}
+
+// Create 插入一个新的集团记录
+func (r *customerGroupRepository) Create(group *core.CustomerGroup) error {
+	return r.db.Create(group).Error
+}
+
+// GetByID 根据 ID 获取集团，并预加载母公司和成员信息
+func (r *customerGroupRepository) GetByID(id uuid.UUID) (*core.CustomerGroup, error) {
+	var group core.CustomerGroup
+	err := r.db.Preload("Parent").Preload("Members").First(&group, id).Error
+	return &group, err
+}
+
+// GetByName 根据名称获取集团，集团名称被假定为唯一的
+func (r *customerGroupRepository) GetByName(name string) (*core.CustomerGroup, error) {
+	var group core.CustomerGroup
+	err := r.db.Where("name = ?", name).First(&group).Error
+	return &group, err
+}
+
+// FindAll 查询全部集团，并预加载母公司和成员信息
+func (r *customerGroupRepository) FindAll() ([]core.CustomerGroup, error) {
+	var groups []core.CustomerGroup
+	err := r.db.Preload("Parent").Preload("Members").Order("name asc").Find(&groups).Error
+	return groups, err
+}
+
+// Update 只更新指定的字段
+func (r *customerGroupRepository) Update(group *core.CustomerGroup, fields ...string) error {
+	return r.db.Model(group).Select(fields).Updates(group).Error
+}
+
+// Delete 先解除成员关联，再软删除集团。调用方应在事务中调用以保证两步的原子性。
+func (r *customerGroupRepository) Delete(id uuid.UUID) error {
+	if err := r.db.Model(&core.Customer{}).Where("group_id = ?", id).Update("group_id", nil).Error; err != nil {
+		return err
+	}
+	return r.db.Delete(&core.CustomerGroup{}, id).Error
+}
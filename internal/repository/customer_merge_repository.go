@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"xquant-default-management/internal/core"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomerMergeRepository 定义了客户去重合并相关的数据操作接口。
+type CustomerMergeRepository interface {
+	// ListCustomers 查询全部未删除的客户，用于重复客户检测。
+	ListCustomers() ([]core.Customer, error)
+	// ReassignReferences 将所有引用 fromID 的违约申请、评级、敞口、曾用名、申请草稿和集团母公司改为引用 toID，
+	// 返回被转移的违约申请数量。
+	ReassignReferences(fromID, toID uuid.UUID) (int64, error)
+	// HasActiveDefault 判断客户是否有处于违约状态 (Approved / RebirthPending) 的申请。
+	HasActiveDefault(customerID uuid.UUID) (bool, error)
+	CreateRecord(record *core.CustomerMergeRecord) error
+	// FindRecords 查询合并审计记录，customerID 不为 nil 时只返回与该客户相关 (作为保留方或被合并方) 的记录。
+	FindRecords(customerID *uuid.UUID) ([]core.CustomerMergeRecord, error)
+}
+
+type customerMergeRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomerMergeRepository 是 customerMergeRepository 的构造函数。
+func NewCustomerMergeRepository(db *gorm.DB) CustomerMergeRepository {
+	return &customerMergeRepository{db: db}
+}
+
+// ListCustomers 查询全部客户 (只取检测所需的字段)
+func (r *customerMergeRepository) ListCustomers() ([]core.Customer, error) {
+	var customers []core.Customer
+	err := r.db.Select("id", "name", "credit_code", "is_default", "created_at").Order("name asc").Find(&customers).Error
+	return customers, err
+}
+
+// ReassignReferences 批量转移客户的关联数据
+func (r *customerMergeRepository) ReassignReferences(fromID, toID uuid.UUID) (int64, error) {
+	// 申请改挂到保留客户名下，客户端看到的申请内容发生了变化，同时递增乐观锁版本号
+	result := r.db.Model(&core.DefaultApplication{}).Where("customer_id = ?", fromID).
+		Updates(map[string]interface{}{"customer_id": toID, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	for _, model := range []interface{}{&core.ExternalRating{}, &core.Exposure{}, &core.CustomerAlias{}, &core.ApplicationDraft{}} {
+		if err := r.db.Model(model).Where("customer_id = ?", fromID).Update("customer_id", toID).Error; err != nil {
+			return 0, err
+		}
+	}
+	// 尚未提交、按信用代码或名称指定客户的草稿同样指向保留客户，
+	// 否则被合并客户的信用代码留在已删除的记录上时，草稿提交会找不到客户
+	err := r.db.Model(&core.ApplicationDraft{}).
+		Where("customer_id IS NULL AND submitted_at IS NULL").
+		Where("customer_credit_code IN (SELECT credit_code FROM customers WHERE id = ?) OR customer_name IN (SELECT name FROM customers WHERE id = ?)", fromID, fromID).
+		Update("customer_id", toID).Error
+	if err != nil {
+		return 0, err
+	}
+	// 以被合并客户为母公司的关联集团改为以保留客户为母公司
+	err = r.db.Model(&core.CustomerGroup{}).Where("parent_customer_id = ?", fromID).Update("parent_customer_id", toID).Error
+	return result.RowsAffected, err
+}
+
+// HasActiveDefault 判断客户当前是否处于违约状态
+func (r *customerMergeRepository) HasActiveDefault(customerID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&core.DefaultApplication{}).
+		Where("customer_id = ? AND status IN ?", customerID, []core.ApplicationStatus{core.StatusApproved, core.StatusRebirthPending}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateRecord 写入一条合并审计记录
+func (r *customerMergeRepository) CreateRecord(record *core.CustomerMergeRecord) error {
+	return r.db.Create(record).Error
+}
+
+// FindRecords 查询合并审计记录，按合并时间从新到旧排序
+func (r *customerMergeRepository) FindRecords(customerID *uuid.UUID) ([]core.CustomerMergeRecord, error) {
+	var records []core.CustomerMergeRecord
+	query := r.db.Preload("Operator")
+	if customerID != nil {
+		query = query.Where("survivor_id = ? OR loser_id = ?", *customerID, *customerID)
+	}
+	err := query.Order("merged_at desc").Find(&records).Error
+	return records, err
+}
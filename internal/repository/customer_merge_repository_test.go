@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomerMergeRepository_ReassignReferences(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	repo := NewCustomerMergeRepository(gormDB)
+	fromID, toID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "default_applications" SET "customer_id"=\$1,"version"=version \+ 1,"updated_at"=\$2 WHERE customer_id = \$3`).
+		WithArgs(toID, sqlmock.AnyArg(), fromID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	for _, table := range []string{"external_ratings", "exposures", "customer_aliases", "application_drafts"} {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "`+table+`" SET "customer_id"=\$1,"updated_at"=\$2 WHERE customer_id = \$3`).
+			WithArgs(toID, sqlmock.AnyArg(), fromID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	// 按被合并客户的信用代码或名称指定客户、尚未提交的草稿同样改为指向保留客户
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "application_drafts" SET "customer_id"=\$1,"updated_at"=\$2 WHERE \(customer_id IS NULL AND submitted_at IS NULL\) AND \(customer_credit_code IN \(SELECT credit_code FROM customers WHERE id = \$3\) OR customer_name IN \(SELECT name FROM customers WHERE id = \$4\)\)`).
+		WithArgs(toID, sqlmock.AnyArg(), fromID, fromID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "customer_groups" SET "parent_customer_id"=\$1,"updated_at"=\$2 WHERE parent_customer_id = \$3`).
+		WithArgs(toID, sqlmock.AnyArg(), fromID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	moved, err := repo.ReassignReferences(fromID, toID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), moved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,7 @@
 package repository
 
 import (
+	"strings"
 	"xquant-default-management/internal/core"
 
 	"github.com/google/uuid"
@@ -21,18 +22,77 @@ type CustomerRepository interface {
 	// GetByID 根据客户的 UUID 主键查找一个客户记录。
 	GetByID(id uuid.UUID) (*core.Customer, error)
 	Update(app *core.Customer, fields ...string) error
+	// FindAll 根据过滤条件分页查询客户，并返回总数用于分页。
+	FindAll(params CustomerQueryParams) ([]core.Customer, int64, error)
+	// Delete 软删除一个客户记录 (GORM 会填充 deleted_at 字段)。
+	Delete(id uuid.UUID) error
+	// FindByGroupID 查找属于某个关联集团的全部成员客户。
+	FindByGroupID(groupID uuid.UUID) ([]core.Customer, error)
+	// GetByCreditCode 根据统一社会信用代码查找客户。
+	GetByCreditCode(creditCode string) (*core.Customer, error)
+	// GetByAlias 根据曾用名查找客户。同一名称被多个客户用过时，返回最近一次停用该名称的客户。
+	GetByAlias(name string) (*core.Customer, error)
+	// CreateAlias 记录一条客户曾用名。
+	CreateAlias(alias *core.CustomerAlias) error
+	// FindAliases 查询客户的全部曾用名，按停用时间从新到旧排序。
+	FindAliases(customerID uuid.UUID) ([]core.CustomerAlias, error)
+	// Search 按名称的前缀、子串和三元组相似度搜索客户，结果按匹配程度排序。
+	Search(q string, limit int) ([]CustomerMatch, error)
+	// GetDeletedByName 查找已被软删除的同名客户。软删除的客户仍然占用名称的唯一索引。
+	GetDeletedByName(name string) (*core.Customer, error)
+	// GetDeletedByCreditCode 查找已被软删除、仍持有该统一社会信用代码的客户。
+	GetDeletedByCreditCode(creditCode string) (*core.Customer, error)
+	// Restore 恢复一个被软删除的客户 (清空 deleted_at)，同时更新指定的字段。
+	Restore(customer *core.Customer, fields ...string) error
+}
+
+// 客户搜索的匹配等级，数值越大匹配越精确
+const (
+	MatchRankFuzzy     = 1 // 三元组相似度达到阈值
+	MatchRankSubstring = 2 // 名称包含关键字
+	MatchRankPrefix    = 3 // 名称以关键字开头
+	MatchRankExact     = 4 // 名称或统一社会信用代码完全一致
+)
+
+// customerSearchSimilarityThreshold 是模糊匹配的相似度阈值 (与 pg_trgm 的默认阈值一致)
+const customerSearchSimilarityThreshold = 0.3
+
+// CustomerSearchOptions 是客户搜索的配置。零值表示只按 ILIKE 匹配。
+type CustomerSearchOptions struct {
+	// Trigram 为 true 时使用 pg_trgm 的 similarity() 做模糊匹配和排序，只有扩展和索引已经就绪 (database.TrigramSearch) 时才能开启；
+	// 为 false 时结果只按匹配等级和名称排序，Score 总是 0。
+	Trigram bool
+}
+
+// CustomerMatch 是客户搜索的一条结果
+type CustomerMatch struct {
+	core.Customer
+	MatchRank  int     // 匹配等级，见 MatchRank* 常量
+	Score      float64 // 名称与关键字的三元组相似度 (0 ~ 1)
+	HasPending bool    // 客户是否已有待处理的违约申请
+}
+
+// CustomerQueryParams 定义了查询客户的过滤条件
+type CustomerQueryParams struct {
+	Industry  *string // 使用指针以区分 "未提供" 和 "空字符串"
+	Region    *string
+	IsDefault *bool
+	Page      int
+	PageSize  int
 }
 
 // customerRepository 是 CustomerRepository 接口的具体实现。
 // 它内部持有 *gorm.DB 数据库连接实例，用于执行实际的数据库操作。
 type customerRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	search CustomerSearchOptions
 }
 
 // NewCustomerRepository 是 customerRepository 的构造函数。
 // 它接收一个数据库连接实例，并通过依赖注入的方式创建一个新的 Repository。
-func NewCustomerRepository(db *gorm.DB) CustomerRepository {
-	return &customerRepository{db: db}
+// search 决定 Search 是否使用 pg_trgm；只在事务中读写客户、不做搜索的调用方传零值即可。
+func NewCustomerRepository(db *gorm.DB, search CustomerSearchOptions) CustomerRepository {
+	return &customerRepository{db: db, search: search}
 }
 
 // Create 使用 GORM 的 Create 方法将一个新的客户实体持久化到数据库中。
@@ -69,3 +129,141 @@ func (r *customerRepository) GetByID(id uuid.UUID) (*core.Customer, error) {
 func (r *customerRepository) Update(app *core.Customer, fields ...string) error {
 	return r.db.Model(app).Select(fields).Updates(app).Error
 }
+
+// FindAll 根据行业、区域和违约状态过滤客户，并返回总数用于分页
+func (r *customerRepository) FindAll(params CustomerQueryParams) ([]core.Customer, int64, error) {
+	var customers []core.Customer
+	var total int64
+
+	// 1. 动态构建 WHERE 条件
+	query := r.db.Model(&core.Customer{})
+	if params.Industry != nil && *params.Industry != "" {
+		query = query.Where("industry = ?", *params.Industry)
+	}
+	if params.Region != nil && *params.Region != "" {
+		query = query.Where("region = ?", *params.Region)
+	}
+	if params.IsDefault != nil {
+		query = query.Where("is_default = ?", *params.IsDefault)
+	}
+
+	// 2. 先统计总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return customers, total, nil
+	}
+
+	// 3. 再分页查询
+	offset := (params.Page - 1) * params.PageSize
+	err := query.Offset(offset).Limit(params.PageSize).Order("name asc").Find(&customers).Error
+	return customers, total, err
+}
+
+// Delete 软删除客户。由于 BaseModel 包含 DeletedAt 字段，GORM 只会更新 deleted_at 而不会物理删除。
+func (r *customerRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&core.Customer{}, id).Error
+}
+
+// FindByGroupID 查找属于指定关联集团的全部客户
+func (r *customerRepository) FindByGroupID(groupID uuid.UUID) ([]core.Customer, error) {
+	var customers []core.Customer
+	err := r.db.Where("group_id = ?", groupID).Order("name asc").Find(&customers).Error
+	return customers, err
+}
+
+// GetByCreditCode 根据统一社会信用代码查找客户
+func (r *customerRepository) GetByCreditCode(creditCode string) (*core.Customer, error) {
+	var customer core.Customer
+	err := r.db.Where("credit_code = ?", creditCode).First(&customer).Error
+	return &customer, err
+}
+
+// GetDeletedByName 查找已被软删除的同名客户。
+// 默认查询会自动加上 deleted_at IS NULL，这里需要 Unscoped 才能看到已删除的记录。
+func (r *customerRepository) GetDeletedByName(name string) (*core.Customer, error) {
+	var customer core.Customer
+	err := r.db.Unscoped().Where("name = ? AND deleted_at IS NOT NULL", name).First(&customer).Error
+	return &customer, err
+}
+
+// GetDeletedByCreditCode 查找已被软删除、仍持有该统一社会信用代码的客户
+func (r *customerRepository) GetDeletedByCreditCode(creditCode string) (*core.Customer, error) {
+	var customer core.Customer
+	err := r.db.Unscoped().Where("credit_code = ? AND deleted_at IS NOT NULL", creditCode).First(&customer).Error
+	return &customer, err
+}
+
+// Restore 恢复被软删除的客户。DeletedAt 总是随指定的字段一起写回 (置为 NULL)。
+func (r *customerRepository) Restore(customer *core.Customer, fields ...string) error {
+	customer.DeletedAt = gorm.DeletedAt{}
+	return r.db.Unscoped().Model(customer).Select(append(fields, "DeletedAt")).Updates(customer).Error
+}
+
+// GetByAlias 根据曾用名查找客户
+func (r *customerRepository) GetByAlias(name string) (*core.Customer, error) {
+	var customer core.Customer
+	err := r.db.Where("id = (?)",
+		r.db.Model(&core.CustomerAlias{}).Select("customer_id").Where("name = ?", name).Order("valid_until desc").Limit(1)).
+		First(&customer).Error
+	return &customer, err
+}
+
+// CreateAlias 记录一条客户曾用名
+func (r *customerRepository) CreateAlias(alias *core.CustomerAlias) error {
+	return r.db.Create(alias).Error
+}
+
+// FindAliases 查询客户的全部曾用名
+func (r *customerRepository) FindAliases(customerID uuid.UUID) ([]core.CustomerAlias, error) {
+	var aliases []core.CustomerAlias
+	err := r.db.Where("customer_id = ?", customerID).Order("valid_until desc").Find(&aliases).Error
+	return aliases, err
+}
+
+// Search 搜索客户。
+// 子串匹配使用 ILIKE，关键字中的 % 和 _ 会被转义；模糊匹配使用 pg_trgm 的 similarity() 函数，
+// 没有开启 CustomerSearchOptions.Trigram 时不做模糊匹配。
+func (r *customerRepository) Search(q string, limit int) ([]CustomerMatch, error) {
+	var matches []CustomerMatch
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(q)
+
+	score := "similarity(name, @q)"
+	if !r.search.Trigram {
+		score = "0::float8"
+	}
+	query := r.db.Model(&core.Customer{}).
+		Select(`customers.*,
+			CASE
+				WHEN lower(name) = lower(@q) OR credit_code = upper(@q) THEN @exact
+				WHEN name ILIKE @prefix THEN @prefixRank
+				WHEN name ILIKE @substring THEN @substringRank
+				ELSE @fuzzy
+			END AS match_rank,
+			`+score+` AS score,
+			EXISTS (
+				SELECT 1 FROM default_applications da
+				WHERE da.customer_id = customers.id AND da.status IN @pending AND da.deleted_at IS NULL
+			) AS has_pending`,
+			map[string]interface{}{
+				"q":             q,
+				"prefix":        escaped + "%",
+				"substring":     "%" + escaped + "%",
+				"exact":         MatchRankExact,
+				"prefixRank":    MatchRankPrefix,
+				"substringRank": MatchRankSubstring,
+				"fuzzy":         MatchRankFuzzy,
+				"pending":       pendingApplicationStatuses,
+			})
+	if r.search.Trigram {
+		query = query.Where("name ILIKE ? OR credit_code = upper(?) OR similarity(name, ?) >= ?",
+			"%"+escaped+"%", q, q, customerSearchSimilarityThreshold)
+	} else {
+		query = query.Where("name ILIKE ? OR credit_code = upper(?)", "%"+escaped+"%", q)
+	}
+	err := query.Order("match_rank desc, score desc, name asc").
+		Limit(limit).
+		Find(&matches).Error
+	return matches, err
+}
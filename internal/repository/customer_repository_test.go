@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"xquant-default-management/internal/core"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCustomerRepository_Search(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	repo := NewCustomerRepository(gormDB, CustomerSearchOptions{Trigram: true})
+	id := uuid.New()
+
+	rows := sqlmock.NewRows([]string{"id", "name", "is_default", "match_rank", "score", "has_pending"}).
+		AddRow(id, "Acme_Corp", true, MatchRankPrefix, 0.62, true)
+	mock.ExpectQuery(`SELECT customers\.\*,.*AS match_rank,.*da\.status IN \(\$\d+,\$\d+\).*AS has_pending FROM "customers" WHERE \(name ILIKE \$\d+ OR credit_code = upper\(\$\d+\) OR similarity\(name, \$\d+\) >= \$\d+\) AND "customers"\."deleted_at" IS NULL ORDER BY match_rank desc, score desc, name asc LIMIT \$\d+`).
+		WithArgs("Acme_Corp", "Acme_Corp", MatchRankExact, `Acme\_Corp%`, MatchRankPrefix, `%Acme\_Corp%`, MatchRankSubstring, MatchRankFuzzy, "Acme_Corp",
+			core.StatusPending, core.StatusReturned,
+			`%Acme\_Corp%`, "Acme_Corp", "Acme_Corp", customerSearchSimilarityThreshold, 10).
+		WillReturnRows(rows)
+
+	matches, err := repo.Search("Acme_Corp", 10)
+
+	assert.NoError(t, err)
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, id, matches[0].ID)
+		assert.Equal(t, "Acme_Corp", matches[0].Name)
+		assert.True(t, matches[0].IsDefault)
+		assert.Equal(t, MatchRankPrefix, matches[0].MatchRank)
+		assert.Equal(t, 0.62, matches[0].Score)
+		assert.True(t, matches[0].HasPending)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCustomerRepository_Search_WithoutTrigram(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	repo := NewCustomerRepository(gormDB, CustomerSearchOptions{})
+	id := uuid.New()
+
+	// pg_trgm 不可用时不调用 similarity()，只按 ILIKE 和信用代码匹配
+	rows := sqlmock.NewRows([]string{"id", "name", "match_rank", "score", "has_pending"}).
+		AddRow(id, "Acme", MatchRankExact, 0.0, false)
+	mock.ExpectQuery(`SELECT customers\.\*,.*AS match_rank, 0::float8 AS score,.*AS has_pending FROM "customers" WHERE \(name ILIKE \$\d+ OR credit_code = upper\(\$\d+\)\) AND "customers"\."deleted_at" IS NULL ORDER BY match_rank desc, score desc, name asc LIMIT \$\d+`).
+		WithArgs("Acme", "Acme", MatchRankExact, `Acme%`, MatchRankPrefix, `%Acme%`, MatchRankSubstring, MatchRankFuzzy,
+			core.StatusPending, core.StatusReturned,
+			`%Acme%`, "Acme", 10).
+		WillReturnRows(rows)
+
+	matches, err := repo.Search("Acme", 10)
+
+	assert.NoError(t, err)
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, MatchRankExact, matches[0].MatchRank)
+		assert.Equal(t, 0.0, matches[0].Score)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCustomerRepository_Restore(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	repo := NewCustomerRepository(gormDB, CustomerSearchOptions{})
+	id := uuid.New()
+	customer := &core.Customer{BaseModel: core.BaseModel{ID: id, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}, Name: "Acme", Region: "East"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "customers" SET "updated_at"=\$1,"deleted_at"=\$2,"region"=\$3 WHERE "id" = \$4$`).
+		WithArgs(sqlmock.AnyArg(), nil, "East", id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Restore(customer, "Region")
+
+	assert.NoError(t, err)
+	assert.False(t, customer.DeletedAt.Valid)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"xquant-default-management/internal/core"
+
+	"gorm.io/gorm"
+)
+
+// DictionaryRepository 定义了行业、区域等参考数据字典的数据操作接口。
+type DictionaryRepository interface {
+	Create(entry *core.DictionaryEntry) error
+	// GetByCode 根据字典类型和编码查询条目。
+	GetByCode(kind, code string) (*core.DictionaryEntry, error)
+	// FindByKind 查询某个字典的全部条目，按层级和编码排序。
+	FindByKind(kind string) ([]core.DictionaryEntry, error)
+	Update(entry *core.DictionaryEntry, fields ...string) error
+	Delete(entry *core.DictionaryEntry) error
+	// CountChildren 统计某个条目的直接下级条目数量。
+	CountChildren(kind, code string) (int64, error)
+	// CountCustomers 统计引用了某个条目编码的客户数量。
+	CountCustomers(kind, code string) (int64, error)
+}
+
+type dictionaryRepository struct {
+	db *gorm.DB
+}
+
+// NewDictionaryRepository 是 dictionaryRepository 的构造函数。
+func NewDictionaryRepository(db *gorm.DB) DictionaryRepository {
+	return &dictionaryRepository{db: db}
+}
+
+// Create 插入一个字典条目
+func (r *dictionaryRepository) Create(entry *core.DictionaryEntry) error {
+	return r.db.Create(entry).Error
+}
+
+// GetByCode 根据字典类型和编码查询条目
+func (r *dictionaryRepository) GetByCode(kind, code string) (*core.DictionaryEntry, error) {
+	var entry core.DictionaryEntry
+	err := r.db.Where("kind = ? AND code = ?", kind, code).First(&entry).Error
+	return &entry, err
+}
+
+// FindByKind 查询某个字典的全部条目
+func (r *dictionaryRepository) FindByKind(kind string) ([]core.DictionaryEntry, error) {
+	var entries []core.DictionaryEntry
+	err := r.db.Where("kind = ?", kind).Order("level asc, code asc").Find(&entries).Error
+	return entries, err
+}
+
+// Update 只更新指定的字段
+func (r *dictionaryRepository) Update(entry *core.DictionaryEntry, fields ...string) error {
+	return r.db.Model(entry).Select(fields).Updates(entry).Error
+}
+
+// Delete 删除一个字典条目。
+// 与评级映射表一样使用硬删除，避免软删除的记录继续占用 (kind, code) 唯一索引。
+func (r *dictionaryRepository) Delete(entry *core.DictionaryEntry) error {
+	return r.db.Unscoped().Delete(entry).Error
+}
+
+// CountChildren 统计直接下级条目数量
+func (r *dictionaryRepository) CountChildren(kind, code string) (int64, error) {
+	var count int64
+	err := r.db.Model(&core.DictionaryEntry{}).Where("kind = ? AND parent_code = ?", kind, code).Count(&count).Error
+	return count, err
+}
+
+// CountCustomers 统计引用了该编码的客户数量。
+// kind 与客户表的列名一致 (industry / region)，由 Service 层保证其合法，防止 SQL 注入。
+func (r *dictionaryRepository) CountCustomers(kind, code string) (int64, error) {
+	var count int64
+	err := r.db.Model(&core.Customer{}).Where(kind+" = ?", code).Count(&count).Error
+	return count, err
+}
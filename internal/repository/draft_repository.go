@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"xquant-default-management/internal/core"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DraftRepository 定义了违约申请草稿相关的数据操作接口。
+type DraftRepository interface {
+	Create(draft *core.ApplicationDraft) error
+	GetByID(id uuid.UUID) (*core.ApplicationDraft, error)
+	// FindOpenByApplicantID 查询申请人尚未提交的草稿，最近保存的在前。
+	FindOpenByApplicantID(applicantID uuid.UUID) ([]core.ApplicationDraft, error)
+	// Update 只更新草稿的指定字段。
+	Update(draft *core.ApplicationDraft, fields ...string) error
+	Delete(draft *core.ApplicationDraft) error
+	// MarkSubmitted 记录草稿提交后创建的申请。只有尚未提交的草稿会被更新，
+	// 草稿已经被 (并发的另一个请求) 提交时返回 gorm.ErrRecordNotFound。
+	MarkSubmitted(draft *core.ApplicationDraft) error
+}
+
+type draftRepository struct {
+	db *gorm.DB
+}
+
+// NewDraftRepository 是 draftRepository 的构造函数。
+func NewDraftRepository(db *gorm.DB) DraftRepository {
+	return &draftRepository{db: db}
+}
+
+// Create 插入一个草稿
+func (r *draftRepository) Create(draft *core.ApplicationDraft) error {
+	return r.db.Create(draft).Error
+}
+
+// GetByID 根据 ID 查询草稿
+func (r *draftRepository) GetByID(id uuid.UUID) (*core.ApplicationDraft, error) {
+	var draft core.ApplicationDraft
+	err := r.db.First(&draft, "id = ?", id).Error
+	return &draft, err
+}
+
+// FindOpenByApplicantID 查询申请人尚未提交的草稿
+func (r *draftRepository) FindOpenByApplicantID(applicantID uuid.UUID) ([]core.ApplicationDraft, error) {
+	var drafts []core.ApplicationDraft
+	err := r.db.Where("applicant_id = ? AND submitted_at IS NULL", applicantID).
+		Order("updated_at desc").
+		Find(&drafts).Error
+	return drafts, err
+}
+
+// Update 只更新指定的字段
+func (r *draftRepository) Update(draft *core.ApplicationDraft, fields ...string) error {
+	return r.db.Model(draft).Select(fields).Updates(draft).Error
+}
+
+// Delete 删除一个草稿 (软删除)
+func (r *draftRepository) Delete(draft *core.ApplicationDraft) error {
+	return r.db.Delete(draft).Error
+}
+
+// MarkSubmitted 以 submitted_at IS NULL 为条件更新，保证同一个草稿只能提交一次
+func (r *draftRepository) MarkSubmitted(draft *core.ApplicationDraft) error {
+	result := r.db.Model(draft).
+		Where("submitted_at IS NULL").
+		Select("SubmittedApplicationID", "SubmittedAt").
+		Updates(draft)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"xquant-default-management/internal/core"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// slaStatuses 计算 SLA 的申请状态。Returned 等待申请人补充材料，不计入审批时限。
+var slaStatuses = []core.ApplicationStatus{core.StatusPending, core.StatusRebirthPending}
+
+// EscalationRepository 定义了 SLA 到期计算和超时升级相关的数据操作接口。
+type EscalationRepository interface {
+	// FindSLAApplications 查询全部处于 SLA 计时状态 (Pending / RebirthPending) 的申请。
+	FindSLAApplications() ([]core.DefaultApplication, error)
+	// UpdateDueDate 保存申请当前环节的 SLA 开始时间和到期时间，并清空升级时间。
+	UpdateDueDate(app *core.DefaultApplication) error
+	// RecordEscalation 在一个事务中写入升级记录并标记申请的升级时间。
+	// 同一环节已经升级过时 (例如被另一个调度器实例抢先) 不做任何修改，返回 false。
+	RecordEscalation(event *core.EscalationEvent) (bool, error)
+	// FindEscalations 查询升级记录 (从新到旧)，并预加载申请、客户和审批人。
+	FindEscalations() ([]core.EscalationEvent, error)
+}
+
+type escalationRepository struct {
+	db *gorm.DB
+}
+
+// NewEscalationRepository 是 escalationRepository 的构造函数。
+func NewEscalationRepository(db *gorm.DB) EscalationRepository {
+	return &escalationRepository{db: db}
+}
+
+// FindSLAApplications 查询处于 SLA 计时状态的申请
+func (r *escalationRepository) FindSLAApplications() ([]core.DefaultApplication, error) {
+	var apps []core.DefaultApplication
+	err := r.db.Where("status IN ?", slaStatuses).Find(&apps).Error
+	return apps, err
+}
+
+// UpdateDueDate 保存申请的 SLA 字段
+func (r *escalationRepository) UpdateDueDate(app *core.DefaultApplication) error {
+	return r.db.Model(app).Select("SLAStartedAt", "DueAt", "EscalatedAt").Updates(app).Error
+}
+
+// RecordEscalation 写入升级记录并标记申请
+func (r *escalationRepository) RecordEscalation(event *core.EscalationEvent) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("Application", "Assignee").Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		created = true
+		return tx.Model(&core.DefaultApplication{}).Where("id = ?", event.ApplicationID).
+			Update("escalated_at", event.EscalatedAt).Error
+	})
+	return created, err
+}
+
+// FindEscalations 查询升级记录
+func (r *escalationRepository) FindEscalations() ([]core.EscalationEvent, error) {
+	var events []core.EscalationEvent
+	err := r.db.Preload("Application.Customer").Preload("Assignee").
+		Order("escalated_at desc").Find(&events).Error
+	return events, err
+}
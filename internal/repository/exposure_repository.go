@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"xquant-default-management/internal/core"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExposureRepository 定义了客户敞口记录相关的数据操作接口。
+type ExposureRepository interface {
+	Create(exposure *core.Exposure) error
+	// FindByCustomerID 查询客户的全部敞口记录，按统计时点从新到旧排序。
+	FindByCustomerID(customerID uuid.UUID) ([]core.Exposure, error)
+	// FindLatestByCustomerID 查询客户最新的一条敞口记录。没有任何记录时返回 (nil, nil)。
+	FindLatestByCustomerID(customerID uuid.UUID) (*core.Exposure, error)
+}
+
+type exposureRepository struct {
+	db *gorm.DB
+}
+
+// NewExposureRepository 是 exposureRepository 的构造函数。
+func NewExposureRepository(db *gorm.DB) ExposureRepository {
+	return &exposureRepository{db: db}
+}
+
+// Create 插入一条敞口记录
+func (r *exposureRepository) Create(exposure *core.Exposure) error {
+	return r.db.Create(exposure).Error
+}
+
+// FindByCustomerID 查询客户的全部敞口记录
+func (r *exposureRepository) FindByCustomerID(customerID uuid.UUID) ([]core.Exposure, error) {
+	var exposures []core.Exposure
+	err := r.db.Where("customer_id = ?", customerID).
+		Order("as_of_date desc, created_at desc").
+		Find(&exposures).Error
+	return exposures, err
+}
+
+// FindLatestByCustomerID 查询客户最新的敞口记录，"没有记录" 是正常的业务场景，因此返回 (nil, nil)
+func (r *exposureRepository) FindLatestByCustomerID(customerID uuid.UUID) (*core.Exposure, error) {
+	var exposure core.Exposure
+	err := r.db.Where("customer_id = ?", customerID).
+		Order("as_of_date desc, created_at desc").
+		First(&exposure).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &exposure, err
+}
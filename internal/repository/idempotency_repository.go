@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"time"
+	"xquant-default-management/internal/core"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository 定义了 Idempotency-Key 记录的数据操作接口。
+type IdempotencyRepository interface {
+	// Create 插入一条处理中的记录。同一用户的同一个 Key 已有记录时不插入，返回 false。
+	Create(record *core.IdempotencyRecord) (bool, error)
+	GetByKey(userID uuid.UUID, key string) (*core.IdempotencyRecord, error)
+	// Complete 保存请求的响应。
+	Complete(record *core.IdempotencyRecord) error
+	Delete(record *core.IdempotencyRecord) error
+	// DeleteExpired 删除 before 之前已经过期的记录，返回删除的数量。
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository 是 idempotencyRepository 的构造函数。
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// Create 以 (user_id, key) 唯一索引保证同一个 Key 只有一个请求能开始处理
+func (r *idempotencyRepository) Create(record *core.IdempotencyRecord) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetByKey 查询用户的某个 Key 的记录
+func (r *idempotencyRepository) GetByKey(userID uuid.UUID, key string) (*core.IdempotencyRecord, error) {
+	var record core.IdempotencyRecord
+	err := r.db.Where("user_id = ? AND key = ?", userID, key).First(&record).Error
+	return &record, err
+}
+
+// Complete 保存响应
+func (r *idempotencyRepository) Complete(record *core.IdempotencyRecord) error {
+	return r.db.Model(record).
+		Select("StatusCode", "ContentType", "ETag", "ResponseBody", "CompletedAt").
+		Updates(record).Error
+}
+
+// Delete 删除一条记录。使用硬删除，避免软删除的记录继续占用 (user_id, key) 唯一索引。
+func (r *idempotencyRepository) Delete(record *core.IdempotencyRecord) error {
+	return r.db.Unscoped().Delete(record).Error
+}
+
+// DeleteExpired 删除过期的记录
+func (r *idempotencyRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Unscoped().Where("expires_at < ?", before).Delete(&core.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
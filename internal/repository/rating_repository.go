@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"xquant-default-management/internal/core"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RatingRepository 定义了外部评级历史和评级映射表相关的数据操作接口。
+type RatingRepository interface {
+	// Create 插入一条外部评级历史记录。
+	Create(rating *core.ExternalRating) error
+	// FindByCustomerID 查询客户的全部评级历史，按生效日期从新到旧排序。
+	FindByCustomerID(customerID uuid.UUID) ([]core.ExternalRating, error)
+	// FindLatestByCustomerID 查询客户最新的一条评级记录。没有任何记录时返回 (nil, nil)。
+	FindLatestByCustomerID(customerID uuid.UUID) (*core.ExternalRating, error)
+
+	// GetScaleEntry 根据评级机构和评级查询映射项。
+	GetScaleEntry(agency, grade string) (*core.RatingScaleEntry, error)
+	// FindScale 查询完整的评级映射表。
+	FindScale() ([]core.RatingScaleEntry, error)
+	// UpsertScaleEntry 按 (Agency, Grade) 插入或更新映射项。
+	UpsertScaleEntry(entry *core.RatingScaleEntry) error
+	// DeleteScaleEntry 删除一个映射项。
+	DeleteScaleEntry(id uuid.UUID) error
+}
+
+type ratingRepository struct {
+	db *gorm.DB
+}
+
+// NewRatingRepository 是 ratingRepository 的构造函数。
+func NewRatingRepository(db *gorm.DB) RatingRepository {
+	return &ratingRepository{db: db}
+}
+
+// Create 插入一条评级历史记录
+func (r *ratingRepository) Create(rating *core.ExternalRating) error {
+	return r.db.Create(rating).Error
+}
+
+// FindByCustomerID 查询客户的全部评级历史
+func (r *ratingRepository) FindByCustomerID(customerID uuid.UUID) ([]core.ExternalRating, error) {
+	var ratings []core.ExternalRating
+	err := r.db.Where("customer_id = ?", customerID).
+		Order("effective_date desc, created_at desc").
+		Find(&ratings).Error
+	return ratings, err
+}
+
+// FindLatestByCustomerID 查询客户最新的评级记录。
+// 与 FindPendingByCustomerID 一样，"没有记录" 是正常的业务场景，因此返回 (nil, nil)。
+func (r *ratingRepository) FindLatestByCustomerID(customerID uuid.UUID) (*core.ExternalRating, error) {
+	var rating core.ExternalRating
+	err := r.db.Where("customer_id = ?", customerID).
+		Order("effective_date desc, created_at desc").
+		First(&rating).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return &rating, err
+}
+
+// GetScaleEntry 根据评级机构和评级查询映射项
+func (r *ratingRepository) GetScaleEntry(agency, grade string) (*core.RatingScaleEntry, error) {
+	var entry core.RatingScaleEntry
+	err := r.db.Where("agency = ? AND grade = ?", agency, grade).First(&entry).Error
+	return &entry, err
+}
+
+// FindScale 查询完整的评级映射表，按机构和序数排序
+func (r *ratingRepository) FindScale() ([]core.RatingScaleEntry, error) {
+	var entries []core.RatingScaleEntry
+	err := r.db.Order("agency asc, ordinal asc").Find(&entries).Error
+	return entries, err
+}
+
+// UpsertScaleEntry 按 (agency, grade) 唯一索引插入或更新映射项
+func (r *ratingRepository) UpsertScaleEntry(entry *core.RatingScaleEntry) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "agency"}, {Name: "grade"}},
+		DoUpdates: clause.AssignmentColumns([]string{"ordinal", "is_default", "updated_at"}),
+	}).Create(entry).Error
+}
+
+// DeleteScaleEntry 删除一个映射项。
+// 映射表是配置数据，使用硬删除 (Unscoped)，否则软删除的记录仍会占用 (agency, grade) 唯一索引。
+func (r *ratingRepository) DeleteScaleEntry(id uuid.UUID) error {
+	result := r.db.Unscoped().Delete(&core.RatingScaleEntry{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
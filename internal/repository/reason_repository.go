@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"xquant-default-management/internal/core"
+
+	"gorm.io/gorm"
+)
+
+// ReasonRepository 定义了违约原因、重生原因目录的数据操作接口。
+type ReasonRepository interface {
+	Create(entry *core.ReasonCatalogEntry) error
+	// GetByCode 根据目录类型和编码查询条目。
+	GetByCode(kind core.ReasonKind, code string) (*core.ReasonCatalogEntry, error)
+	// FindByKind 查询某个目录的全部条目，按编码排序。
+	FindByKind(kind core.ReasonKind) ([]core.ReasonCatalogEntry, error)
+	Update(entry *core.ReasonCatalogEntry, fields ...string) error
+	Delete(entry *core.ReasonCatalogEntry) error
+	// CountApplications 统计引用了某个原因编码的申请数量 (重生原因同时统计被驳回的重生记录)。
+	CountApplications(kind core.ReasonKind, code string) (int64, error)
+}
+
+type reasonRepository struct {
+	db *gorm.DB
+}
+
+// NewReasonRepository 是 reasonRepository 的构造函数。
+func NewReasonRepository(db *gorm.DB) ReasonRepository {
+	return &reasonRepository{db: db}
+}
+
+// Create 插入一个原因条目
+func (r *reasonRepository) Create(entry *core.ReasonCatalogEntry) error {
+	return r.db.Create(entry).Error
+}
+
+// GetByCode 根据目录类型和编码查询条目
+func (r *reasonRepository) GetByCode(kind core.ReasonKind, code string) (*core.ReasonCatalogEntry, error) {
+	var entry core.ReasonCatalogEntry
+	err := r.db.Where("kind = ? AND code = ?", kind, code).First(&entry).Error
+	return &entry, err
+}
+
+// FindByKind 查询某个目录的全部条目
+func (r *reasonRepository) FindByKind(kind core.ReasonKind) ([]core.ReasonCatalogEntry, error) {
+	var entries []core.ReasonCatalogEntry
+	err := r.db.Where("kind = ?", kind).Order("code asc").Find(&entries).Error
+	return entries, err
+}
+
+// Update 只更新指定的字段
+func (r *reasonRepository) Update(entry *core.ReasonCatalogEntry, fields ...string) error {
+	return r.db.Model(entry).Select(fields).Updates(entry).Error
+}
+
+// Delete 删除一个原因条目。与字典一样使用硬删除，避免软删除的记录继续占用 (kind, code) 唯一索引。
+func (r *reasonRepository) Delete(entry *core.ReasonCatalogEntry) error {
+	return r.db.Unscoped().Delete(entry).Error
+}
+
+// CountApplications 统计引用了原因编码的申请数量
+func (r *reasonRepository) CountApplications(kind core.ReasonKind, code string) (int64, error) {
+	var count int64
+	if kind == core.ReasonKindDefault {
+		err := r.db.Model(&core.DefaultApplication{}).
+			Where("default_reason_code = ?", code).
+			Or("id IN (?)", r.db.Model(&core.ApplicationRevision{}).Select("application_id").Where("default_reason_code = ?", code)).
+			Count(&count).Error
+		return count, err
+	}
+	err := r.db.Model(&core.DefaultApplication{}).
+		Where("rebirth_reason_code = ?", code).
+		Or("id IN (?)", r.db.Model(&core.RebirthRejection{}).Select("application_id").Where("rebirth_reason_code = ?", code)).
+		Count(&count).Error
+	return count, err
+}
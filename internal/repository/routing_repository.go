@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"time"
+	"xquant-default-management/internal/core"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApproverWorkload 是一位审批人当前的工作量，用于分派时的负载均衡。
+type ApproverWorkload struct {
+	ApproverID uuid.UUID
+	// Open 分派给该审批人且尚未结束审核 (Pending / Returned / RebirthPending) 的申请数量。
+	Open int64
+	// LastAssignedAt 最近一次分派给该审批人的时间，从未分派过时为空。
+	LastAssignedAt *time.Time
+}
+
+// openAssignmentStatuses 计入审批人工作量的申请状态
+var openAssignmentStatuses = []core.ApplicationStatus{core.StatusPending, core.StatusReturned, core.StatusRebirthPending}
+
+// RoutingRepository 定义了审批人分派相关的数据操作接口。
+type RoutingRepository interface {
+	// FindRules 查询全部分派规则，按优先级排序，并预加载审批人。
+	FindRules() ([]core.RoutingRule, error)
+	// CreateRule 插入一条分派规则。
+	CreateRule(rule *core.RoutingRule) error
+	// DeleteRule 删除一条分派规则。
+	DeleteRule(id uuid.UUID) error
+	// FindApprovers 查询全部审批人。
+	FindApprovers() ([]core.User, error)
+	// GetApprover 根据 ID 查询审批人，用户不存在或不是审批人时返回 gorm.ErrRecordNotFound。
+	GetApprover(id uuid.UUID) (*core.User, error)
+	// FindWorkloads 统计审批人当前的工作量。没有任何分派记录的审批人不会出现在结果中。
+	FindWorkloads(approverIDs []uuid.UUID) ([]ApproverWorkload, error)
+}
+
+type routingRepository struct {
+	db *gorm.DB
+}
+
+// NewRoutingRepository 是 routingRepository 的构造函数。
+func NewRoutingRepository(db *gorm.DB) RoutingRepository {
+	return &routingRepository{db: db}
+}
+
+// FindRules 查询全部分派规则
+func (r *routingRepository) FindRules() ([]core.RoutingRule, error) {
+	var rules []core.RoutingRule
+	err := r.db.Preload("Approver").Order("priority asc, created_at asc").Find(&rules).Error
+	return rules, err
+}
+
+// CreateRule 插入一条分派规则
+func (r *routingRepository) CreateRule(rule *core.RoutingRule) error {
+	return r.db.Create(rule).Error
+}
+
+// DeleteRule 删除一条分派规则。规则是配置数据，使用硬删除。
+func (r *routingRepository) DeleteRule(id uuid.UUID) error {
+	result := r.db.Unscoped().Delete(&core.RoutingRule{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindApprovers 查询全部审批人，按用户名排序
+func (r *routingRepository) FindApprovers() ([]core.User, error) {
+	var users []core.User
+	err := r.db.Where("role = ?", core.RoleApprover).Order("username asc").Find(&users).Error
+	return users, err
+}
+
+// GetApprover 根据 ID 查询审批人
+func (r *routingRepository) GetApprover(id uuid.UUID) (*core.User, error) {
+	var user core.User
+	err := r.db.Where("id = ? AND role = ?", id, core.RoleApprover).First(&user).Error
+	return &user, err
+}
+
+// FindWorkloads 按审批人分组统计待审申请数量和最近一次分派时间
+func (r *routingRepository) FindWorkloads(approverIDs []uuid.UUID) ([]ApproverWorkload, error) {
+	var workloads []ApproverWorkload
+	if len(approverIDs) == 0 {
+		return workloads, nil
+	}
+	err := r.db.Model(&core.DefaultApplication{}).
+		Select("assignee_id AS approver_id, COUNT(*) FILTER (WHERE status IN ?) AS open, MAX(assigned_at) AS last_assigned_at", openAssignmentStatuses).
+		Where("assignee_id IN ?", approverIDs).
+		Group("assignee_id").
+		Scan(&workloads).Error
+	return workloads, err
+}
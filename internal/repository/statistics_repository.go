@@ -8,14 +8,15 @@ import (
 
 // StatResult 用于存储按维度统计的聚合结果
 type StatResult struct {
-	Dimension string `json:"dimension"` // 可以是行业，也可以是区域
-	Count     int64  `json:"count"`
+	Dimension string  `json:"dimension"` // 可以是行业，也可以是区域
+	Count     int64   `json:"count"`
+	Amount    float64 `json:"amount"` // 指定币种的敞口快照金额合计 (本金 + 利息)
 }
 
 // StatisticsRepository 定义了统计查询的接口
 type StatisticsRepository interface {
-	// 新方法：按维度、年份和状态进行统计
-	GetCountsByDimension(year int, dimension string, status string) ([]StatResult, error)
+	// 新方法：按维度、年份和状态进行统计，同时汇总指定币种的敞口快照金额
+	GetCountsByDimension(year int, dimension string, status string, currency string) ([]StatResult, error)
 }
 
 type statisticsRepository struct {
@@ -27,7 +28,7 @@ func NewStatisticsRepository(db *gorm.DB) StatisticsRepository {
 }
 
 // GetCountsByDimension 是一个通用的聚合查询函数
-func (r *statisticsRepository) GetCountsByDimension(year int, dimension string, status string) ([]StatResult, error) {
+func (r *statisticsRepository) GetCountsByDimension(year int, dimension string, status string, currency string) ([]StatResult, error) {
 	var results []StatResult
 
 	// 基础查询，从申请表开始
@@ -35,9 +36,19 @@ func (r *statisticsRepository) GetCountsByDimension(year int, dimension string,
 		Joins("join customers as c on c.id = da.customer_id")
 
 	// 1. 动态选择维度和分组依据
-	// dimension 参数必须是 'industry' 或 'region'，由 Service 层保证，防止 SQL 注入
-	selectClause := fmt.Sprintf("c.%s as dimension, count(da.id) as count", dimension)
-	query = query.Select(selectClause).Group("c." + dimension)
+	// dimension 参数必须是 'industry'、'region' 或 'reason'，由 Service 层保证，防止 SQL 注入
+	// 'reason' 按申请记录的原因编码统计：违约统计使用违约原因，重生统计使用重生原因
+	column := "c." + dimension
+	if dimension == "reason" {
+		column = "da.default_reason_code"
+		if status == "Reborn" {
+			column = "da.rebirth_reason_code"
+		}
+	}
+	// 金额只累加币种匹配的敞口快照，不同币种的金额不能直接相加；没有快照的申请只计入数量
+	selectClause := fmt.Sprintf("%s as dimension, count(da.id) as count, "+
+		"coalesce(sum(case when da.exposure_currency = ? then da.exposure_principal + da.exposure_interest else 0 end), 0) as amount", column)
+	query = query.Select(selectClause, currency).Group(column)
 
 	// 2. 动态选择时间和状态过滤条件
 	switch status {
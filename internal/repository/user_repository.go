@@ -10,6 +10,8 @@ import (
 type UserRepository interface {
 	Create(user *core.User) error
 	GetByUsername(username string) (*core.User, error)
+	// FindByUsernames 批量查找用户，不存在的用户名会被忽略。
+	FindByUsernames(usernames []string) ([]core.User, error)
 }
 
 type userRepository struct {
@@ -32,3 +34,13 @@ func (r *userRepository) GetByUsername(username string) (*core.User, error) {
 	err := r.db.Where("username = ?", username).First(&user).Error
 	return &user, err
 }
+
+// FindByUsernames 根据用户名批量查找用户
+func (r *userRepository) FindByUsernames(usernames []string) ([]core.User, error) {
+	var users []core.User
+	if len(usernames) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("username IN ?", usernames).Find(&users).Error
+	return users, err
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 	"xquant-default-management/internal/core"
 	"xquant-default-management/internal/repository"
+	"xquant-default-management/internal/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -17,12 +18,69 @@ import (
 // 接口化设计使得 Handler 层可以解耦具体的实现，方便进行单元测试。
 type ApplicationService interface {
 	// CreateApplication 定义了创建新违约申请的业务流程。
-	CreateApplication(customerName, severity, reason, remarks string, applicantID uuid.UUID) (*core.DefaultApplication, error)
-	ApproveApplication(appID, approverID uuid.UUID) error                     // ApplicationService 接口增加 ApproveApplication 方法
-	RejectApplication(appID, approverID uuid.UUID, reason string) error       // 新增
-	GetPendingApplications() ([]core.DefaultApplication, error)               // 新增
-	ApplyForRebirth(appID, applicantID uuid.UUID, rebirthReason string) error // 新增
-	ApproveRebirth(appID, approverID uuid.UUID) error                         // 新增
+	// reasonCode 必须是违约原因目录中当前生效的编码，reason 是对原因的补充说明，为空时使用目录中的原因名称。
+	CreateApplication(customer CustomerRef, severity, reasonCode, reason, remarks string, applicantID uuid.UUID) (*core.DefaultApplication, error)
+	// Transition 是改变申请状态的唯一入口：在一个事务中校验迁移是否合法、操作者角色是否允许，
+	// 然后改变状态并执行该迁移的副作用。非法迁移返回 *InvalidTransitionError，角色不符返回 *ForbiddenTransitionError。
+	Transition(appID uuid.UUID, event core.ApplicationEvent, actor Actor, input TransitionInput) (*core.DefaultApplication, error)
+	// ReviewBatch 依次对多个申请执行审核决定。每个决定都通过 Transition 在各自的事务中执行，
+	// 一个申请失败不影响其他申请；返回的结果与 decisions 一一对应。
+	ReviewBatch(decisions []ReviewDecision, actor Actor) []ReviewResult
+	// GetPendingApplications 查询待审批的申请，view 决定查询全部、分派给 actor 的还是尚未分派的申请。
+	GetPendingApplications(view PendingView, actor Actor) ([]core.DefaultApplication, error)
+	// StateGraph 以 Mermaid 格式输出申请的状态机，用于文档。
+	StateGraph() string
+	// RequiredApprovals 返回申请在多级审批环节中需要的审批人数量。
+	RequiredApprovals(app *core.DefaultApplication) int
+	// GetRounds 查询操作者可以查看的申请历轮提交的内容，以及每一轮相对上一轮修改的字段。
+	GetRounds(appID uuid.UUID, actor Actor) ([]ApplicationRound, error)
+}
+
+// ReviewDecision 是批量审核中对一个申请的决定。
+type ReviewDecision struct {
+	ApplicationID uuid.UUID
+	// Event 审核操作：core.EventApprove 或 core.EventReject
+	Event core.ApplicationEvent
+	Input TransitionInput
+}
+
+// ReviewResult 是批量审核中一个申请的处理结果。Err 为空表示成功，此时 Application 是处理后的申请。
+type ReviewResult struct {
+	ApplicationID uuid.UUID
+	Application   *core.DefaultApplication
+	Err           error
+}
+
+// ApplicationRound 是申请某一轮提交的内容。
+type ApplicationRound struct {
+	Round         int
+	Severity      string
+	DefaultReason string
+	Remarks       string
+	SubmittedAt   time.Time
+	// Questions / ReturnedBy / ReturnedAt 本轮被退回时审批人提出的问题，当前轮次尚未被退回时为空。
+	Questions  string
+	ReturnedBy *core.User
+	ReturnedAt *time.Time
+	// Changes 与上一轮相比修改的字段，第一轮为空。
+	Changes []FieldChange
+
+	DefaultReasonCode string
+}
+
+// FieldChange 是相邻两轮之间一个字段的修改
+type FieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// CustomerRef 指定申请所针对的客户。三种方式按 ID、统一社会信用代码、名称的优先级使用第一个非空的值。
+// 按名称查找时，当前名称找不到会再按曾用名查找，以兼容客户改名前录入的数据。
+type CustomerRef struct {
+	ID         uuid.UUID
+	CreditCode string
+	Name       string
 }
 
 // applicationService 是 ApplicationService 接口的具体实现。
@@ -30,28 +88,33 @@ type ApplicationService interface {
 type applicationService struct {
 	appRepo      repository.ApplicationRepository
 	customerRepo repository.CustomerRepository
+	routingRepo  repository.RoutingRepository
+	reasonRepo   repository.ReasonRepository
 	db           *gorm.DB // 新增一个 db 字段用于事务
+	machine      *ApplicationStateMachine
 }
 
 // NewApplicationService 是 applicationService 的构造函数。
 // 通过依赖注入的方式，传入所需的 Repository 实例。
-func NewApplicationService(db *gorm.DB, appRepo repository.ApplicationRepository, customerRepo repository.CustomerRepository) ApplicationService {
-	return &applicationService{db: db, appRepo: appRepo, customerRepo: customerRepo}
+// routingRepo 用于为新申请分派审批人，reasonRepo 用于校验违约原因，policy 规定不同严重等级的申请需要几级审批。
+func NewApplicationService(db *gorm.DB, appRepo repository.ApplicationRepository, customerRepo repository.CustomerRepository, routingRepo repository.RoutingRepository, reasonRepo repository.ReasonRepository, policy ApprovalPolicy) ApplicationService {
+	return &applicationService{db: db, appRepo: appRepo, customerRepo: customerRepo, routingRepo: routingRepo, reasonRepo: reasonRepo, machine: NewApplicationStateMachine(policy)}
 }
 
 // CreateApplication 实现了创建新违约申请的核心业务逻辑。
 // 它按照业务规则进行一系列校验，全部通过后才会创建新的申请记录。
-func (s *applicationService) CreateApplication(customerName, severity, reason, remarks string, applicantID uuid.UUID) (*core.DefaultApplication, error) {
+func (s *applicationService) CreateApplication(ref CustomerRef, severity, reasonCode, reason, remarks string, applicantID uuid.UUID) (*core.DefaultApplication, error) {
+	return createApplication(s.appRepo, s.customerRepo, s.routingRepo, s.reasonRepo, ref, severity, reasonCode, reason, remarks, applicantID)
+}
+
+// createApplication 校验新申请的业务规则并创建申请。直接提交的申请和由草稿提交的申请都经过这里，
+// 两者的校验规则完全一致；草稿提交时传入事务内的 Repository。
+func createApplication(appRepo repository.ApplicationRepository, customerRepo repository.CustomerRepository, routingRepo repository.RoutingRepository, reasonRepo repository.ReasonRepository,
+	ref CustomerRef, severity, reasonCode, reason, remarks string, applicantID uuid.UUID) (*core.DefaultApplication, error) {
 	// 业务规则 1: 确认客户存在。
-	// 在进行任何操作前，必须先通过客户名称查询，确保我们操作的目标客户是存在的。
-	customer, err := s.customerRepo.GetByName(customerName)
+	// 在进行任何操作前，必须先定位到客户，确保我们操作的目标客户是存在的。
+	customer, err := resolveCustomer(customerRepo, ref)
 	if err != nil {
-		// 如果错误是 gorm.ErrRecordNotFound，说明数据库中没有这个客户。
-		// 我们将其转换为一个对上层（Handler）更友好的、不暴露底层细节的业务错误。
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("customer not found")
-		}
-		// 对于其他类型的数据库错误，直接返回。
 		return nil, err
 	}
 
@@ -63,7 +126,7 @@ func (s *applicationService) CreateApplication(customerName, severity, reason, r
 
 	// 业务规则 3: 检查是否已有待处理 (Pending) 的申请。
 	// 为防止重复劳动和流程冲突，系统不允许在已有申请待处理的情况下，为同一客户再次提交申请。
-	existingApp, err := s.appRepo.FindPendingByCustomerID(customer.ID)
+	existingApp, err := appRepo.FindPendingByCustomerID(customer.ID)
 	if err != nil {
 		// 这不是“未找到”错误，而是查询本身可能出了问题，应视为一个错误。
 		return nil, err
@@ -73,203 +136,333 @@ func (s *applicationService) CreateApplication(customerName, severity, reason, r
 		return nil, errors.New("there is already a pending application for this customer")
 	}
 
-	// 4. 所有业务规则校验通过后，创建新的申请实体。
+	// 业务规则 4: 违约原因必须是原因目录中当前生效的原因。
+	// 申请记录原因编码用于统计；申请人没有补充说明时，以目录中的原因名称作为申请的违约原因。
+	now := time.Now()
+	catalogReason, err := resolveReason(reasonRepo, core.ReasonKindDefault, reasonCode, now)
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		reason = catalogReason.LabelZh
+	}
+
+	// 5. 所有业务规则校验通过后，创建新的申请实体。
 	// 用传入的参数和系统生成的值来填充 DefaultApplication 结构体。
 	app := &core.DefaultApplication{
 		CustomerID:      customer.ID,
-		Status:          "Pending", // 新申请的状态默认为 "Pending"
+		Status:          core.StatusPending, // 新申请的状态默认为 "Pending"
+		Round:           1,
 		Severity:        severity,
 		DefaultReason:   reason,
 		Remarks:         remarks,
 		ApplicantID:     applicantID,
-		ApplicationTime: time.Now(), // 记录申请提交的精确时间
+		ApplicationTime: now, // 记录申请提交的精确时间
+
+		DefaultReasonCode: catalogReason.Code,
+	}
+
+	// 6. 按分派规则为申请选择负责审核的审批人。
+	if err := assignApprover(routingRepo, app, customer); err != nil {
+		return nil, err
 	}
 
-	// 5. 将新创建的申请实体持久化到数据库。
+	// 7. 将新创建的申请实体持久化到数据库。
 	// 调用 Repository 层的 Create 方法来执行数据库插入操作。
-	if err := s.appRepo.Create(app); err != nil {
+	if err := appRepo.Create(app); err != nil {
 		return nil, err
 	}
 
-	// 6. 成功创建后，返回新生成的申请实体指针和 nil 错误。
+	// 8. 成功创建后，返回新生成的申请实体指针和 nil 错误。
 	// 返回的 app 对象将包含由数据库生成的 ID 和时间戳等信息。
 
 	return app, nil
 }
 
-// ApproveApplication 批准一个违约申请
-//批准一个违约申请需要修改客户的状态 isDefault 和修改申请单的状态
-//申请单需要修改"status", "approver_id", "approval_time"
-
-func (s *applicationService) ApproveApplication(appID, approverID uuid.UUID) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		txAppRepo := repository.NewApplicationRepository(tx)
-		//建立新的申请处理仓管
-		txCustomerRepo := repository.NewCustomerRepository(tx)
-		//建立新的顾客仓管
-
-		// 1. 获取申请单，它已经包含了 Customer 信息
-		app, err := txAppRepo.GetByID(appID)
-		//再 GetByID 中，*customer 容器被填充
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("application not found")
-			}
-			return err
-		}
-
-		// 2. 检查申请单状态
-		if app.Status != "Pending" {
-			return errors.New("application is not in pending state")
-		}
-
-		// --- 关键修改在这里 ---
-		// 3. 直接使用预加载的 Customer 对象
-		// 我们需要先检查一下 Customer 是否真的被加载了，这是一个好的防御性编程习惯
-		if app.Customer.ID == uuid.Nil {
-			return errors.New("customer data is missing in the application")
+// resolveCustomer 根据 CustomerRef 定位客户
+func resolveCustomer(customerRepo repository.CustomerRepository, ref CustomerRef) (*core.Customer, error) {
+	var customer *core.Customer
+	var err error
+	switch {
+	case ref.ID != uuid.Nil:
+		customer, err = customerRepo.GetByID(ref.ID)
+	case ref.CreditCode != "":
+		customer, err = customerRepo.GetByCreditCode(utils.NormalizeUSCC(ref.CreditCode))
+	default:
+		customer, err = customerRepo.GetByName(ref.Name)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			customer, err = customerRepo.GetByAlias(ref.Name)
 		}
-		customer := &app.Customer // 直接获取指针
-
-		// 4. 先更新客户状态
-		customer.IsDefault = true
-		if err := txCustomerRepo.Update(customer, "IsDefault"); err != nil {
-			return err // 如果这里失败，事务回滚
+	}
+	if err != nil {
+		// 如果错误是 gorm.ErrRecordNotFound，说明数据库中没有这个客户。
+		// 我们将其转换为一个对上层（Handler）更友好的、不暴露底层细节的业务错误。
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("customer not found")
 		}
-		app.Customer = core.Customer{} // 或者 app.Customer = *new(core.Customer)
-		//这里将客户容器清空，防止后续 GORM 误操作
-
-		// 5. 再更新申请单状态（使用结构体方式）
-		now := time.Now()
-		app.Status = "Approved"
-		app.ApproverID = &approverID
-		app.ApprovalTime = &now
+		// 对于其他类型的数据库错误，直接返回。
+		return nil, err
+	}
+	return customer, nil
+}
 
-		// 使用 Select 明确指定要更新的字段
-		if err := txAppRepo.Update(app, "status", "approver_id", "approval_time"); err != nil {
-			return err
-		}
+// groupRebirthReasonCode 是关联集团成员因触发成员重生而解除违约时使用的重生原因编码，
+// 原因名称在使用时从重生原因目录中读取。编码必须与目录的初始内容 (database 包) 保持一致。
+const groupRebirthReasonCode = "GROUP_MEMBER_REBORN"
 
-		return nil
+// groupDefaultReasonCode 是为关联集团成员自动发起的违约申请使用的违约原因编码，
+// 必须与违约原因目录的初始内容 (database 包) 保持一致。
+const groupDefaultReasonCode = "GROUP_CONTAGION"
 
-	})
+// builtinReasonCodes 是系统自动处理关联集团申请时使用的内置原因编码。
+// 这些条目不允许删除或停用 (见 reasonService)，否则关联集团的传导和解除会失败。
+var builtinReasonCodes = map[core.ReasonKind]string{
+	core.ReasonKindDefault: groupDefaultReasonCode,
+	core.ReasonKindRebirth: groupRebirthReasonCode,
 }
 
-// RejectApplication 拒绝一个违约申请
-func (s *applicationService) RejectApplication(appID, approverID uuid.UUID, reason string) error {
-	// 即使只更新一张表，使用事务也是一个好习惯，可以保持代码风格一致性
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		txAppRepo := repository.NewApplicationRepository(tx)
+// Transition 在一个事务中对申请执行一次状态迁移
+func (s *applicationService) Transition(appID uuid.UUID, event core.ApplicationEvent, actor Actor, input TransitionInput) (*core.DefaultApplication, error) {
+	var app *core.DefaultApplication
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		tc := newTransitionContext(tx, actor, input)
 
-		// 1. 获取申请单
-		app, err := txAppRepo.GetByID(appID)
+		var err error
+		app, err = getApplication(tc.appRepo, appID)
 		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("application not found")
-			}
 			return err
 		}
-
-		// 2. 检查状态
-		if app.Status != "Pending" {
-			return errors.New("application is not in pending state")
+		if input.ExpectedVersion != 0 && input.ExpectedVersion != app.Version {
+			return &StaleApplicationError{Current: app}
 		}
+		return s.machine.fire(tc, app, event)
+	})
+	var stale *StaleApplicationError
+	if errors.Is(err, repository.ErrVersionConflict) && !errors.As(err, &stale) {
+		// 并发的另一个请求在本事务读取申请之后修改了它，事务已回滚，重新读取申请的当前状态
+		return nil, staleApplication(s.appRepo, appID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return app, nil
+}
 
-		// 3. 更新申请单状态
-		now := time.Now()
-		app.Status = "Rejected"
-		app.ApproverID = &approverID
-		app.ApprovalTime = &now
-		app.RejectionReason = reason // 记录拒绝原因
-		// 需要更新的数据库列名列表
-		updateFields := []string{
-			"Status",
-			"ApproverID",
-			"ApprovalTime",
-			"RejectionReason",
-		}
-		// 使用我们之前优化的、更安全的 Update 方法
-		if err := txAppRepo.Update(app, updateFields...); err != nil {
-			return err // 事务将回滚
-		}
+// ReviewBatch 逐个执行审核决定，单个申请的失败记录在结果中
+func (s *applicationService) ReviewBatch(decisions []ReviewDecision, actor Actor) []ReviewResult {
+	results := make([]ReviewResult, 0, len(decisions))
+	for _, decision := range decisions {
+		app, err := s.Transition(decision.ApplicationID, decision.Event, actor, decision.Input)
+		results = append(results, ReviewResult{ApplicationID: decision.ApplicationID, Application: app, Err: err})
+	}
+	return results
+}
 
-		return nil // 事务将提交
-	})
+// StateGraph 输出申请状态机的 Mermaid 图
+func (s *applicationService) StateGraph() string {
+	return s.machine.Graph()
 }
 
-// GetPendingApplications 获取所有待处理的申请
-func (s *applicationService) GetPendingApplications() ([]core.DefaultApplication, error) {
-	return s.appRepo.FindAllByStatus("Pending")
+// RequiredApprovals 返回申请需要的审批人数量
+func (s *applicationService) RequiredApprovals(app *core.DefaultApplication) int {
+	return s.machine.RequiredApprovals(app)
 }
 
-// ApplyForRebirth 为一个已违约的申请发起重生
-func (s *applicationService) ApplyForRebirth(appID, applicantID uuid.UUID, rebirthReason string) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		txAppRepo := repository.NewApplicationRepository(tx)
+// GetRounds 查询申请的历轮提交内容
+func (s *applicationService) GetRounds(appID uuid.UUID, actor Actor) ([]ApplicationRound, error) {
+	app, err := getVisibleApplication(s.appRepo, appID, actor)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := s.appRepo.FindRevisions(appID)
+	if err != nil {
+		return nil, err
+	}
+	return buildApplicationRounds(app, revisions), nil
+}
 
-		app, err := txAppRepo.GetByID(appID)
-		if err != nil {
-			// 如果是 GORM 经典的 "没找到" 错误，我们就把它翻译成一个更业务化的错误
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("application not found")
+// buildApplicationRounds 将历轮快照和申请的当前内容整理为轮次列表，并计算相邻两轮的差异。
+// 快照只在退回时写入，因此尚未被退回的当前轮次取自申请本身。
+func buildApplicationRounds(app *core.DefaultApplication, revisions []core.ApplicationRevision) []ApplicationRound {
+	rounds := make([]ApplicationRound, 0, len(revisions)+1)
+	for i := range revisions {
+		revision := &revisions[i]
+		rounds = append(rounds, ApplicationRound{
+			Round:         revision.Round,
+			Severity:      revision.Severity,
+			DefaultReason: revision.DefaultReason,
+			Remarks:       revision.Remarks,
+			SubmittedAt:   revision.SubmittedAt,
+			Questions:     revision.Questions,
+			ReturnedBy:    &revision.ReturnedBy,
+			ReturnedAt:    &revision.ReturnedAt,
+
+			DefaultReasonCode: revision.DefaultReasonCode,
+		})
+	}
+	if len(revisions) == 0 || revisions[len(revisions)-1].Round < app.Round {
+		submittedAt := app.ApplicationTime
+		if app.RoundSubmittedAt != nil {
+			submittedAt = *app.RoundSubmittedAt
+		}
+		rounds = append(rounds, ApplicationRound{
+			Round:         app.Round,
+			Severity:      app.Severity,
+			DefaultReason: app.DefaultReason,
+			Remarks:       app.Remarks,
+			SubmittedAt:   submittedAt,
+
+			DefaultReasonCode: app.DefaultReasonCode,
+		})
+	}
+
+	for i := 1; i < len(rounds); i++ {
+		prev, cur := &rounds[i-1], &rounds[i]
+		for _, field := range []struct{ name, from, to string }{
+			{"severity", prev.Severity, cur.Severity},
+			{"default_reason_code", prev.DefaultReasonCode, cur.DefaultReasonCode},
+			{"default_reason", prev.DefaultReason, cur.DefaultReason},
+			{"remarks", prev.Remarks, cur.Remarks},
+		} {
+			if field.from != field.to {
+				cur.Changes = append(cur.Changes, FieldChange{Field: field.name, From: field.from, To: field.to})
 			}
-			// 如果是其他数据库错误，直接返回
-			return err
 		}
+	}
+	return rounds
+}
 
-		// 业务规则：只有 "Approved" 状态的申请才能发起重生
-		if app.Status != "Approved" {
-			return errors.New("only approved applications can apply for rebirth")
+// getApplication 查询申请，并把 "记录不存在" 转换为业务错误
+func getApplication(appRepo repository.ApplicationRepository, id uuid.UUID) (*core.DefaultApplication, error) {
+	app, err := appRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("application not found")
 		}
+		return nil, err
+	}
+	return app, nil
+}
+
+// staleApplication 重新读取申请的当前状态，构造 StaleApplicationError
+func staleApplication(appRepo repository.ApplicationRepository, id uuid.UUID) error {
+	current, err := getApplication(appRepo, id)
+	if err != nil {
+		return err
+	}
+	return &StaleApplicationError{Current: current}
+}
 
-		app.Status = "RebirthPending"
-		app.RebirthReason = rebirthReason
+// canViewApplication 判断操作者能否查看申请 (及其附件等从属数据)。
+// 申请人只能看到自己提交的申请和自己发起重生的申请，审批人和管理员可以看到全部申请。
+func canViewApplication(app *core.DefaultApplication, actor Actor) bool {
+	switch actor.Role {
+	case core.RoleApprover, core.RoleAdmin, core.RoleSystem:
+		return true
+	case core.RoleApplicant:
+		return app.ApplicantID == actor.ID ||
+			(app.RebirthApplicantID != nil && *app.RebirthApplicantID == actor.ID)
+	default:
+		return false
+	}
+}
 
-		return txAppRepo.Update(app, "Status", "RebirthReason")
-	})
+// getVisibleApplication 查询操作者可以查看的申请。看不到的申请与不存在的申请一样返回 "application not found"，
+// 避免通过错误信息探测申请是否存在。
+func getVisibleApplication(appRepo repository.ApplicationRepository, id uuid.UUID, actor Actor) (*core.DefaultApplication, error) {
+	app, err := getApplication(appRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewApplication(app, actor) {
+		return nil, errors.New("application not found")
+	}
+	return app, nil
 }
 
-// ApproveRebirth 批准一个重生申请
-func (s *applicationService) ApproveRebirth(appID, approverID uuid.UUID) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		txAppRepo := repository.NewApplicationRepository(tx)
-		txCustomerRepo := repository.NewCustomerRepository(tx)
+// raiseGroupApplications 为触发申请所在集团的其他成员自动发起关联违约申请。
+// 已违约或已有待处理申请的成员会被跳过。新申请仍需审批人审核，申请人记为批准触发申请的审批人，
+// 并按分派规则分派给另一位审批人。
+func raiseGroupApplications(appRepo repository.ApplicationRepository, customerRepo repository.CustomerRepository, routingRepo repository.RoutingRepository, trigger *core.DefaultApplication, groupID, approverID uuid.UUID) error {
+	members, err := customerRepo.FindByGroupID(groupID)
+	if err != nil {
+		return err
+	}
 
-		app, err := txAppRepo.GetByID(appID)
+	for _, member := range members {
+		if member.ID == trigger.CustomerID || member.IsDefault {
+			continue
+		}
+		existing, err := appRepo.FindPendingByCustomerID(member.ID)
 		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("application not found")
-			}
 			return err
 		}
-
-		// 业务规则：只有 "RebirthPending" 状态的申请才能被批准重生
-		if app.Status != "RebirthPending" {
-			return errors.New("application is not pending for rebirth approval")
+		if existing != nil {
+			continue
 		}
 
-		// 1. 更新申请单状态
-		now := time.Now()
-		app.Status = "Reborn"
-		app.RebirthApproverID = &approverID
-		app.RebirthApprovalTime = &now
-		updateAppFields := []string{"Status", "RebirthApproverID", "RebirthApprovalTime"}
-		if err := txAppRepo.Update(app, updateAppFields...); err != nil {
+		triggerID := trigger.ID
+		linked := &core.DefaultApplication{
+			CustomerID:           member.ID,
+			Status:               core.StatusPending,
+			Round:                1,
+			Severity:             trigger.Severity,
+			DefaultReason:        "关联集团成员违约：" + trigger.DefaultReason,
+			DefaultReasonCode:    groupDefaultReasonCode,
+			Remarks:              "由关联集团成员的违约认定申请 " + trigger.ID.String() + " 自动发起",
+			ApplicantID:          approverID,
+			ApplicationTime:      time.Now(),
+			TriggerApplicationID: &triggerID,
+		}
+		if err := assignApprover(routingRepo, linked, &member); err != nil {
+			return err
+		}
+		if err := appRepo.Create(linked); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		// 2. 更新客户状态
-		// 注意：txAppRepo.GetByID 已经 Preload 了 Customer，所以我们不需要重新查询
-		if app.Customer.ID == uuid.Nil {
-			// 这是一个防御性检查，防止 Preload 失败
-			return errors.New("customer data is missing for this application")
+// GetPendingApplications 获取所有待处理的申请
+func (s *applicationService) GetPendingApplications(view PendingView, actor Actor) ([]core.DefaultApplication, error) {
+	var filter repository.AssigneeFilter
+	switch view {
+	case PendingViewMine:
+		filter.AssigneeID = &actor.ID
+	case PendingViewUnassigned:
+		filter.Unassigned = true
+	}
+	return s.appRepo.FindAllByStatus(core.StatusPending, filter)
+}
+
+// releaseGroupApplications 在触发申请重生后，处理由它传导出去的关联违约申请：
+// - 已批准的关联申请自动发起重生 (RebirthPending)，仍需审批人确认后才会解除成员的违约状态；
+// - 尚未审批的关联申请已失去依据，直接拒绝。
+// 这些迁移以系统角色执行，操作者记为批准触发申请重生的审批人。
+func releaseGroupApplications(tc *transitionContext, triggerID uuid.UUID) error {
+	linkedApps, err := tc.appRepo.FindByTriggerApplicationID(triggerID)
+	if err != nil {
+		return err
+	}
+
+	system := Actor{ID: tc.actor.ID, Role: core.RoleSystem}
+	for i := range linkedApps {
+		linked := &linkedApps[i]
+		var event core.ApplicationEvent
+		var input TransitionInput
+		switch linked.Status {
+		case core.StatusApproved:
+			event, input = core.EventApplyRebirth, TransitionInput{ReasonCode: groupRebirthReasonCode}
+		case core.StatusPending, core.StatusReturned:
+			// 尚未结束审核的关联申请直接拒绝，否则会一直阻止为该成员提交新的申请
+			event, input = core.EventReject, TransitionInput{Reason: "触发关联违约的集团成员已违约重生"}
+		default:
+			continue
 		}
-		customer := &app.Customer
-		customer.IsDefault = false
-		if err := txCustomerRepo.Update(customer, "IsDefault"); err != nil {
+		if err := tc.machine.fire(tc.derive(system, input), linked, event); err != nil {
 			return err
 		}
-
-		return nil
-	})
+	}
+	return nil
 }
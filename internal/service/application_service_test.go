@@ -0,0 +1,196 @@
+package service
+
+import (
+	"testing"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestRaiseGroupApplications(t *testing.T) {
+	mockAppRepo := new(mocks.ApplicationRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockRoutingRepo := new(mocks.RoutingRepository)
+
+	groupID := uuid.New()
+	approverID := uuid.New()
+	otherApprover := core.User{BaseModel: core.BaseModel{ID: uuid.New()}, Role: core.RoleApprover}
+	trigger := &core.DefaultApplication{
+		BaseModel:     core.BaseModel{ID: uuid.New()},
+		CustomerID:    uuid.New(),
+		Severity:      "High",
+		DefaultReason: "逾期 90 天",
+	}
+	defaulted := core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, IsDefault: true}
+	withPending := core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}}
+	clean := core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}}
+	members := []core.Customer{
+		{BaseModel: core.BaseModel{ID: trigger.CustomerID}},
+		defaulted,
+		withPending,
+		clean,
+	}
+
+	mockCustomerRepo.On("FindByGroupID", groupID).Return(members, nil).Once()
+	mockAppRepo.On("FindPendingByCustomerID", withPending.ID).Return(&core.DefaultApplication{}, nil).Once()
+	mockAppRepo.On("FindPendingByCustomerID", clean.ID).Return(nil, nil).Once()
+	// 关联申请的申请人是批准触发申请的审批人，按四眼原则不能分派给他自己
+	mockRoutingRepo.On("FindRules").Return(nil, nil).Once()
+	mockRoutingRepo.On("FindApprovers").Return([]core.User{{BaseModel: core.BaseModel{ID: approverID}}, otherApprover}, nil).Once()
+	mockRoutingRepo.On("FindWorkloads", []uuid.UUID{approverID, otherApprover.ID}).Return(nil, nil).Once()
+	mockAppRepo.On("Create", mock.MatchedBy(func(app *core.DefaultApplication) bool {
+		return app.CustomerID == clean.ID &&
+			app.Status == "Pending" &&
+			app.Severity == "High" &&
+			app.DefaultReasonCode == groupDefaultReasonCode &&
+			app.ApplicantID == approverID &&
+			app.AssigneeID != nil && *app.AssigneeID == otherApprover.ID &&
+			app.TriggerApplicationID != nil && *app.TriggerApplicationID == trigger.ID
+	})).Return(nil).Once()
+
+	err := raiseGroupApplications(mockAppRepo, mockCustomerRepo, mockRoutingRepo, trigger, groupID, approverID)
+
+	assert.NoError(t, err)
+	mockCustomerRepo.AssertExpectations(t)
+	mockAppRepo.AssertExpectations(t)
+	mockRoutingRepo.AssertExpectations(t)
+}
+
+func TestSnapshotExposure(t *testing.T) {
+	mockExposureRepo := new(mocks.ExposureRepository)
+	app := &core.DefaultApplication{CustomerID: uuid.New()}
+
+	t.Run("no exposure", func(t *testing.T) {
+		mockExposureRepo.On("FindLatestByCustomerID", app.CustomerID).Return(nil, nil).Once()
+
+		fields, err := snapshotExposure(mockExposureRepo, app)
+
+		assert.NoError(t, err)
+		assert.Empty(t, fields)
+		assert.Nil(t, app.ExposurePrincipal)
+	})
+
+	t.Run("latest exposure is copied", func(t *testing.T) {
+		asOf := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+		mockExposureRepo.On("FindLatestByCustomerID", app.CustomerID).Return(&core.Exposure{
+			Principal: 1000000, Interest: 2500.5, Currency: "CNY", AsOfDate: asOf,
+		}, nil).Once()
+
+		fields, err := snapshotExposure(mockExposureRepo, app)
+
+		assert.NoError(t, err)
+		assert.Len(t, fields, 4)
+		assert.Equal(t, 1000000.0, *app.ExposurePrincipal)
+		assert.Equal(t, 2500.5, *app.ExposureInterest)
+		assert.Equal(t, "CNY", app.ExposureCurrency)
+		assert.Equal(t, asOf, *app.ExposureAsOfDate)
+	})
+	mockExposureRepo.AssertExpectations(t)
+}
+
+func TestResolveCustomer(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	customer := &core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "新名称有限公司"}
+
+	t.Run("by credit code", func(t *testing.T) {
+		mockCustomerRepo.On("GetByCreditCode", "91350100M000100Y43").Return(customer, nil).Once()
+
+		found, err := resolveCustomer(mockCustomerRepo, CustomerRef{CreditCode: "91350100m000100y43", Name: "ignored"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, customer.ID, found.ID)
+	})
+
+	t.Run("falls back to former name", func(t *testing.T) {
+		mockCustomerRepo.On("GetByName", "旧名称有限公司").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetByAlias", "旧名称有限公司").Return(customer, nil).Once()
+
+		found, err := resolveCustomer(mockCustomerRepo, CustomerRef{Name: "旧名称有限公司"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, customer.ID, found.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		id := uuid.New()
+		mockCustomerRepo.On("GetByID", id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := resolveCustomer(mockCustomerRepo, CustomerRef{ID: id})
+
+		assert.EqualError(t, err, "customer not found")
+	})
+	mockCustomerRepo.AssertExpectations(t)
+}
+
+func TestBuildApplicationRounds(t *testing.T) {
+	submitted := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	resubmitted := submitted.Add(48 * time.Hour)
+	approver := core.User{Username: "approver"}
+	revisions := []core.ApplicationRevision{{
+		Round: 1, Severity: "Medium", DefaultReason: "逾期 60 天", SubmittedAt: submitted,
+		Questions: "请补充逾期明细", ReturnedBy: approver, ReturnedAt: submitted.Add(time.Hour),
+	}}
+
+	t.Run("current round is taken from the application", func(t *testing.T) {
+		app := &core.DefaultApplication{
+			Status: core.StatusPending, Round: 2, Severity: "High", DefaultReason: "逾期 60 天",
+			Remarks: "附逾期明细", ApplicationTime: submitted, RoundSubmittedAt: &resubmitted,
+		}
+
+		rounds := buildApplicationRounds(app, revisions)
+
+		if assert.Len(t, rounds, 2) {
+			assert.Equal(t, "请补充逾期明细", rounds[0].Questions)
+			assert.Empty(t, rounds[0].Changes)
+			assert.Equal(t, resubmitted, rounds[1].SubmittedAt)
+			assert.Empty(t, rounds[1].Questions)
+			assert.Equal(t, []FieldChange{
+				{Field: "severity", From: "Medium", To: "High"},
+				{Field: "remarks", From: "", To: "附逾期明细"},
+			}, rounds[1].Changes)
+		}
+	})
+
+	t.Run("returned round is not duplicated", func(t *testing.T) {
+		app := &core.DefaultApplication{Status: core.StatusReturned, Round: 1, Severity: "Medium", DefaultReason: "逾期 60 天", ApplicationTime: submitted}
+
+		rounds := buildApplicationRounds(app, revisions)
+
+		assert.Len(t, rounds, 1)
+	})
+}
+
+func TestApplicationService_GetRounds(t *testing.T) {
+	appID := uuid.New()
+	applicantID := uuid.New()
+	app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: appID}, ApplicantID: applicantID, Status: core.StatusPending, Round: 1}
+
+	t.Run("applicant reads own rounds", func(t *testing.T) {
+		mockAppRepo := new(mocks.ApplicationRepository)
+		appService := NewApplicationService(nil, mockAppRepo, nil, nil, nil, NewApprovalPolicy(nil))
+		mockAppRepo.On("GetByID", appID).Return(app, nil).Once()
+		mockAppRepo.On("FindRevisions", appID).Return([]core.ApplicationRevision{}, nil).Once()
+
+		rounds, err := appService.GetRounds(appID, Actor{ID: applicantID, Role: core.RoleApplicant})
+
+		assert.NoError(t, err)
+		assert.Len(t, rounds, 1)
+		mockAppRepo.AssertExpectations(t)
+	})
+
+	t.Run("another applicant cannot read them", func(t *testing.T) {
+		mockAppRepo := new(mocks.ApplicationRepository)
+		appService := NewApplicationService(nil, mockAppRepo, nil, nil, nil, NewApprovalPolicy(nil))
+		mockAppRepo.On("GetByID", appID).Return(app, nil).Once()
+
+		_, err := appService.GetRounds(appID, Actor{ID: uuid.New(), Role: core.RoleApplicant})
+
+		assert.EqualError(t, err, "application not found")
+		mockAppRepo.AssertNotCalled(t, "FindRevisions", appID)
+	})
+}
@@ -0,0 +1,569 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrUnknownApplicationEvent 表示状态机中没有定义该操作
+var ErrUnknownApplicationEvent = errors.New("unknown application event")
+
+// 四眼原则相关的业务错误
+var (
+	ErrSelfApproval      = errors.New("applicant cannot review their own application")
+	ErrDuplicateApprover = errors.New("approver has already approved this application")
+	ErrNotApplicant      = errors.New("only the applicant can withdraw this application")
+)
+
+// InvalidTransitionError 表示申请当前的状态不允许执行该操作。
+type InvalidTransitionError struct {
+	From  core.ApplicationStatus
+	Event core.ApplicationEvent
+}
+
+// invalidTransitionMessages 沿用引入状态机之前各操作的错误信息，保持 API 的错误响应不变
+var invalidTransitionMessages = map[core.ApplicationEvent]string{
+	core.EventApprove:        "application is not in pending state",
+	core.EventReject:         "application is not in pending state",
+	core.EventApplyRebirth:   "only approved applications can apply for rebirth",
+	core.EventApproveRebirth: "application is not pending for rebirth approval",
+	core.EventRejectRebirth:  "application is not pending for rebirth approval",
+	core.EventWithdraw:       "only pending applications can be withdrawn",
+	core.EventReturn:         "application is not in pending state",
+	core.EventResubmit:       "only returned applications can be resubmitted",
+}
+
+func (e *InvalidTransitionError) Error() string {
+	if msg, ok := invalidTransitionMessages[e.Event]; ok {
+		return msg
+	}
+	return fmt.Sprintf("cannot %s an application in %s state", e.Event, e.From)
+}
+
+// ForbiddenTransitionError 表示操作者的角色不允许执行该操作。
+type ForbiddenTransitionError struct {
+	Event core.ApplicationEvent
+	Role  string
+}
+
+func (e *ForbiddenTransitionError) Error() string {
+	return fmt.Sprintf("role %s is not allowed to %s this application", e.Role, e.Event)
+}
+
+// StaleApplicationError 表示操作基于申请的过期版本：客户端 If-Match 的版本不是当前版本，
+// 或者并发的另一个请求抢先修改了申请。Current 是申请的当前状态，供客户端刷新后重试。
+type StaleApplicationError struct {
+	Current *core.DefaultApplication
+}
+
+func (e *StaleApplicationError) Error() string {
+	return repository.ErrVersionConflict.Error()
+}
+
+// Unwrap 使 errors.Is(err, repository.ErrVersionConflict) 成立
+func (e *StaleApplicationError) Unwrap() error {
+	return repository.ErrVersionConflict
+}
+
+// Actor 是发起状态迁移的用户及其角色
+type Actor struct {
+	ID   uuid.UUID
+	Role string
+}
+
+// TransitionInput 是状态迁移的附加参数，不同的操作使用其中不同的字段。
+type TransitionInput struct {
+	// Reason 拒绝原因 (Reject / RejectRebirth)、重生原因 (ApplyRebirth) 或撤回原因 (Withdraw)
+	Reason string
+	// ReasonCode 仅用于 ApplyRebirth：重生原因在原因目录中的编码，申请上记录的重生原因取自目录
+	ReasonCode string
+	// PropagateToGroup 仅用于 Approve：是否为客户所在关联集团的其他成员自动发起关联违约申请
+	PropagateToGroup bool
+	// Questions 仅用于 Return：审批人要求申请人补充说明的问题
+	Questions string
+	// Amendment 仅用于 Resubmit：申请人对申请内容的修改
+	Amendment ApplicationAmendment
+	// ExpectedVersion 客户端读取申请时的版本号 (If-Match)，与当前版本不一致时拒绝操作；0 表示不校验
+	ExpectedVersion int
+}
+
+// ApplicationAmendment 是申请人重新提交时对申请内容的修改，nil 表示该字段不修改。
+type ApplicationAmendment struct {
+	Severity      *string
+	DefaultReason *string
+	Remarks       *string
+	// DefaultReasonCode 修改违约原因编码，必须是原因目录中当前生效的编码
+	DefaultReasonCode *string
+}
+
+// transitionContext 是一次状态迁移可以使用的依赖，所有 Repository 都绑定在同一个事务上。
+type transitionContext struct {
+	machine      *ApplicationStateMachine
+	appRepo      repository.ApplicationRepository
+	customerRepo repository.CustomerRepository
+	exposureRepo repository.ExposureRepository
+	routingRepo  repository.RoutingRepository
+	reasonRepo   repository.ReasonRepository
+	actor        Actor
+	input        TransitionInput
+	now          time.Time
+}
+
+// newTransitionContext 基于事务创建迁移上下文
+func newTransitionContext(tx *gorm.DB, actor Actor, input TransitionInput) *transitionContext {
+	return &transitionContext{
+		appRepo:      repository.NewApplicationRepository(tx),
+		customerRepo: repository.NewCustomerRepository(tx, repository.CustomerSearchOptions{}),
+		exposureRepo: repository.NewExposureRepository(tx),
+		routingRepo:  repository.NewRoutingRepository(tx),
+		reasonRepo:   repository.NewReasonRepository(tx),
+		actor:        actor,
+		input:        input,
+		now:          time.Now(),
+	}
+}
+
+// derive 复用同一事务，以另一个操作者和参数触发连带的状态迁移
+func (tc *transitionContext) derive(actor Actor, input TransitionInput) *transitionContext {
+	derived := *tc
+	derived.actor = actor
+	derived.input = input
+	return &derived
+}
+
+// transitionEffect 是状态迁移的副作用：在状态改变之后、申请保存之前执行，
+// 负责填写该迁移附带的字段并更新其他实体，返回申请上需要额外保存的字段。
+type transitionEffect func(tc *transitionContext, app *core.DefaultApplication) ([]string, error)
+
+// transitionGuard 在迁移前对具体的申请和操作者做额外校验 (角色之外的规则)
+type transitionGuard func(tc *transitionContext, app *core.DefaultApplication) error
+
+// stateTransition 是状态迁移表中的一行
+type stateTransition struct {
+	From  core.ApplicationStatus
+	Event core.ApplicationEvent
+	To    core.ApplicationStatus
+	Roles []string // 允许执行该迁移的角色
+	Guard transitionGuard
+	// MultiLevel 为 true 时该迁移需要按审批策略逐级审批，
+	// 每次操作记录一级审批，最后一级完成后才真正迁移状态。
+	MultiLevel bool
+	Effect     transitionEffect
+}
+
+// applicationTransitions 是违约申请的状态迁移表，申请状态的所有变化都必须在这里声明。
+func applicationTransitions() []stateTransition {
+	return []stateTransition{
+		{From: core.StatusPending, Event: core.EventApprove, To: core.StatusApproved,
+			Roles: []string{core.RoleApprover}, Guard: notOwnApplication, MultiLevel: true, Effect: approveEffect},
+		{From: core.StatusPending, Event: core.EventReject, To: core.StatusRejected,
+			Roles: []string{core.RoleApprover, core.RoleSystem}, Guard: notOwnApplication, Effect: rejectEffect},
+		{From: core.StatusApproved, Event: core.EventApplyRebirth, To: core.StatusRebirthPending,
+			Roles: []string{core.RoleApplicant, core.RoleSystem}, Effect: applyRebirthEffect},
+		{From: core.StatusRebirthPending, Event: core.EventApproveRebirth, To: core.StatusReborn,
+			Roles: []string{core.RoleApprover}, Guard: notOwnRebirth, MultiLevel: true, Effect: approveRebirthEffect},
+		{From: core.StatusRebirthPending, Event: core.EventRejectRebirth, To: core.StatusApproved,
+			Roles: []string{core.RoleApprover}, Guard: notOwnRebirth, Effect: rejectRebirthEffect},
+		{From: core.StatusPending, Event: core.EventReturn, To: core.StatusReturned,
+			Roles: []string{core.RoleApprover}, Guard: notOwnApplication, Effect: returnEffect},
+		{From: core.StatusReturned, Event: core.EventResubmit, To: core.StatusPending,
+			Roles: []string{core.RoleApplicant}, Guard: isApplicant, Effect: resubmitEffect},
+		{From: core.StatusPending, Event: core.EventWithdraw, To: core.StatusWithdrawn,
+			Roles: []string{core.RoleApplicant}, Guard: isApplicant, Effect: withdrawEffect},
+		{From: core.StatusReturned, Event: core.EventWithdraw, To: core.StatusWithdrawn,
+			Roles: []string{core.RoleApplicant}, Guard: isApplicant, Effect: withdrawEffect},
+		// 被退回修改的关联违约申请在触发申请重生时由系统拒绝，审批人只能拒绝待审核的申请
+		{From: core.StatusReturned, Event: core.EventReject, To: core.StatusRejected,
+			Roles: []string{core.RoleSystem}, Effect: rejectEffect},
+		{From: core.StatusRebirthPending, Event: core.EventWithdraw, To: core.StatusApproved,
+			Roles: []string{core.RoleApplicant}, Guard: isRebirthApplicant, Effect: withdrawRebirthEffect},
+	}
+}
+
+// ApplicationStateMachine 管理违约申请的状态迁移。
+type ApplicationStateMachine struct {
+	policy      ApprovalPolicy
+	initial     core.ApplicationStatus
+	transitions []stateTransition
+	index       map[core.ApplicationStatus]map[core.ApplicationEvent]*stateTransition
+	events      map[core.ApplicationEvent]bool
+}
+
+// NewApplicationStateMachine 根据迁移表构建状态机，policy 决定多级审批迁移需要的审批人数量。
+func NewApplicationStateMachine(policy ApprovalPolicy) *ApplicationStateMachine {
+	m := &ApplicationStateMachine{
+		policy:      policy,
+		initial:     core.StatusPending,
+		transitions: applicationTransitions(),
+		index:       make(map[core.ApplicationStatus]map[core.ApplicationEvent]*stateTransition),
+		events:      make(map[core.ApplicationEvent]bool),
+	}
+	for i := range m.transitions {
+		t := &m.transitions[i]
+		if m.index[t.From] == nil {
+			m.index[t.From] = make(map[core.ApplicationEvent]*stateTransition)
+		}
+		m.index[t.From][t.Event] = t
+		m.events[t.Event] = true
+	}
+	return m
+}
+
+// Check 校验角色为 role 的用户能否对处于 from 状态的申请执行 event，返回对应的迁移。
+func (m *ApplicationStateMachine) Check(from core.ApplicationStatus, event core.ApplicationEvent, role string) (*stateTransition, error) {
+	if !m.events[event] {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownApplicationEvent, event)
+	}
+	t, ok := m.index[from][event]
+	if !ok {
+		return nil, &InvalidTransitionError{From: from, Event: event}
+	}
+	for _, allowed := range t.Roles {
+		if allowed == role {
+			return t, nil
+		}
+	}
+	return nil, &ForbiddenTransitionError{Event: event, Role: role}
+}
+
+// fire 执行一次状态迁移：校验、记录审批、改变状态、执行副作用并保存申请。
+// 多级审批尚未完成时只记录本级审批，申请保持原状态。
+func (m *ApplicationStateMachine) fire(tc *transitionContext, app *core.DefaultApplication, event core.ApplicationEvent) error {
+	t, err := m.Check(app.Status, event, tc.actor.Role)
+	if err != nil {
+		return err
+	}
+	tc.machine = m
+
+	if t.Guard != nil {
+		if err := t.Guard(tc, app); err != nil {
+			return err
+		}
+	}
+	if t.MultiLevel {
+		completed, err := m.recordApproval(tc, app, t.Event)
+		if err != nil {
+			return err
+		}
+		if !completed {
+			// 本级审批同样递增版本号，基于同一版本的并发审批只有一个能成功
+			return saveApplication(tc.appRepo, app)
+		}
+	}
+
+	app.Status = t.To
+	fields := []string{"Status"}
+	if t.Effect != nil {
+		extra, err := t.Effect(tc, app)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, extra...)
+	}
+
+	return saveApplication(tc.appRepo, app, fields...)
+}
+
+// saveApplication 保存申请的指定字段 (版本号由 Repository 校验并递增)。
+func saveApplication(appRepo repository.ApplicationRepository, app *core.DefaultApplication, fields ...string) error {
+	// 清空预加载的客户，防止 GORM 保存申请时连带更新客户
+	customer := app.Customer
+	app.Customer = core.Customer{}
+	err := appRepo.Update(app, fields...)
+	app.Customer = customer
+	return err
+}
+
+// recordApproval 记录一级审批，返回审批链是否已经完成。
+// 同一环节、同一轮次中每一级必须由不同的审批人完成。
+func (m *ApplicationStateMachine) recordApproval(tc *transitionContext, app *core.DefaultApplication, stage core.ApplicationEvent) (bool, error) {
+	steps, err := tc.appRepo.FindApprovalSteps(app.ID, stage, app.Round)
+	if err != nil {
+		return false, err
+	}
+	for _, step := range steps {
+		if step.ApproverID == tc.actor.ID {
+			return false, ErrDuplicateApprover
+		}
+	}
+
+	step := core.ApprovalStep{
+		ApplicationID: app.ID,
+		Stage:         stage,
+		Round:         app.Round,
+		Level:         len(steps) + 1,
+		ApproverID:    tc.actor.ID,
+		ApprovedAt:    tc.now,
+	}
+	if err := tc.appRepo.CreateApprovalStep(&step); err != nil {
+		return false, err
+	}
+	app.ApprovalSteps = append(steps, step)
+	return step.Level >= m.policy.RequiredApprovals(app.Severity), nil
+}
+
+// RequiredApprovals 返回申请在多级审批环节中需要的审批人数量
+func (m *ApplicationStateMachine) RequiredApprovals(app *core.DefaultApplication) int {
+	return m.policy.RequiredApprovals(app.Severity)
+}
+
+// Graph 以 Mermaid stateDiagram 的格式输出状态机，用于文档。
+func (m *ApplicationStateMachine) Graph() string {
+	var b strings.Builder
+	b.WriteString("stateDiagram-v2\n")
+	fmt.Fprintf(&b, "    [*] --> %s\n", m.initial)
+	var terminals []core.ApplicationStatus
+	seen := make(map[core.ApplicationStatus]bool)
+	for _, t := range m.transitions {
+		label := fmt.Sprintf("%s [%s]", t.Event, strings.Join(t.Roles, ", "))
+		if t.MultiLevel {
+			label += " (multi-level)"
+		}
+		fmt.Fprintf(&b, "    %s --> %s: %s\n", t.From, t.To, label)
+		if len(m.index[t.To]) == 0 && !seen[t.To] {
+			seen[t.To] = true
+			terminals = append(terminals, t.To)
+		}
+	}
+	for _, status := range terminals {
+		fmt.Fprintf(&b, "    %s --> [*]\n", status)
+	}
+	return b.String()
+}
+
+// notOwnApplication 四眼原则：申请人不能批准、拒绝或退回自己提交的申请。
+// 触发申请重生时由系统联动拒绝关联申请，不受此限制。
+func notOwnApplication(tc *transitionContext, app *core.DefaultApplication) error {
+	if tc.actor.Role == core.RoleSystem {
+		return nil
+	}
+	if app.ApplicantID == tc.actor.ID {
+		return ErrSelfApproval
+	}
+	return nil
+}
+
+// notOwnRebirth 四眼原则：重生的发起人不能批准或拒绝自己发起的重生
+func notOwnRebirth(tc *transitionContext, app *core.DefaultApplication) error {
+	if app.RebirthApplicantID != nil && *app.RebirthApplicantID == tc.actor.ID {
+		return ErrSelfApproval
+	}
+	return nil
+}
+
+// isApplicant 只有提交申请的用户本人可以撤回申请
+func isApplicant(tc *transitionContext, app *core.DefaultApplication) error {
+	if app.ApplicantID != tc.actor.ID {
+		return ErrNotApplicant
+	}
+	return nil
+}
+
+// isRebirthApplicant 只有发起重生的用户本人可以撤回重生
+func isRebirthApplicant(tc *transitionContext, app *core.DefaultApplication) error {
+	if app.RebirthApplicantID == nil || *app.RebirthApplicantID != tc.actor.ID {
+		return ErrNotApplicant
+	}
+	return nil
+}
+
+// approveEffect 认定违约：标记客户违约、记录审批信息和敞口快照，并按需向关联集团传导违约。
+func approveEffect(tc *transitionContext, app *core.DefaultApplication) ([]string, error) {
+	// 防御性检查：GetByID 会预加载客户，这里确认客户确实被加载了
+	if app.Customer.ID == uuid.Nil {
+		return nil, errors.New("customer data is missing in the application")
+	}
+	customer := &app.Customer
+	customer.IsDefault = true
+	if err := tc.customerRepo.Update(customer, "IsDefault"); err != nil {
+		return nil, err
+	}
+
+	approverID, now := tc.actor.ID, tc.now
+	app.ApproverID = &approverID
+	app.ApprovalTime = &now
+
+	// 记录认定时点的敞口快照，供金额加权统计使用
+	snapshotFields, err := snapshotExposure(tc.exposureRepo, app)
+	if err != nil {
+		return nil, err
+	}
+
+	if tc.input.PropagateToGroup && customer.GroupID != nil {
+		if err := raiseGroupApplications(tc.appRepo, tc.customerRepo, tc.routingRepo, app, *customer.GroupID, approverID); err != nil {
+			return nil, err
+		}
+	}
+	return append([]string{"ApproverID", "ApprovalTime"}, snapshotFields...), nil
+}
+
+// rejectEffect 拒绝申请：记录审批人、审批时间和拒绝原因
+func rejectEffect(tc *transitionContext, app *core.DefaultApplication) ([]string, error) {
+	approverID, now := tc.actor.ID, tc.now
+	app.ApproverID = &approverID
+	app.ApprovalTime = &now
+	app.RejectionReason = tc.input.Reason
+	return []string{"ApproverID", "ApprovalTime", "RejectionReason"}, nil
+}
+
+// applyRebirthEffect 发起重生：记录重生原因、发起人和发起时间。
+// 重生原因必须是原因目录中当前生效的原因，原因名称取自目录；
+// 系统为关联集团成员发起的重生使用内置的原因，不受目录生效期间的限制。
+func applyRebirthEffect(tc *transitionContext, app *core.DefaultApplication) ([]string, error) {
+	var entry *core.ReasonCatalogEntry
+	var err error
+	if tc.actor.Role == core.RoleSystem {
+		entry, err = tc.reasonRepo.GetByCode(core.ReasonKindRebirth, tc.input.ReasonCode)
+	} else {
+		entry, err = resolveReason(tc.reasonRepo, core.ReasonKindRebirth, tc.input.ReasonCode, tc.now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	reason := entry.LabelZh
+
+	applicantID, now := tc.actor.ID, tc.now
+	app.RebirthReasonCode = tc.input.ReasonCode
+	app.RebirthReason = reason
+	app.RebirthApplicantID = &applicantID
+	app.RebirthAppliedAt = &now
+	return []string{"RebirthReasonCode", "RebirthReason", "RebirthApplicantID", "RebirthAppliedAt"}, nil
+}
+
+// approveRebirthEffect 批准重生：解除客户的违约状态，并反向解除由该申请传导出去的关联违约。
+func approveRebirthEffect(tc *transitionContext, app *core.DefaultApplication) ([]string, error) {
+	if app.Customer.ID == uuid.Nil {
+		return nil, errors.New("customer data is missing for this application")
+	}
+	customer := &app.Customer
+	customer.IsDefault = false
+	if err := tc.customerRepo.Update(customer, "IsDefault"); err != nil {
+		return nil, err
+	}
+
+	approverID, now := tc.actor.ID, tc.now
+	app.RebirthApproverID = &approverID
+	app.RebirthApprovalTime = &now
+
+	if err := releaseGroupApplications(tc, app.ID); err != nil {
+		return nil, err
+	}
+	return []string{"RebirthApproverID", "RebirthApprovalTime"}, nil
+}
+
+// withdrawEffect 撤回待审核的申请：记录撤回人、时间和原因
+func withdrawEffect(tc *transitionContext, app *core.DefaultApplication) ([]string, error) {
+	withdrawnByID, now := tc.actor.ID, tc.now
+	app.WithdrawnByID = &withdrawnByID
+	app.WithdrawnAt = &now
+	app.WithdrawalReason = tc.input.Reason
+	return []string{"WithdrawnByID", "WithdrawnAt", "WithdrawalReason"}, nil
+}
+
+// withdrawRebirthEffect 撤回待审核的重生：保存被撤回的重生申请，申请退回 Approved。
+// 申请本身仍处于违约状态，不写申请上的撤回字段 (那些字段只表示申请被撤回)。
+func withdrawRebirthEffect(tc *transitionContext, app *core.DefaultApplication) ([]string, error) {
+	withdrawal := &core.RebirthWithdrawal{
+		ApplicationID:     app.ID,
+		RebirthReason:     app.RebirthReason,
+		RebirthReasonCode: app.RebirthReasonCode,
+		RebirthAppliedAt:  app.RebirthAppliedAt,
+		WithdrawnByID:     tc.actor.ID,
+		WithdrawnAt:       tc.now,
+		WithdrawalReason:  tc.input.Reason,
+	}
+	if err := tc.appRepo.CreateRebirthWithdrawal(withdrawal); err != nil {
+		return nil, err
+	}
+	return resetRebirth(tc, app)
+}
+
+// rejectRebirthEffect 驳回重生：保存被驳回的重生申请，申请回到 Approved。客户的违约状态保持不变。
+func rejectRebirthEffect(tc *transitionContext, app *core.DefaultApplication) ([]string, error) {
+	rejection := &core.RebirthRejection{
+		ApplicationID:      app.ID,
+		RebirthReason:      app.RebirthReason,
+		RebirthReasonCode:  app.RebirthReasonCode,
+		RebirthApplicantID: app.RebirthApplicantID,
+		RebirthAppliedAt:   app.RebirthAppliedAt,
+		RejectedByID:       tc.actor.ID,
+		RejectedAt:         tc.now,
+		RejectionReason:    tc.input.Reason,
+	}
+	if err := tc.appRepo.CreateRebirthRejection(rejection); err != nil {
+		return nil, err
+	}
+	return resetRebirth(tc, app)
+}
+
+// resetRebirth 清空申请上的重生信息和已完成的重生审批，之后重新发起重生时审批从第一级开始。
+func resetRebirth(tc *transitionContext, app *core.DefaultApplication) ([]string, error) {
+	if err := tc.appRepo.DeleteApprovalSteps(app.ID, core.EventApproveRebirth); err != nil {
+		return nil, err
+	}
+	app.RebirthReasonCode = ""
+	app.RebirthReason = ""
+	app.RebirthApplicantID = nil
+	app.RebirthAppliedAt = nil
+	return []string{"RebirthReasonCode", "RebirthReason", "RebirthApplicantID", "RebirthAppliedAt"}, nil
+}
+
+// returnEffect 退回申请：保存本轮提交内容的快照和审批人提出的问题
+func returnEffect(tc *transitionContext, app *core.DefaultApplication) ([]string, error) {
+	submittedAt := app.ApplicationTime
+	if app.RoundSubmittedAt != nil {
+		submittedAt = *app.RoundSubmittedAt
+	}
+	revision := &core.ApplicationRevision{
+		ApplicationID: app.ID,
+		Round:         app.Round,
+		Severity:      app.Severity,
+		DefaultReason: app.DefaultReason,
+		Remarks:       app.Remarks,
+		SubmittedAt:   submittedAt,
+		Questions:     tc.input.Questions,
+		ReturnedByID:  tc.actor.ID,
+		ReturnedAt:    tc.now,
+
+		DefaultReasonCode: app.DefaultReasonCode,
+	}
+	return nil, tc.appRepo.CreateRevision(revision)
+}
+
+// resubmitEffect 重新提交：应用申请人的修改并进入下一轮，新一轮需要重新逐级审批
+func resubmitEffect(tc *transitionContext, app *core.DefaultApplication) ([]string, error) {
+	now := tc.now
+	app.Round++
+	app.RoundSubmittedAt = &now
+	fields := []string{"Round", "RoundSubmittedAt"}
+
+	amendment := tc.input.Amendment
+	if amendment.Severity != nil {
+		app.Severity = *amendment.Severity
+		fields = append(fields, "Severity")
+	}
+	if amendment.DefaultReasonCode != nil {
+		entry, err := resolveReason(tc.reasonRepo, core.ReasonKindDefault, *amendment.DefaultReasonCode, tc.now)
+		if err != nil {
+			return nil, err
+		}
+		app.DefaultReasonCode = entry.Code
+		fields = append(fields, "DefaultReasonCode")
+	}
+	if amendment.DefaultReason != nil {
+		app.DefaultReason = *amendment.DefaultReason
+		fields = append(fields, "DefaultReason")
+	}
+	if amendment.Remarks != nil {
+		app.Remarks = *amendment.Remarks
+		fields = append(fields, "Remarks")
+	}
+	return fields, nil
+}
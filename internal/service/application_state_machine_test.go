@@ -0,0 +1,360 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestApplicationStateMachine_Check(t *testing.T) {
+	m := NewApplicationStateMachine(NewApprovalPolicy(nil))
+
+	t.Run("allowed", func(t *testing.T) {
+		tr, err := m.Check(core.StatusPending, core.EventApprove, core.RoleApprover)
+
+		assert.NoError(t, err)
+		assert.Equal(t, core.StatusApproved, tr.To)
+	})
+
+	t.Run("invalid transition keeps the legacy message", func(t *testing.T) {
+		_, err := m.Check(core.StatusReborn, core.EventApplyRebirth, core.RoleApplicant)
+
+		var invalid *InvalidTransitionError
+		assert.True(t, errors.As(err, &invalid))
+		assert.Equal(t, core.StatusReborn, invalid.From)
+		assert.EqualError(t, err, "only approved applications can apply for rebirth")
+	})
+
+	t.Run("role not allowed", func(t *testing.T) {
+		_, err := m.Check(core.StatusPending, core.EventApprove, core.RoleApplicant)
+
+		var forbidden *ForbiddenTransitionError
+		assert.True(t, errors.As(err, &forbidden))
+		assert.Equal(t, core.RoleApplicant, forbidden.Role)
+	})
+
+	t.Run("only the system rejects a returned application", func(t *testing.T) {
+		_, err := m.Check(core.StatusReturned, core.EventReject, core.RoleApprover)
+		var forbidden *ForbiddenTransitionError
+		assert.True(t, errors.As(err, &forbidden))
+
+		tr, err := m.Check(core.StatusReturned, core.EventReject, core.RoleSystem)
+		assert.NoError(t, err)
+		assert.Equal(t, core.StatusRejected, tr.To)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		_, err := m.Check(core.StatusPending, core.ApplicationEvent("Escalate"), core.RoleApprover)
+
+		assert.ErrorIs(t, err, ErrUnknownApplicationEvent)
+	})
+}
+
+func TestApplicationStateMachine_ApproveRebirthReleasesGroup(t *testing.T) {
+	mockAppRepo := new(mocks.ApplicationRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockReasonRepo := new(mocks.ReasonRepository)
+	approverID := uuid.New()
+	tc := &transitionContext{
+		appRepo:      mockAppRepo,
+		customerRepo: mockCustomerRepo,
+		reasonRepo:   mockReasonRepo,
+		actor:        Actor{ID: approverID, Role: core.RoleApprover},
+		now:          time.Now(),
+	}
+
+	app := &core.DefaultApplication{
+		BaseModel:  core.BaseModel{ID: uuid.New()},
+		Status:     core.StatusRebirthPending,
+		Round:      1,
+		CustomerID: uuid.New(),
+	}
+	app.Customer = core.Customer{BaseModel: core.BaseModel{ID: app.CustomerID}, IsDefault: true}
+	linkedApproved := core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusApproved}
+	linkedPending := core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusPending}
+	linkedReturned := core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusReturned}
+
+	mockAppRepo.On("FindApprovalSteps", app.ID, core.EventApproveRebirth, 1).Return(nil, nil).Once()
+	mockAppRepo.On("CreateApprovalStep", mock.MatchedBy(func(step *core.ApprovalStep) bool {
+		return step.Level == 1 && step.ApproverID == approverID
+	})).Return(nil).Once()
+	mockCustomerRepo.On("Update", mock.MatchedBy(func(c *core.Customer) bool { return !c.IsDefault }), "IsDefault").Return(nil).Once()
+	mockAppRepo.On("FindByTriggerApplicationID", app.ID).Return([]core.DefaultApplication{linkedApproved, linkedPending, linkedReturned}, nil).Once()
+	// 自动重生的原因名称在使用时从目录中读取，管理员修改过的名称会生效
+	groupReason := &core.ReasonCatalogEntry{Kind: core.ReasonKindRebirth, Code: groupRebirthReasonCode, LabelZh: "触发关联违约的集团成员已重生"}
+	mockReasonRepo.On("GetByCode", core.ReasonKindRebirth, groupRebirthReasonCode).Return(groupReason, nil).Once()
+	mockAppRepo.On("Update", mock.MatchedBy(func(a *core.DefaultApplication) bool {
+		return a.ID == linkedApproved.ID && a.Status == core.StatusRebirthPending &&
+			a.RebirthReasonCode == groupRebirthReasonCode && a.RebirthReason == groupReason.LabelZh
+	}), "Status", "RebirthReasonCode", "RebirthReason", "RebirthApplicantID", "RebirthAppliedAt").Return(nil).Once()
+	mockAppRepo.On("Update", mock.MatchedBy(func(a *core.DefaultApplication) bool {
+		return a.ID == linkedPending.ID && a.Status == core.StatusRejected && *a.ApproverID == approverID
+	}), "Status", "ApproverID", "ApprovalTime", "RejectionReason").Return(nil).Once()
+	// 被退回修改的关联申请同样被拒绝，不再阻止为该成员提交新的申请
+	mockAppRepo.On("Update", mock.MatchedBy(func(a *core.DefaultApplication) bool {
+		return a.ID == linkedReturned.ID && a.Status == core.StatusRejected && *a.ApproverID == approverID
+	}), "Status", "ApproverID", "ApprovalTime", "RejectionReason").Return(nil).Once()
+	mockAppRepo.On("Update", mock.MatchedBy(func(a *core.DefaultApplication) bool {
+		return a.ID == app.ID && a.Status == core.StatusReborn && a.Customer.ID == uuid.Nil
+	}), "Status", "RebirthApproverID", "RebirthApprovalTime").Return(nil).Once()
+
+	err := NewApplicationStateMachine(NewApprovalPolicy(nil)).fire(tc, app, core.EventApproveRebirth)
+
+	assert.NoError(t, err)
+	assert.Equal(t, core.StatusReborn, app.Status)
+	assert.Equal(t, approverID, *app.RebirthApproverID)
+	mockAppRepo.AssertExpectations(t)
+	mockCustomerRepo.AssertExpectations(t)
+	mockReasonRepo.AssertExpectations(t)
+}
+
+func TestApplicationStateMachine_Graph(t *testing.T) {
+	graph := NewApplicationStateMachine(NewApprovalPolicy(nil)).Graph()
+
+	assert.Contains(t, graph, "[*] --> Pending")
+	assert.Contains(t, graph, "Pending --> Approved: Approve [Approver] (multi-level)")
+	assert.Contains(t, graph, "Rejected --> [*]")
+	assert.Contains(t, graph, "Reborn --> [*]")
+	assert.NotContains(t, graph, "Approved --> [*]")
+}
+
+func TestApplicationStateMachine_MultiLevelApproval(t *testing.T) {
+	m := NewApplicationStateMachine(NewApprovalPolicy(map[string]int{"high": 2}))
+	applicantID, firstApproverID := uuid.New(), uuid.New()
+	newApp := func() *core.DefaultApplication {
+		return &core.DefaultApplication{
+			BaseModel:   core.BaseModel{ID: uuid.New()},
+			Status:      core.StatusPending,
+			Severity:    "High",
+			Round:       1,
+			ApplicantID: applicantID,
+		}
+	}
+
+	t.Run("applicant cannot approve own filing", func(t *testing.T) {
+		mockAppRepo := new(mocks.ApplicationRepository)
+		tc := &transitionContext{appRepo: mockAppRepo, actor: Actor{ID: applicantID, Role: core.RoleApprover}}
+
+		err := m.fire(tc, newApp(), core.EventApprove)
+
+		assert.ErrorIs(t, err, ErrSelfApproval)
+		mockAppRepo.AssertExpectations(t)
+	})
+
+	t.Run("first approval keeps the application pending", func(t *testing.T) {
+		mockAppRepo := new(mocks.ApplicationRepository)
+		tc := &transitionContext{appRepo: mockAppRepo, actor: Actor{ID: firstApproverID, Role: core.RoleApprover}, now: time.Now()}
+		app := newApp()
+		mockAppRepo.On("FindApprovalSteps", app.ID, core.EventApprove, 1).Return(nil, nil).Once()
+		mockAppRepo.On("CreateApprovalStep", mock.AnythingOfType("*core.ApprovalStep")).Return(nil).Once()
+		// 只递增版本号，不更新其他字段
+		mockAppRepo.On("Update", app).Return(nil).Once()
+
+		err := m.fire(tc, app, core.EventApprove)
+
+		assert.NoError(t, err)
+		assert.Equal(t, core.StatusPending, app.Status)
+		assert.Len(t, app.ApprovalSteps, 1)
+		mockAppRepo.AssertExpectations(t)
+	})
+
+	t.Run("same approver cannot approve twice", func(t *testing.T) {
+		mockAppRepo := new(mocks.ApplicationRepository)
+		tc := &transitionContext{appRepo: mockAppRepo, actor: Actor{ID: firstApproverID, Role: core.RoleApprover}, now: time.Now()}
+		app := newApp()
+		mockAppRepo.On("FindApprovalSteps", app.ID, core.EventApprove, 1).
+			Return([]core.ApprovalStep{{ApplicationID: app.ID, Level: 1, ApproverID: firstApproverID}}, nil).Once()
+
+		err := m.fire(tc, app, core.EventApprove)
+
+		assert.ErrorIs(t, err, ErrDuplicateApprover)
+		mockAppRepo.AssertExpectations(t)
+	})
+}
+
+func TestApplicationStateMachine_Withdraw(t *testing.T) {
+	m := NewApplicationStateMachine(NewApprovalPolicy(nil))
+	applicantID := uuid.New()
+
+	t.Run("only the applicant can withdraw", func(t *testing.T) {
+		tc := &transitionContext{actor: Actor{ID: uuid.New(), Role: core.RoleApplicant}}
+		app := &core.DefaultApplication{Status: core.StatusPending, ApplicantID: applicantID}
+
+		err := m.fire(tc, app, core.EventWithdraw)
+
+		assert.ErrorIs(t, err, ErrNotApplicant)
+		assert.Equal(t, core.StatusPending, app.Status)
+	})
+
+	t.Run("withdrawing a rebirth restores approved", func(t *testing.T) {
+		mockAppRepo := new(mocks.ApplicationRepository)
+		tc := &transitionContext{appRepo: mockAppRepo, actor: Actor{ID: applicantID, Role: core.RoleApplicant}, input: TransitionInput{Reason: "材料有误"}, now: time.Now()}
+		app := &core.DefaultApplication{
+			BaseModel:          core.BaseModel{ID: uuid.New()},
+			Status:             core.StatusRebirthPending,
+			RebirthReason:      "正常结算后解除",
+			RebirthApplicantID: &applicantID,
+		}
+		mockAppRepo.On("CreateRebirthWithdrawal", mock.MatchedBy(func(w *core.RebirthWithdrawal) bool {
+			return w.ApplicationID == app.ID && w.RebirthReason == "正常结算后解除" && w.WithdrawnByID == applicantID && w.WithdrawalReason == "材料有误"
+		})).Return(nil).Once()
+		mockAppRepo.On("DeleteApprovalSteps", app.ID, core.EventApproveRebirth).Return(nil).Once()
+		mockAppRepo.On("Update", app, "Status", "RebirthReasonCode", "RebirthReason", "RebirthApplicantID", "RebirthAppliedAt").Return(nil).Once()
+
+		err := m.fire(tc, app, core.EventWithdraw)
+
+		assert.NoError(t, err)
+		assert.Equal(t, core.StatusApproved, app.Status)
+		assert.Empty(t, app.RebirthReason)
+		assert.Nil(t, app.RebirthApplicantID)
+		// 仍处于违约状态的申请不能显示为已撤回
+		assert.Nil(t, app.WithdrawnByID)
+		assert.Nil(t, app.WithdrawnAt)
+		assert.Empty(t, app.WithdrawalReason)
+		mockAppRepo.AssertExpectations(t)
+	})
+}
+
+func TestApplicationStateMachine_FourEyes(t *testing.T) {
+	m := NewApplicationStateMachine(NewApprovalPolicy(nil))
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name  string
+		app   *core.DefaultApplication
+		event core.ApplicationEvent
+	}{
+		{"reject own application", &core.DefaultApplication{Status: core.StatusPending, ApplicantID: ownerID}, core.EventReject},
+		{"return own application", &core.DefaultApplication{Status: core.StatusPending, ApplicantID: ownerID}, core.EventReturn},
+		{"reject own rebirth", &core.DefaultApplication{Status: core.StatusRebirthPending, ApplicantID: uuid.New(), RebirthApplicantID: &ownerID}, core.EventRejectRebirth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAppRepo := new(mocks.ApplicationRepository)
+			tc := &transitionContext{appRepo: mockAppRepo, actor: Actor{ID: ownerID, Role: core.RoleApprover}, input: TransitionInput{Reason: "资料不全"}}
+			from := tt.app.Status
+
+			err := m.fire(tc, tt.app, tt.event)
+
+			assert.ErrorIs(t, err, ErrSelfApproval)
+			assert.Equal(t, from, tt.app.Status)
+			mockAppRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("system rejection of a linked application is not restricted", func(t *testing.T) {
+		mockAppRepo := new(mocks.ApplicationRepository)
+		tc := &transitionContext{appRepo: mockAppRepo, actor: Actor{ID: ownerID, Role: core.RoleSystem}, input: TransitionInput{Reason: "客户已重生"}, now: time.Now()}
+		app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusPending, ApplicantID: ownerID}
+		mockAppRepo.On("Update", app, "Status", "ApproverID", "ApprovalTime", "RejectionReason").Return(nil).Once()
+
+		err := m.fire(tc, app, core.EventReject)
+
+		assert.NoError(t, err)
+		assert.Equal(t, core.StatusRejected, app.Status)
+		mockAppRepo.AssertExpectations(t)
+	})
+}
+
+func TestApplicationStateMachine_RejectRebirth(t *testing.T) {
+	mockAppRepo := new(mocks.ApplicationRepository)
+	applicantID, approverID := uuid.New(), uuid.New()
+	appliedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	// customerRepo 为 nil：驳回重生不能改动客户的违约状态
+	tc := &transitionContext{appRepo: mockAppRepo, actor: Actor{ID: approverID, Role: core.RoleApprover}, input: TransitionInput{Reason: "逾期尚未结清"}, now: time.Now()}
+	app := &core.DefaultApplication{
+		BaseModel:          core.BaseModel{ID: uuid.New()},
+		Status:             core.StatusRebirthPending,
+		RebirthReason:      "正常结算后解除",
+		RebirthReasonCode:  "NORMAL_SETTLEMENT",
+		RebirthApplicantID: &applicantID,
+		RebirthAppliedAt:   &appliedAt,
+	}
+	mockAppRepo.On("CreateRebirthRejection", mock.MatchedBy(func(r *core.RebirthRejection) bool {
+		return r.ApplicationID == app.ID && r.RebirthReason == "正常结算后解除" && r.RebirthReasonCode == "NORMAL_SETTLEMENT" && *r.RebirthAppliedAt == appliedAt &&
+			r.RejectedByID == approverID && r.RejectionReason == "逾期尚未结清"
+	})).Return(nil).Once()
+	mockAppRepo.On("DeleteApprovalSteps", app.ID, core.EventApproveRebirth).Return(nil).Once()
+	mockAppRepo.On("Update", app, "Status", "RebirthReasonCode", "RebirthReason", "RebirthApplicantID", "RebirthAppliedAt").Return(nil).Once()
+
+	err := NewApplicationStateMachine(NewApprovalPolicy(nil)).fire(tc, app, core.EventRejectRebirth)
+
+	assert.NoError(t, err)
+	assert.Equal(t, core.StatusApproved, app.Status)
+	assert.Empty(t, app.RebirthReasonCode)
+	assert.Nil(t, app.RebirthApplicantID)
+	mockAppRepo.AssertExpectations(t)
+}
+
+func TestApplicationStateMachine_ApplyRebirth(t *testing.T) {
+	m := NewApplicationStateMachine(NewApprovalPolicy(nil))
+	applicantID := uuid.New()
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("records the catalog reason", func(t *testing.T) {
+		mockAppRepo := new(mocks.ApplicationRepository)
+		mockReasonRepo := new(mocks.ReasonRepository)
+		tc := &transitionContext{appRepo: mockAppRepo, reasonRepo: mockReasonRepo, actor: Actor{ID: applicantID, Role: core.RoleApplicant},
+			input: TransitionInput{ReasonCode: "NORMAL_SETTLEMENT"}, now: now}
+		app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusApproved}
+		mockReasonRepo.On("GetByCode", core.ReasonKindRebirth, "NORMAL_SETTLEMENT").
+			Return(&core.ReasonCatalogEntry{Kind: core.ReasonKindRebirth, Code: "NORMAL_SETTLEMENT", LabelZh: "正常结算后解除"}, nil).Once()
+		mockAppRepo.On("Update", app, "Status", "RebirthReasonCode", "RebirthReason", "RebirthApplicantID", "RebirthAppliedAt").Return(nil).Once()
+
+		err := m.fire(tc, app, core.EventApplyRebirth)
+
+		assert.NoError(t, err)
+		assert.Equal(t, core.StatusRebirthPending, app.Status)
+		assert.Equal(t, "NORMAL_SETTLEMENT", app.RebirthReasonCode)
+		assert.Equal(t, "正常结算后解除", app.RebirthReason)
+		mockAppRepo.AssertExpectations(t)
+		mockReasonRepo.AssertExpectations(t)
+	})
+
+	t.Run("retired reasons cannot be used", func(t *testing.T) {
+		mockReasonRepo := new(mocks.ReasonRepository)
+		tc := &transitionContext{reasonRepo: mockReasonRepo, actor: Actor{ID: applicantID, Role: core.RoleApplicant},
+			input: TransitionInput{ReasonCode: "PROVISION_BELOW_THRESHOLD"}, now: now}
+		app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusApproved}
+		retiredAt := now.Add(-time.Hour)
+		mockReasonRepo.On("GetByCode", core.ReasonKindRebirth, "PROVISION_BELOW_THRESHOLD").
+			Return(&core.ReasonCatalogEntry{Kind: core.ReasonKindRebirth, Code: "PROVISION_BELOW_THRESHOLD", ValidTo: &retiredAt}, nil).Once()
+
+		err := m.fire(tc, app, core.EventApplyRebirth)
+
+		assert.ErrorIs(t, err, ErrInactiveReason)
+		mockReasonRepo.AssertExpectations(t)
+	})
+}
+
+func TestApplicationStateMachine_Resubmit(t *testing.T) {
+	mockAppRepo := new(mocks.ApplicationRepository)
+	applicantID := uuid.New()
+	severity, remarks := "High", "附逾期明细"
+	tc := &transitionContext{
+		appRepo: mockAppRepo,
+		actor:   Actor{ID: applicantID, Role: core.RoleApplicant},
+		input:   TransitionInput{Amendment: ApplicationAmendment{Severity: &severity, Remarks: &remarks}},
+		now:     time.Now(),
+	}
+	app := &core.DefaultApplication{
+		BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusReturned, Round: 1,
+		Severity: "Medium", DefaultReason: "逾期 60 天", ApplicantID: applicantID,
+	}
+	mockAppRepo.On("Update", app, "Status", "Round", "RoundSubmittedAt", "Severity", "Remarks").Return(nil).Once()
+
+	err := NewApplicationStateMachine(NewApprovalPolicy(nil)).fire(tc, app, core.EventResubmit)
+
+	assert.NoError(t, err)
+	assert.Equal(t, core.StatusPending, app.Status)
+	assert.Equal(t, 2, app.Round)
+	assert.Equal(t, "High", app.Severity)
+	assert.Equal(t, "逾期 60 天", app.DefaultReason)
+	mockAppRepo.AssertExpectations(t)
+}
@@ -0,0 +1,26 @@
+package service
+
+import "strings"
+
+// ApprovalPolicy 按严重等级规定违约认定和重生各需要多少名不同的审批人。
+type ApprovalPolicy struct {
+	levels map[string]int
+}
+
+// NewApprovalPolicy 根据配置创建审批策略。
+// 配置文件的键不区分大小写 (viper 会把键转为小写)，因此统一按小写保存。
+func NewApprovalPolicy(levels map[string]int) ApprovalPolicy {
+	normalized := make(map[string]int, len(levels))
+	for severity, n := range levels {
+		normalized[strings.ToLower(severity)] = n
+	}
+	return ApprovalPolicy{levels: normalized}
+}
+
+// RequiredApprovals 返回该严重等级需要的审批人数量，未配置或配置小于 1 时为 1。
+func (p ApprovalPolicy) RequiredApprovals(severity string) int {
+	if n := p.levels[strings.ToLower(severity)]; n > 1 {
+		return n
+	}
+	return 1
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"errors"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PendingView 是待审批列表的视图
+type PendingView string
+
+const (
+	// PendingViewAll 全部待审批申请
+	PendingViewAll PendingView = "all"
+	// PendingViewMine 分派给当前审批人的申请
+	PendingViewMine PendingView = "mine"
+	// PendingViewUnassigned 尚未分派的申请
+	PendingViewUnassigned PendingView = "unassigned"
+)
+
+// AssignmentService 定义了审批人分派规则维护和申请改派相关的业务操作接口。
+type AssignmentService interface {
+	ListRules() ([]core.RoutingRule, error)
+	CreateRule(priority int, region, industry string, approverID uuid.UUID) (*core.RoutingRule, error)
+	DeleteRule(id uuid.UUID) error
+	// Reassign 由管理员把尚未结束审核的申请改派给另一位审批人。
+	Reassign(appID, assigneeID uuid.UUID, actor Actor) (*core.DefaultApplication, error)
+}
+
+type assignmentService struct {
+	appRepo     repository.ApplicationRepository
+	routingRepo repository.RoutingRepository
+}
+
+// NewAssignmentService 是 assignmentService 的构造函数。
+func NewAssignmentService(appRepo repository.ApplicationRepository, routingRepo repository.RoutingRepository) AssignmentService {
+	return &assignmentService{appRepo: appRepo, routingRepo: routingRepo}
+}
+
+// ListRules 查询全部分派规则
+func (s *assignmentService) ListRules() ([]core.RoutingRule, error) {
+	return s.routingRepo.FindRules()
+}
+
+// CreateRule 新增一条分派规则，规则指定的用户必须是审批人
+func (s *assignmentService) CreateRule(priority int, region, industry string, approverID uuid.UUID) (*core.RoutingRule, error) {
+	approver, err := getApprover(s.routingRepo, approverID)
+	if err != nil {
+		return nil, err
+	}
+	rule := &core.RoutingRule{
+		Priority:   priority,
+		Region:     region,
+		Industry:   industry,
+		ApproverID: approver.ID,
+	}
+	if err := s.routingRepo.CreateRule(rule); err != nil {
+		return nil, err
+	}
+	rule.Approver = *approver
+	return rule, nil
+}
+
+// DeleteRule 删除一条分派规则
+func (s *assignmentService) DeleteRule(id uuid.UUID) error {
+	err := s.routingRepo.DeleteRule(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("routing rule not found")
+	}
+	return err
+}
+
+// Reassign 改派申请。
+// 业务规则：只有尚未结束审核的申请可以改派；按四眼原则，不能改派给申请人或重生发起人本人。
+func (s *assignmentService) Reassign(appID, assigneeID uuid.UUID, actor Actor) (*core.DefaultApplication, error) {
+	app, err := getApplication(s.appRepo, appID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOpenForReview(app.Status) {
+		return nil, errors.New("only applications under review can be reassigned")
+	}
+	assignee, err := getApprover(s.routingRepo, assigneeID)
+	if err != nil {
+		return nil, err
+	}
+	if assignee.ID == app.ApplicantID || (app.RebirthApplicantID != nil && *app.RebirthApplicantID == assignee.ID) {
+		return nil, errors.New("cannot assign an application to its own applicant")
+	}
+
+	now, actorID := time.Now(), actor.ID
+	app.AssigneeID = &assignee.ID
+	app.AssignedAt = &now
+	app.AssignedByID = &actorID
+
+	if err := saveApplication(s.appRepo, app, "AssigneeID", "AssignedAt", "AssignedByID"); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, staleApplication(s.appRepo, appID)
+		}
+		return nil, err
+	}
+	app.Assignee = assignee
+	return app, nil
+}
+
+// isOpenForReview 判断申请是否仍在审核流程中
+func isOpenForReview(status core.ApplicationStatus) bool {
+	return status == core.StatusPending || status == core.StatusReturned || status == core.StatusRebirthPending
+}
+
+// getApprover 查询审批人，不存在或不是审批人时返回业务错误
+func getApprover(routingRepo repository.RoutingRepository, id uuid.UUID) (*core.User, error) {
+	approver, err := routingRepo.GetApprover(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("approver not found")
+		}
+		return nil, err
+	}
+	return approver, nil
+}
+
+// assignApprover 按分派规则为新申请选择审批人，填写申请的 AssigneeID 和 AssignedAt。
+// 系统中没有可用的审批人时申请保持未分派状态，等待管理员手动分派。
+func assignApprover(routingRepo repository.RoutingRepository, app *core.DefaultApplication, customer *core.Customer) error {
+	rules, err := routingRepo.FindRules()
+	if err != nil {
+		return err
+	}
+	approvers, err := routingRepo.FindApprovers()
+	if err != nil {
+		return err
+	}
+	ids := make([]uuid.UUID, 0, len(approvers))
+	for _, approver := range approvers {
+		ids = append(ids, approver.ID)
+	}
+	workloads, err := routingRepo.FindWorkloads(ids)
+	if err != nil {
+		return err
+	}
+
+	assigneeID := pickApprover(rules, approvers, workloads, customer, app.ApplicantID)
+	if assigneeID != nil {
+		now := time.Now()
+		app.AssigneeID = assigneeID
+		app.AssignedAt = &now
+	}
+	return nil
+}
+
+// pickApprover 是分派算法：
+//  1. 按优先级从小到大找到第一组与客户区域、行业匹配的规则，规则指定的审批人构成候选池；
+//  2. 没有规则匹配时，全部审批人构成候选池；
+//  3. 在候选池中选择待审申请最少的审批人，数量相同时选择最久没有被分派的一位 (即轮流分派)。
+//
+// 申请人本人 (exclude) 不会被选中。候选池为空时返回 nil。
+func pickApprover(rules []core.RoutingRule, approvers []core.User, workloads []repository.ApproverWorkload, customer *core.Customer, exclude uuid.UUID) *uuid.UUID {
+	eligible := make(map[uuid.UUID]bool, len(approvers))
+	for _, approver := range approvers {
+		if approver.ID != exclude {
+			eligible[approver.ID] = true
+		}
+	}
+
+	var pool []uuid.UUID
+	var matchedPriority int
+	for _, rule := range rules {
+		if len(pool) > 0 && rule.Priority != matchedPriority {
+			break
+		}
+		if !eligible[rule.ApproverID] ||
+			(rule.Region != "" && rule.Region != customer.Region) ||
+			(rule.Industry != "" && rule.Industry != customer.Industry) {
+			continue
+		}
+		matchedPriority = rule.Priority
+		pool = append(pool, rule.ApproverID)
+	}
+	if len(pool) == 0 {
+		for _, approver := range approvers {
+			if eligible[approver.ID] {
+				pool = append(pool, approver.ID)
+			}
+		}
+	}
+
+	loads := make(map[uuid.UUID]repository.ApproverWorkload, len(workloads))
+	for _, w := range workloads {
+		loads[w.ApproverID] = w
+	}
+	var best *uuid.UUID
+	for i := range pool {
+		candidate := pool[i]
+		if best == nil || lessLoaded(loads[candidate], loads[*best]) {
+			best = &candidate
+		}
+	}
+	return best
+}
+
+// lessLoaded 判断 a 的工作量是否小于 b：先比较待审数量，再比较最近一次分派时间 (从未分派的优先)
+func lessLoaded(a, b repository.ApproverWorkload) bool {
+	if a.Open != b.Open {
+		return a.Open < b.Open
+	}
+	switch {
+	case a.LastAssignedAt == nil:
+		return b.LastAssignedAt != nil
+	case b.LastAssignedAt == nil:
+		return false
+	default:
+		return a.LastAssignedAt.Before(*b.LastAssignedAt)
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"testing"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPickApprover(t *testing.T) {
+	alice := core.User{BaseModel: core.BaseModel{ID: uuid.New()}, Username: "alice"}
+	bob := core.User{BaseModel: core.BaseModel{ID: uuid.New()}, Username: "bob"}
+	carol := core.User{BaseModel: core.BaseModel{ID: uuid.New()}, Username: "carol"}
+	approvers := []core.User{alice, bob, carol}
+	customer := &core.Customer{Region: "310000", Industry: "C"}
+	earlier := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	t.Run("first matching priority wins", func(t *testing.T) {
+		rules := []core.RoutingRule{
+			{Priority: 1, Region: "110000", ApproverID: alice.ID},
+			{Priority: 2, Region: "310000", ApproverID: bob.ID},
+			{Priority: 3, Industry: "C", ApproverID: carol.ID},
+		}
+
+		assert.Equal(t, bob.ID, *pickApprover(rules, approvers, nil, customer, uuid.Nil))
+	})
+
+	t.Run("same priority is balanced by workload", func(t *testing.T) {
+		rules := []core.RoutingRule{
+			{Priority: 1, Region: "310000", ApproverID: alice.ID},
+			{Priority: 1, Industry: "C", ApproverID: bob.ID},
+		}
+		workloads := []repository.ApproverWorkload{{ApproverID: alice.ID, Open: 3}, {ApproverID: bob.ID, Open: 1}}
+
+		assert.Equal(t, bob.ID, *pickApprover(rules, approvers, workloads, customer, uuid.Nil))
+	})
+
+	t.Run("round robin when no rule matches", func(t *testing.T) {
+		workloads := []repository.ApproverWorkload{
+			{ApproverID: alice.ID, Open: 1, LastAssignedAt: &later},
+			{ApproverID: bob.ID, Open: 1, LastAssignedAt: &earlier},
+			{ApproverID: carol.ID, Open: 2},
+		}
+
+		assert.Equal(t, bob.ID, *pickApprover(nil, approvers, workloads, customer, uuid.Nil))
+	})
+
+	t.Run("applicant is never picked", func(t *testing.T) {
+		rules := []core.RoutingRule{{Priority: 1, Region: "310000", ApproverID: alice.ID}}
+
+		picked := pickApprover(rules, approvers, nil, customer, alice.ID)
+
+		assert.NotEqual(t, alice.ID, *picked)
+		assert.Nil(t, pickApprover(nil, []core.User{alice}, nil, customer, alice.ID))
+	})
+}
+
+func TestAssignmentService_Reassign(t *testing.T) {
+	mockAppRepo := new(mocks.ApplicationRepository)
+	mockRoutingRepo := new(mocks.RoutingRepository)
+	svc := NewAssignmentService(mockAppRepo, mockRoutingRepo)
+	admin := Actor{ID: uuid.New(), Role: core.RoleAdmin}
+	approver := &core.User{BaseModel: core.BaseModel{ID: uuid.New()}, Username: "approver2", Role: core.RoleApprover}
+
+	t.Run("success", func(t *testing.T) {
+		app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusPending, ApplicantID: uuid.New()}
+		mockAppRepo.On("GetByID", app.ID).Return(app, nil).Once()
+		mockRoutingRepo.On("GetApprover", approver.ID).Return(approver, nil).Once()
+		mockAppRepo.On("Update", app, "AssigneeID", "AssignedAt", "AssignedByID").Return(nil).Once()
+
+		updated, err := svc.Reassign(app.ID, approver.ID, admin)
+
+		assert.NoError(t, err)
+		assert.Equal(t, approver.ID, *updated.AssigneeID)
+		assert.Equal(t, admin.ID, *updated.AssignedByID)
+	})
+
+	t.Run("closed application", func(t *testing.T) {
+		app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusRejected}
+		mockAppRepo.On("GetByID", app.ID).Return(app, nil).Once()
+
+		_, err := svc.Reassign(app.ID, approver.ID, admin)
+
+		assert.EqualError(t, err, "only applications under review can be reassigned")
+	})
+
+	t.Run("assignee is not an approver", func(t *testing.T) {
+		app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusPending}
+		userID := uuid.New()
+		mockAppRepo.On("GetByID", app.ID).Return(app, nil).Once()
+		mockRoutingRepo.On("GetApprover", userID).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := svc.Reassign(app.ID, userID, admin)
+
+		assert.EqualError(t, err, "approver not found")
+	})
+	mockAppRepo.AssertExpectations(t)
+	mockRoutingRepo.AssertExpectations(t)
+}
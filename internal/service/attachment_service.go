@@ -0,0 +1,209 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+	"xquant-default-management/internal/storage"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultMaxAttachmentSize 未配置 ATTACHMENT_MAX_SIZE_MB 时单个附件的大小上限
+const DefaultMaxAttachmentSize int64 = 20 << 20
+
+// allowedAttachmentTypes 允许上传的附件类型：PDF、图片、Office 文档和纯文本。
+// 类型根据文件内容检测，客户端声明的 Content-Type 和扩展名都不可信。
+var allowedAttachmentTypes = []string{
+	"application/pdf",
+	"image/png",
+	"image/jpeg",
+	"image/tiff",
+	"application/msword",
+	"application/vnd.ms-excel",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"text/plain",
+	"text/csv",
+}
+
+// AttachmentService 定义了违约申请证据附件相关的业务操作接口。
+// 附件的访问权限跟随申请：看不到申请的用户也看不到它的附件。
+type AttachmentService interface {
+	// Upload 为申请上传一个附件。违约认定的证据只能在申请审核期间上传，重生的证据只能在认定违约之后上传。
+	Upload(appID uuid.UUID, purpose core.AttachmentPurpose, fileName string, r io.Reader, actor Actor) (*core.Attachment, error)
+	// List 查询申请的全部附件。
+	List(appID uuid.UUID, actor Actor) ([]core.Attachment, error)
+	// Open 打开附件内容用于下载，调用方负责关闭返回的 ReadCloser。
+	Open(appID, attachmentID uuid.UUID, actor Actor) (*core.Attachment, io.ReadCloser, error)
+	// MaxSize 返回单个附件的字节数上限，Handler 据此在解析请求体之前限制上传大小。
+	MaxSize() int64
+}
+
+type attachmentService struct {
+	appRepo        repository.ApplicationRepository
+	attachmentRepo repository.AttachmentRepository
+	store          storage.Storage
+	maxSize        int64
+}
+
+// NewAttachmentService 是 attachmentService 的构造函数。maxSize 是单个附件的字节数上限，不大于 0 时使用默认值。
+func NewAttachmentService(appRepo repository.ApplicationRepository, attachmentRepo repository.AttachmentRepository, store storage.Storage, maxSize int64) AttachmentService {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxAttachmentSize
+	}
+	return &attachmentService{appRepo: appRepo, attachmentRepo: attachmentRepo, store: store, maxSize: maxSize}
+}
+
+// MaxSize 返回附件大小上限
+func (s *attachmentService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// Upload 上传附件。
+// 文件先完整读入内存 (受大小上限约束)，在写入存储之前完成类型检测、校验和计算和重复检查。
+func (s *attachmentService) Upload(appID uuid.UUID, purpose core.AttachmentPurpose, fileName string, r io.Reader, actor Actor) (*core.Attachment, error) {
+	app, err := getVisibleApplication(s.appRepo, appID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !attachmentAllowed(app.Status, purpose) {
+		return nil, errors.New("attachments of this purpose cannot be added in the current application status")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, errors.New("attachment exceeds the size limit")
+	}
+	if len(data) == 0 {
+		return nil, errors.New("attachment is empty")
+	}
+	contentType, ok := detectAttachmentType(data)
+	if !ok {
+		return nil, errors.New("attachment type is not allowed")
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	existing, err := s.attachmentRepo.FindByChecksum(app.ID, checksum)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("attachment already exists")
+	}
+
+	attachment := &core.Attachment{
+		ApplicationID: app.ID,
+		Purpose:       purpose,
+		FileName:      sanitizeFileName(fileName),
+		ContentType:   contentType,
+		Size:          int64(len(data)),
+		SHA256:        checksum,
+		StorageKey:    "applications/" + app.ID.String() + "/" + uuid.NewString(),
+		UploadedByID:  actor.ID,
+	}
+	if err := s.store.Put(attachment.StorageKey, bytes.NewReader(data), attachment.Size, contentType); err != nil {
+		return nil, err
+	}
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		// 元数据写入失败时清理已写入的文件，清理失败只记录日志，不影响返回的错误
+		if delErr := s.store.Delete(attachment.StorageKey); delErr != nil {
+			log.Printf("failed to clean up attachment %s: %v", attachment.StorageKey, delErr)
+		}
+		return nil, err
+	}
+	// 重新查询以带上上传人
+	return s.attachmentRepo.GetByID(attachment.ID)
+}
+
+// List 查询申请的全部附件
+func (s *attachmentService) List(appID uuid.UUID, actor Actor) ([]core.Attachment, error) {
+	if _, err := getVisibleApplication(s.appRepo, appID, actor); err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.FindByApplicationID(appID)
+}
+
+// Open 打开附件内容。附件必须属于路径中的申请，否则视为不存在。
+func (s *attachmentService) Open(appID, attachmentID uuid.UUID, actor Actor) (*core.Attachment, io.ReadCloser, error) {
+	if _, err := getVisibleApplication(s.appRepo, appID, actor); err != nil {
+		return nil, nil, err
+	}
+	attachment, err := s.attachmentRepo.GetByID(attachmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New("attachment not found")
+		}
+		return nil, nil, err
+	}
+	if attachment.ApplicationID != appID {
+		return nil, nil, errors.New("attachment not found")
+	}
+
+	content, err := s.store.Get(attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, errors.New("attachment content is missing")
+		}
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// attachmentAllowed 判断申请当前状态下能否上传某种用途的附件
+func attachmentAllowed(status core.ApplicationStatus, purpose core.AttachmentPurpose) bool {
+	switch purpose {
+	case core.AttachmentPurposeDefault:
+		return status == core.StatusPending || status == core.StatusReturned
+	case core.AttachmentPurposeRebirth:
+		return status == core.StatusApproved || status == core.StatusRebirthPending
+	default:
+		return false
+	}
+}
+
+// detectAttachmentType 根据文件内容检测 MIME 类型，并判断是否在允许上传的类型之内
+func detectAttachmentType(data []byte) (string, bool) {
+	detected := mimetype.Detect(data)
+	for _, allowed := range allowedAttachmentTypes {
+		if detected.Is(allowed) {
+			return detected.String(), true
+		}
+	}
+	return detected.String(), false
+}
+
+// sanitizeFileName 去掉客户端文件名中的路径部分和控制字符，文件名只用于展示和下载
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if len(name) > 255 {
+		ext := filepath.Ext(name)
+		if len(ext) > 16 {
+			ext = ""
+		}
+		name = strings.ToValidUTF8(name[:255-len(ext)], "") + ext
+	}
+	return name
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+	"xquant-default-management/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+const samplePDF = "%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\ntrailer << /Root 1 0 R >>\n%%EOF\n"
+
+func TestAttachmentService_Upload(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+	applicant := Actor{ID: uuid.New(), Role: core.RoleApplicant}
+	app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusPending, ApplicantID: applicant.ID}
+
+	newService := func(maxSize int64) (AttachmentService, *mocks.ApplicationRepository, *mocks.AttachmentRepository) {
+		appRepo := new(mocks.ApplicationRepository)
+		attachmentRepo := new(mocks.AttachmentRepository)
+		appRepo.On("GetByID", app.ID).Return(app, nil)
+		return NewAttachmentService(appRepo, attachmentRepo, store, maxSize), appRepo, attachmentRepo
+	}
+
+	t.Run("success", func(t *testing.T) {
+		svc, _, attachmentRepo := newService(0)
+		attachmentRepo.On("FindByChecksum", app.ID, mock.AnythingOfType("string")).Return(nil, nil).Once()
+		var created *core.Attachment
+		attachmentRepo.On("Create", mock.AnythingOfType("*core.Attachment")).Return(nil).Once().
+			Run(func(args mock.Arguments) {
+				created = args.Get(0).(*core.Attachment)
+				created.ID = uuid.New()
+			})
+		attachmentRepo.On("GetByID", mock.AnythingOfType("uuid.UUID")).Return(func(id uuid.UUID) *core.Attachment {
+			reloaded := *created
+			reloaded.UploadedBy = core.User{BaseModel: core.BaseModel{ID: applicant.ID}, Username: "applicant"}
+			return &reloaded
+		}, nil).Once()
+
+		attachment, err := svc.Upload(app.ID, core.AttachmentPurposeDefault, `C:\证据\逾期通知.pdf`, strings.NewReader(samplePDF), applicant)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, created.ID, attachment.ID)
+			assert.Equal(t, "applicant", attachment.UploadedBy.Username)
+			assert.Equal(t, "逾期通知.pdf", attachment.FileName)
+			assert.Equal(t, "application/pdf", attachment.ContentType)
+			assert.Equal(t, int64(len(samplePDF)), attachment.Size)
+			assert.Len(t, attachment.SHA256, 64)
+
+			content, err := store.Get(attachment.StorageKey)
+			if assert.NoError(t, err) {
+				data, _ := io.ReadAll(content)
+				content.Close()
+				assert.Equal(t, samplePDF, string(data))
+			}
+		}
+		attachmentRepo.AssertExpectations(t)
+	})
+
+	t.Run("size limit", func(t *testing.T) {
+		svc, _, _ := newService(16)
+
+		_, err := svc.Upload(app.ID, core.AttachmentPurposeDefault, "a.pdf", strings.NewReader(samplePDF), applicant)
+
+		assert.EqualError(t, err, "attachment exceeds the size limit")
+	})
+
+	t.Run("type is detected from content", func(t *testing.T) {
+		svc, _, _ := newService(0)
+
+		_, err := svc.Upload(app.ID, core.AttachmentPurposeDefault, "report.pdf", bytes.NewReader([]byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00\x00\x00\xff\xff")), applicant)
+
+		assert.EqualError(t, err, "attachment type is not allowed")
+	})
+
+	t.Run("duplicate content", func(t *testing.T) {
+		svc, _, attachmentRepo := newService(0)
+		attachmentRepo.On("FindByChecksum", app.ID, mock.AnythingOfType("string")).Return(&core.Attachment{}, nil).Once()
+
+		_, err := svc.Upload(app.ID, core.AttachmentPurposeDefault, "a.pdf", strings.NewReader(samplePDF), applicant)
+
+		assert.EqualError(t, err, "attachment already exists")
+	})
+
+	t.Run("rebirth evidence before default is approved", func(t *testing.T) {
+		svc, _, _ := newService(0)
+
+		_, err := svc.Upload(app.ID, core.AttachmentPurposeRebirth, "a.pdf", strings.NewReader(samplePDF), applicant)
+
+		assert.EqualError(t, err, "attachments of this purpose cannot be added in the current application status")
+	})
+
+	t.Run("other applicants cannot see the application", func(t *testing.T) {
+		svc, _, _ := newService(0)
+
+		_, err := svc.Upload(app.ID, core.AttachmentPurposeDefault, "a.pdf", strings.NewReader(samplePDF), Actor{ID: uuid.New(), Role: core.RoleApplicant})
+
+		assert.EqualError(t, err, "application not found")
+	})
+}
+
+func TestAttachmentService_Open(t *testing.T) {
+	appRepo := new(mocks.ApplicationRepository)
+	attachmentRepo := new(mocks.AttachmentRepository)
+	svc := NewAttachmentService(appRepo, attachmentRepo, nil, 0)
+	approver := Actor{ID: uuid.New(), Role: core.RoleApprover}
+	app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusApproved, ApplicantID: uuid.New()}
+	appRepo.On("GetByID", app.ID).Return(app, nil)
+
+	t.Run("attachment of another application", func(t *testing.T) {
+		id := uuid.New()
+		attachmentRepo.On("GetByID", id).Return(&core.Attachment{ApplicationID: uuid.New()}, nil).Once()
+
+		_, _, err := svc.Open(app.ID, id, approver)
+
+		assert.EqualError(t, err, "attachment not found")
+	})
+
+	t.Run("missing attachment", func(t *testing.T) {
+		id := uuid.New()
+		attachmentRepo.On("GetByID", id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, _, err := svc.Open(app.ID, id, approver)
+
+		assert.EqualError(t, err, "attachment not found")
+	})
+	attachmentRepo.AssertExpectations(t)
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// mentionPattern 匹配正文中的 @用户名。@ 前面必须是开头或空白/标点，避免把邮箱地址当作提及。
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@.])@([\p{L}\p{N}_.\-]+)`)
+
+// CommentService 定义了违约申请讨论评论相关的业务操作接口。
+// 评论的访问权限跟随申请：看不到申请的用户也看不到它的评论。
+type CommentService interface {
+	// AddComment 在申请下发表评论，正文中的 @用户名 会被记录为提及。
+	AddComment(appID uuid.UUID, body string, actor Actor) (*core.Comment, error)
+	// ListComments 查询申请下的全部评论。
+	ListComments(appID uuid.UUID, actor Actor) ([]core.Comment, error)
+	// EditComment 修改评论正文，修改前的版本保存到编辑历史中。只有作者可以编辑。
+	EditComment(appID, commentID uuid.UUID, body string, actor Actor) (*core.Comment, error)
+}
+
+type commentService struct {
+	db          *gorm.DB // 用于编辑评论时同时写入编辑历史的事务
+	appRepo     repository.ApplicationRepository
+	commentRepo repository.CommentRepository
+	userRepo    repository.UserRepository
+}
+
+// NewCommentService 是 commentService 的构造函数。
+func NewCommentService(db *gorm.DB, appRepo repository.ApplicationRepository, commentRepo repository.CommentRepository, userRepo repository.UserRepository) CommentService {
+	return &commentService{db: db, appRepo: appRepo, commentRepo: commentRepo, userRepo: userRepo}
+}
+
+// AddComment 发表评论
+func (s *commentService) AddComment(appID uuid.UUID, body string, actor Actor) (*core.Comment, error) {
+	app, err := getVisibleApplication(s.appRepo, appID, actor)
+	if err != nil {
+		return nil, err
+	}
+	mentions, err := resolveMentions(s.userRepo, app, body, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &core.Comment{
+		ApplicationID: app.ID,
+		AuthorID:      actor.ID,
+		Body:          body,
+		Mentions:      mentions,
+	}
+	if err := s.commentRepo.Create(comment); err != nil {
+		return nil, err
+	}
+	return s.commentRepo.GetByID(comment.ID)
+}
+
+// ListComments 查询申请下的全部评论
+func (s *commentService) ListComments(appID uuid.UUID, actor Actor) ([]core.Comment, error) {
+	if _, err := getVisibleApplication(s.appRepo, appID, actor); err != nil {
+		return nil, err
+	}
+	return s.commentRepo.FindByApplicationID(appID)
+}
+
+// EditComment 编辑评论。
+// 业务规则：只有作者本人可以编辑；正文没有变化时不产生编辑记录；提及的用户按新正文重新计算。
+func (s *commentService) EditComment(appID, commentID uuid.UUID, body string, actor Actor) (*core.Comment, error) {
+	var comment *core.Comment
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txAppRepo := repository.NewApplicationRepository(tx)
+		txCommentRepo := repository.NewCommentRepository(tx)
+		txUserRepo := repository.NewUserRepository(tx)
+
+		app, err := getVisibleApplication(txAppRepo, appID, actor)
+		if err != nil {
+			return err
+		}
+		comment, err = getComment(txCommentRepo, app.ID, commentID)
+		if err != nil {
+			return err
+		}
+		if comment.AuthorID != actor.ID {
+			return errors.New("only the author can edit this comment")
+		}
+		if comment.Body == body {
+			return nil
+		}
+
+		now := time.Now()
+		if err := txCommentRepo.CreateEdit(&core.CommentEdit{CommentID: comment.ID, Body: comment.Body, EditedAt: now}); err != nil {
+			return err
+		}
+		comment.Body = body
+		comment.EditedAt = &now
+		if err := txCommentRepo.Update(comment, "Body", "EditedAt"); err != nil {
+			return err
+		}
+
+		mentions, err := resolveMentions(txUserRepo, app, body, actor)
+		if err != nil {
+			return err
+		}
+		if err := txCommentRepo.ReplaceMentions(comment, mentions); err != nil {
+			return err
+		}
+		comment, err = txCommentRepo.GetByID(comment.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// getComment 查询属于指定申请的评论，不属于该申请的评论视为不存在
+func getComment(commentRepo repository.CommentRepository, appID, commentID uuid.UUID) (*core.Comment, error) {
+	comment, err := commentRepo.GetByID(commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("comment not found")
+		}
+		return nil, err
+	}
+	if comment.ApplicationID != appID {
+		return nil, errors.New("comment not found")
+	}
+	return comment, nil
+}
+
+// resolveMentions 将正文中的 @用户名 解析为用户。
+// 不存在的用户名、作者本人以及看不到这笔申请的用户 (例如其他申请人) 都会被忽略。
+func resolveMentions(userRepo repository.UserRepository, app *core.DefaultApplication, body string, author Actor) ([]core.User, error) {
+	names := parseMentions(body)
+	if len(names) == 0 {
+		return nil, nil
+	}
+	users, err := userRepo.FindByUsernames(names)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions := make([]core.User, 0, len(users))
+	for _, user := range users {
+		if user.ID == author.ID || !canViewApplication(app, Actor{ID: user.ID, Role: user.Role}) {
+			continue
+		}
+		mentions = append(mentions, user)
+	}
+	return mentions, nil
+}
+
+// parseMentions 提取正文中去重后的 @用户名，句末的标点不属于用户名
+func parseMentions(body string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		name := strings.TrimRight(match[1], ".-")
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseMentions(t *testing.T) {
+	assert.Equal(t, []string{"approver1", "张三"}, parseMentions("@approver1 请看一下逾期明细，抄送 @张三。再次提醒 @approver1"))
+	assert.Empty(t, parseMentions("联系 risk@example.com 获取原件"))
+}
+
+func TestCommentService_AddComment(t *testing.T) {
+	mockAppRepo := new(mocks.ApplicationRepository)
+	mockCommentRepo := new(mocks.CommentRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	svc := NewCommentService(nil, mockAppRepo, mockCommentRepo, mockUserRepo)
+
+	applicant := Actor{ID: uuid.New(), Role: core.RoleApplicant}
+	approver := core.User{BaseModel: core.BaseModel{ID: uuid.New()}, Username: "approver1", Role: core.RoleApprover}
+	otherApplicant := core.User{BaseModel: core.BaseModel{ID: uuid.New()}, Username: "applicant2", Role: core.RoleApplicant}
+	app := &core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusPending, ApplicantID: applicant.ID}
+
+	t.Run("mentions are limited to users who can see the application", func(t *testing.T) {
+		body := "@approver1 @applicant2 @nobody 已补充法院文书"
+		mockAppRepo.On("GetByID", app.ID).Return(app, nil).Once()
+		mockUserRepo.On("FindByUsernames", []string{"approver1", "applicant2", "nobody"}).Return([]core.User{approver, otherApplicant}, nil).Once()
+		mockCommentRepo.On("Create", mock.MatchedBy(func(c *core.Comment) bool {
+			return c.ApplicationID == app.ID && c.AuthorID == applicant.ID && c.Body == body &&
+				len(c.Mentions) == 1 && c.Mentions[0].ID == approver.ID
+		})).Return(nil).Once()
+		mockCommentRepo.On("GetByID", mock.Anything).Return(&core.Comment{Body: body}, nil).Once()
+
+		comment, err := svc.AddComment(app.ID, body, applicant)
+
+		assert.NoError(t, err)
+		assert.Equal(t, body, comment.Body)
+	})
+
+	t.Run("other applicants cannot comment", func(t *testing.T) {
+		mockAppRepo.On("GetByID", app.ID).Return(app, nil).Once()
+
+		_, err := svc.AddComment(app.ID, "hello", Actor{ID: otherApplicant.ID, Role: core.RoleApplicant})
+
+		assert.EqualError(t, err, "application not found")
+	})
+
+	mockAppRepo.AssertExpectations(t)
+	mockCommentRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
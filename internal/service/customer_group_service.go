@@ -0,0 +1,251 @@
+package service
+
+import (
+	"errors"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomerGroupService 定义了关联集团维护相关的业务操作接口。
+type CustomerGroupService interface {
+	CreateGroup(name string, parentCustomerID *uuid.UUID) (*core.CustomerGroup, error)
+	GetGroup(id uuid.UUID) (*core.CustomerGroup, error)
+	ListGroups() ([]core.CustomerGroup, error)
+	// UpdateGroup 只更新非 nil 的字段。
+	UpdateGroup(id uuid.UUID, name *string, parentCustomerID *uuid.UUID) (*core.CustomerGroup, error)
+	DeleteGroup(id uuid.UUID) error
+	AddMember(groupID, customerID uuid.UUID) (*core.CustomerGroup, error)
+	RemoveMember(groupID, customerID uuid.UUID) (*core.CustomerGroup, error)
+}
+
+type customerGroupService struct {
+	groupRepo    repository.CustomerGroupRepository
+	customerRepo repository.CustomerRepository
+	db           *gorm.DB // 集团维护总是涉及集团和客户两张表，写操作在事务中通过事务内的 Repository 完成
+}
+
+// NewCustomerGroupService 是 customerGroupService 的构造函数。
+func NewCustomerGroupService(db *gorm.DB, groupRepo repository.CustomerGroupRepository, customerRepo repository.CustomerRepository) CustomerGroupService {
+	return &customerGroupService{db: db, groupRepo: groupRepo, customerRepo: customerRepo}
+}
+
+// CreateGroup 创建一个关联集团。如果指定了母公司，母公司会自动成为集团成员。
+func (s *customerGroupService) CreateGroup(name string, parentCustomerID *uuid.UUID) (*core.CustomerGroup, error) {
+	var created *core.CustomerGroup
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txGroupRepo := repository.NewCustomerGroupRepository(tx)
+		txCustomerRepo := repository.NewCustomerRepository(tx, repository.CustomerSearchOptions{})
+
+		if err := checkGroupNameAvailable(txGroupRepo, name, uuid.Nil); err != nil {
+			return err
+		}
+
+		group := &core.CustomerGroup{Name: name}
+		if err := txGroupRepo.Create(group); err != nil {
+			return err
+		}
+
+		if parentCustomerID != nil {
+			if err := attachGroupMember(txCustomerRepo, group.ID, *parentCustomerID); err != nil {
+				return err
+			}
+			group.ParentCustomerID = parentCustomerID
+			if err := txGroupRepo.Update(group, "ParentCustomerID"); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		created, err = txGroupRepo.GetByID(group.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetGroup 根据 ID 获取集团及其成员
+func (s *customerGroupService) GetGroup(id uuid.UUID) (*core.CustomerGroup, error) {
+	return getGroup(s.groupRepo, id)
+}
+
+// ListGroups 获取全部集团
+func (s *customerGroupService) ListGroups() ([]core.CustomerGroup, error) {
+	return s.groupRepo.FindAll()
+}
+
+// UpdateGroup 修改集团名称或母公司。更换母公司时，新母公司同样会自动加入集团。
+func (s *customerGroupService) UpdateGroup(id uuid.UUID, name *string, parentCustomerID *uuid.UUID) (*core.CustomerGroup, error) {
+	var updated *core.CustomerGroup
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txGroupRepo := repository.NewCustomerGroupRepository(tx)
+		txCustomerRepo := repository.NewCustomerRepository(tx, repository.CustomerSearchOptions{})
+
+		group, err := getGroup(txGroupRepo, id)
+		if err != nil {
+			return err
+		}
+
+		var fields []string
+		if name != nil && *name != group.Name {
+			if err := checkGroupNameAvailable(txGroupRepo, *name, group.ID); err != nil {
+				return err
+			}
+			group.Name = *name
+			fields = append(fields, "Name")
+		}
+		if parentCustomerID != nil {
+			if err := attachGroupMember(txCustomerRepo, group.ID, *parentCustomerID); err != nil {
+				return err
+			}
+			group.ParentCustomerID = parentCustomerID
+			fields = append(fields, "ParentCustomerID")
+		}
+
+		if len(fields) > 0 {
+			// 清空预加载的关联，避免 GORM 在 Updates 时尝试保存关联数据
+			group.Parent = nil
+			group.Members = nil
+			if err := txGroupRepo.Update(group, fields...); err != nil {
+				return err
+			}
+		}
+
+		updated, err = txGroupRepo.GetByID(group.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteGroup 删除集团，所有成员会被解除关联，但客户本身不受影响。
+func (s *customerGroupService) DeleteGroup(id uuid.UUID) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		txGroupRepo := repository.NewCustomerGroupRepository(tx)
+		if _, err := getGroup(txGroupRepo, id); err != nil {
+			return err
+		}
+		return txGroupRepo.Delete(id)
+	})
+}
+
+// AddMember 将客户加入集团。一个客户最多属于一个集团。
+func (s *customerGroupService) AddMember(groupID, customerID uuid.UUID) (*core.CustomerGroup, error) {
+	var updated *core.CustomerGroup
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txGroupRepo := repository.NewCustomerGroupRepository(tx)
+		if _, err := getGroup(txGroupRepo, groupID); err != nil {
+			return err
+		}
+		if err := attachGroupMember(repository.NewCustomerRepository(tx, repository.CustomerSearchOptions{}), groupID, customerID); err != nil {
+			return err
+		}
+		var err error
+		updated, err = txGroupRepo.GetByID(groupID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// RemoveMember 将客户移出集团。如果该客户是母公司，集团的母公司设定也会被清除。
+func (s *customerGroupService) RemoveMember(groupID, customerID uuid.UUID) (*core.CustomerGroup, error) {
+	var updated *core.CustomerGroup
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txGroupRepo := repository.NewCustomerGroupRepository(tx)
+		txCustomerRepo := repository.NewCustomerRepository(tx, repository.CustomerSearchOptions{})
+
+		group, err := getGroup(txGroupRepo, groupID)
+		if err != nil {
+			return err
+		}
+		customer, err := getCustomer(txCustomerRepo, customerID)
+		if err != nil {
+			return err
+		}
+		if customer.GroupID == nil || *customer.GroupID != groupID {
+			return errors.New("customer is not a member of this group")
+		}
+
+		customer.GroupID = nil
+		if err := txCustomerRepo.Update(customer, "GroupID"); err != nil {
+			return err
+		}
+
+		if group.ParentCustomerID != nil && *group.ParentCustomerID == customerID {
+			group.ParentCustomerID = nil
+			group.Parent = nil
+			group.Members = nil
+			if err := txGroupRepo.Update(group, "ParentCustomerID"); err != nil {
+				return err
+			}
+		}
+
+		updated, err = txGroupRepo.GetByID(groupID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// getGroup 获取集团，并把 "未找到" 翻译为业务错误
+func getGroup(groupRepo repository.CustomerGroupRepository, id uuid.UUID) (*core.CustomerGroup, error) {
+	group, err := groupRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("customer group not found")
+		}
+		return nil, err
+	}
+	return group, nil
+}
+
+// getCustomer 获取客户，并把 "未找到" 翻译为业务错误
+func getCustomer(customerRepo repository.CustomerRepository, id uuid.UUID) (*core.Customer, error) {
+	customer, err := customerRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("customer not found")
+		}
+		return nil, err
+	}
+	return customer, nil
+}
+
+// checkGroupNameAvailable 检查集团名称是否已被其他集团占用
+func checkGroupNameAvailable(groupRepo repository.CustomerGroupRepository, name string, selfID uuid.UUID) error {
+	existing, err := groupRepo.GetByName(name)
+	if err == nil && existing.ID != selfID {
+		return errors.New("customer group name already exists")
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// attachGroupMember 将客户挂到指定集团下。已是该集团成员时不做任何操作。
+func attachGroupMember(customerRepo repository.CustomerRepository, groupID, customerID uuid.UUID) error {
+	customer, err := getCustomer(customerRepo, customerID)
+	if err != nil {
+		return err
+	}
+	if customer.GroupID != nil {
+		if *customer.GroupID == groupID {
+			return nil
+		}
+		return errors.New("customer already belongs to another group")
+	}
+	customer.GroupID = &groupID
+	return customerRepo.Update(customer, "GroupID")
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCustomerGroupService_GetGroup(t *testing.T) {
+	mockGroupRepo := new(mocks.CustomerGroupRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	groupService := NewCustomerGroupService(nil, mockGroupRepo, mockCustomerRepo)
+	id := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		group := &core.CustomerGroup{BaseModel: core.BaseModel{ID: id}, Name: "Acme Group"}
+		mockGroupRepo.On("GetByID", id).Return(group, nil).Once()
+
+		result, err := groupService.GetGroup(id)
+
+		assert.NoError(t, err)
+		assert.Same(t, group, result)
+		mockGroupRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockGroupRepo.On("GetByID", id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := groupService.GetGroup(id)
+
+		assert.EqualError(t, err, "customer group not found")
+		mockGroupRepo.AssertExpectations(t)
+	})
+}
+
+func TestCustomerGroupService_ListGroups(t *testing.T) {
+	mockGroupRepo := new(mocks.CustomerGroupRepository)
+	groupService := NewCustomerGroupService(nil, mockGroupRepo, new(mocks.CustomerRepository))
+	groups := []core.CustomerGroup{{Name: "Acme Group"}, {Name: "Globex Group"}}
+	mockGroupRepo.On("FindAll").Return(groups, nil).Once()
+
+	result, err := groupService.ListGroups()
+
+	assert.NoError(t, err)
+	assert.Equal(t, groups, result)
+	mockGroupRepo.AssertExpectations(t)
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DuplicateCandidate 是重复客户检测报告中的一对疑似重复客户
+type DuplicateCandidate struct {
+	Customer  core.Customer
+	Duplicate core.Customer
+	Score     float64 // 规范化名称的三元组相似度 (0 ~ 1)
+}
+
+// CustomerMergeService 定义了重复客户检测与合并的业务操作接口。
+type CustomerMergeService interface {
+	// FindDuplicates 按规范化名称的相似度找出疑似重复的客户对，相似度不低于 threshold。
+	FindDuplicates(threshold float64) ([]DuplicateCandidate, error)
+	// MergeCustomers 将 loserID 合并到 survivorID，所有操作在一个事务中完成。
+	MergeCustomers(survivorID, loserID, operatorID uuid.UUID, reason string) (*core.CustomerMergeRecord, error)
+	ListMergeRecords(customerID *uuid.UUID) ([]core.CustomerMergeRecord, error)
+}
+
+type customerMergeService struct {
+	mergeRepo repository.CustomerMergeRepository
+	db        *gorm.DB // 用于合并时开启事务
+}
+
+// NewCustomerMergeService 是 customerMergeService 的构造函数。
+func NewCustomerMergeService(db *gorm.DB, mergeRepo repository.CustomerMergeRepository) CustomerMergeService {
+	return &customerMergeService{db: db, mergeRepo: mergeRepo}
+}
+
+// FindDuplicates 生成重复客户检测报告
+func (s *customerMergeService) FindDuplicates(threshold float64) ([]DuplicateCandidate, error) {
+	customers, err := s.mergeRepo.ListCustomers()
+	if err != nil {
+		return nil, err
+	}
+	return findDuplicateCandidates(customers, threshold), nil
+}
+
+// MergeCustomers 合并重复客户。
+// 1. 被合并客户的违约申请、评级、敞口和曾用名全部转移到保留客户，被合并客户的名称记为保留客户的曾用名；
+// 2. 根据转移后的申请重新计算保留客户的违约状态，并根据最新评级重新派生 LatestExtGrade；
+// 3. 保留客户缺失的信用代码、行业、区域和所属集团从被合并客户补齐；
+// 4. 软删除被合并客户并写入审计记录。
+// 业务规则：两个客户都有待处理申请时不能合并，否则合并后同一客户会有两个待处理申请。
+func (s *customerMergeService) MergeCustomers(survivorID, loserID, operatorID uuid.UUID, reason string) (*core.CustomerMergeRecord, error) {
+	if survivorID == loserID {
+		return nil, errors.New("cannot merge a customer into itself")
+	}
+
+	var record *core.CustomerMergeRecord
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txCustomerRepo := repository.NewCustomerRepository(tx, repository.CustomerSearchOptions{})
+		txAppRepo := repository.NewApplicationRepository(tx)
+		txMergeRepo := repository.NewCustomerMergeRepository(tx)
+
+		survivor, err := getCustomer(txCustomerRepo, survivorID)
+		if err != nil {
+			return err
+		}
+		loser, err := getCustomer(txCustomerRepo, loserID)
+		if err != nil {
+			return err
+		}
+
+		survivorPending, err := txAppRepo.FindPendingByCustomerID(survivor.ID)
+		if err != nil {
+			return err
+		}
+		loserPending, err := txAppRepo.FindPendingByCustomerID(loser.ID)
+		if err != nil {
+			return err
+		}
+		if survivorPending != nil && loserPending != nil {
+			return errors.New("both customers have pending applications")
+		}
+
+		// 1. 转移关联数据，并保留被合并客户的名称作为曾用名
+		moved, err := txMergeRepo.ReassignReferences(loser.ID, survivor.ID)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := txCustomerRepo.CreateAlias(&core.CustomerAlias{CustomerID: survivor.ID, Name: loser.Name, ValidUntil: now}); err != nil {
+			return err
+		}
+
+		// 2. 重新计算违约状态和最新评级
+		wasDefault := survivor.IsDefault
+		survivor.IsDefault, err = txMergeRepo.HasActiveDefault(survivor.ID)
+		if err != nil {
+			return err
+		}
+		fields := []string{"IsDefault"}
+		latest, err := repository.NewRatingRepository(tx).FindLatestByCustomerID(survivor.ID)
+		if err != nil {
+			return err
+		}
+		if latest != nil {
+			survivor.LatestExtGrade = latest.Grade
+			fields = append(fields, "LatestExtGrade")
+		}
+
+		// 3. 补齐保留客户缺失的主数据
+		var loserCreditCode string
+		if loser.CreditCode != nil {
+			loserCreditCode = *loser.CreditCode
+		}
+		if survivor.CreditCode == nil && loser.CreditCode != nil {
+			// 信用代码有唯一索引，必须先从被合并客户上移除
+			survivor.CreditCode, loser.CreditCode = loser.CreditCode, nil
+			if err := txCustomerRepo.Update(loser, "CreditCode"); err != nil {
+				return err
+			}
+			fields = append(fields, "CreditCode")
+		}
+		if survivor.Industry == "" && loser.Industry != "" {
+			survivor.Industry = loser.Industry
+			fields = append(fields, "Industry")
+		}
+		if survivor.Region == "" && loser.Region != "" {
+			survivor.Region = loser.Region
+			fields = append(fields, "Region")
+		}
+		if survivor.GroupID == nil && loser.GroupID != nil {
+			survivor.GroupID = loser.GroupID
+			fields = append(fields, "GroupID")
+		}
+		if err := txCustomerRepo.Update(survivor, fields...); err != nil {
+			return err
+		}
+
+		// 4. 软删除被合并客户并记录审计信息
+		if err := txCustomerRepo.Delete(loser.ID); err != nil {
+			return err
+		}
+		record = &core.CustomerMergeRecord{
+			SurvivorID:         survivor.ID,
+			LoserID:            loser.ID,
+			LoserName:          loser.Name,
+			LoserCreditCode:    loserCreditCode,
+			MovedApplications:  moved,
+			SurvivorWasDefault: wasDefault,
+			SurvivorIsDefault:  survivor.IsDefault,
+			Reason:             reason,
+			OperatorID:         operatorID,
+			MergedAt:           now,
+		}
+		return txMergeRepo.CreateRecord(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ListMergeRecords 查询合并审计记录
+func (s *customerMergeService) ListMergeRecords(customerID *uuid.UUID) ([]core.CustomerMergeRecord, error) {
+	return s.mergeRepo.FindRecords(customerID)
+}
+
+// legalFormSuffixes 是规范化名称时去掉的英文公司组织形式后缀 (按单词匹配)
+var legalFormSuffixes = map[string]bool{
+	"co": true, "ltd": true, "limited": true, "inc": true, "corp": true,
+	"corporation": true, "company": true, "llc": true, "plc": true,
+}
+
+// chineseLegalFormSuffixes 是规范化名称时去掉的中文公司组织形式后缀，较长的在前
+var chineseLegalFormSuffixes = []string{"股份有限公司", "有限责任公司", "有限公司", "公司"}
+
+// normalizeCustomerName 将客户名称规范化，用于重复检测：
+// 全角字符转半角、统一小写、去掉标点和空白，并去掉 "有限公司"、"Co., Ltd." 等组织形式后缀。
+func normalizeCustomerName(name string) string {
+	folded := strings.Map(func(r rune) rune {
+		switch {
+		case r == '　':
+			return ' '
+		case r >= '！' && r <= '～':
+			r -= 0xfee0
+		}
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			return ' '
+		}
+		return unicode.ToLower(r)
+	}, name)
+
+	words := strings.Fields(folded)
+	for len(words) > 1 && legalFormSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	normalized := strings.Join(words, "")
+	for _, suffix := range chineseLegalFormSuffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			if trimmed := strings.TrimSuffix(normalized, suffix); trimmed != "" {
+				normalized = trimmed
+			}
+			break
+		}
+	}
+	return normalized
+}
+
+// nameTrigrams 按 pg_trgm 的规则 (前补两个空格、后补一个空格) 提取三元组集合
+func nameTrigrams(normalized string) map[string]struct{} {
+	runes := []rune("  " + normalized + " ")
+	grams := make(map[string]struct{}, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		grams[string(runes[i:i+3])] = struct{}{}
+	}
+	return grams
+}
+
+// findDuplicateCandidates 找出规范化名称相似度不低于 threshold 的客户对，按相似度从高到低排序。
+// 使用三元组倒排索引，只比较至少共享一个三元组的客户，避免对全部客户两两比较。
+func findDuplicateCandidates(customers []core.Customer, threshold float64) []DuplicateCandidate {
+	grams := make([]map[string]struct{}, len(customers))
+	index := make(map[string][]int)
+	for i, customer := range customers {
+		normalized := normalizeCustomerName(customer.Name)
+		if normalized == "" {
+			continue
+		}
+		grams[i] = nameTrigrams(normalized)
+		for gram := range grams[i] {
+			index[gram] = append(index[gram], i)
+		}
+	}
+
+	var candidates []DuplicateCandidate
+	for i := range customers {
+		shared := make(map[int]int)
+		for gram := range grams[i] {
+			for _, j := range index[gram] {
+				if j > i {
+					shared[j]++
+				}
+			}
+		}
+		for j, common := range shared {
+			score := float64(common) / float64(len(grams[i])+len(grams[j])-common)
+			if score >= threshold {
+				candidates = append(candidates, DuplicateCandidate{Customer: customers[i], Duplicate: customers[j], Score: score})
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].Score != candidates[b].Score {
+			return candidates[a].Score > candidates[b].Score
+		}
+		if candidates[a].Customer.Name != candidates[b].Customer.Name {
+			return candidates[a].Customer.Name < candidates[b].Customer.Name
+		}
+		return candidates[a].Duplicate.Name < candidates[b].Duplicate.Name
+	})
+	return candidates
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"testing"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeCustomerName(t *testing.T) {
+	cases := map[string]string{
+		"厦门国贸集团股份有限公司":          "厦门国贸集团",
+		"厦门国贸集团（股份有限公司）":        "厦门国贸集团",
+		"Ｘｑｕａｎｔ Tech Co., Ltd.": "xquanttech",
+		"Costco":                "costco",
+		"有限公司":                  "有限公司",
+	}
+	for name, want := range cases {
+		assert.Equal(t, want, normalizeCustomerName(name), name)
+	}
+}
+
+func TestCustomerMergeService_FindDuplicates(t *testing.T) {
+	mockMergeRepo := new(mocks.CustomerMergeRepository)
+	svc := NewCustomerMergeService(nil, mockMergeRepo)
+
+	a := core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "深圳市腾讯计算机系统有限公司"}
+	b := core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "深圳市腾讯计算机系统有限责任公司"}
+	c := core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "Acme Holdings Inc."}
+	d := core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "ACME Holding Inc"}
+	e := core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "北京字节跳动科技有限公司"}
+	mockMergeRepo.On("ListCustomers").Return([]core.Customer{a, b, c, d, e}, nil).Once()
+
+	candidates, err := svc.FindDuplicates(0.6)
+
+	assert.NoError(t, err)
+	if assert.Len(t, candidates, 2) {
+		// 规范化后完全相同的一对排在最前
+		assert.Equal(t, 1.0, candidates[0].Score)
+		assert.ElementsMatch(t, []uuid.UUID{a.ID, b.ID}, []uuid.UUID{candidates[0].Customer.ID, candidates[0].Duplicate.ID})
+		assert.Less(t, candidates[1].Score, 1.0)
+		assert.ElementsMatch(t, []uuid.UUID{c.ID, d.ID}, []uuid.UUID{candidates[1].Customer.ID, candidates[1].Duplicate.ID})
+	}
+	mockMergeRepo.AssertExpectations(t)
+}
@@ -0,0 +1,675 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+	"xquant-default-management/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomerService 定义了客户主数据维护相关的业务操作接口。
+type CustomerService interface {
+	// CreateCustomer 创建客户，creditCode 为空表示暂不登记统一社会信用代码。
+	// LatestExtGrade 不能直接写入，只能由评级录入 (RatingService.IngestRating) 派生。
+	CreateCustomer(name, creditCode, industry, region string) (*core.Customer, error)
+	GetCustomer(id uuid.UUID) (*core.Customer, error)
+	ListCustomers(params repository.CustomerQueryParams) ([]core.Customer, int64, error)
+	// UpdateCustomer 只更新非 nil 的字段，nil 表示调用方未提供该字段。
+	UpdateCustomer(id uuid.UUID, name, creditCode, industry, region *string) (*core.Customer, error)
+	// GetAliases 查询客户的曾用名历史。
+	GetAliases(id uuid.UUID) ([]core.CustomerAlias, error)
+	// SearchCustomers 按关键字搜索客户，用于提交申请前的客户选择和输入联想。
+	SearchCustomers(q string, limit int) ([]repository.CustomerMatch, error)
+	DeleteCustomer(id uuid.UUID) error
+	// ImportCustomers 从 CSV 中批量导入客户，按名称做 upsert，并返回逐行的处理报告。
+	ImportCustomers(r io.Reader) (*api.CustomerImportReport, error)
+	// GetTimeline 根据违约申请记录还原客户的违约/重生时间线。
+	// on 不为 nil 时，同时判断客户在该日期内是否处于违约状态。
+	GetTimeline(customerID uuid.UUID, on *time.Time) (*api.CustomerTimelineResponse, error)
+}
+
+// 客户搜索返回的默认条数和最大条数
+const (
+	defaultCustomerSearchLimit = 10
+	maxCustomerSearchLimit     = 50
+)
+
+// customerImportChunkSize 是批量导入时每个事务处理的行数。
+// 分块提交可以避免一个超大文件长时间持有事务，同时某一块失败也不会影响其他块。
+const customerImportChunkSize = 500
+
+// ErrInvalidCSV 表示上传的文件整体无法作为客户 CSV 解析 (空文件、缺少表头等)。
+// 单行数据的问题不会返回此错误，而是记录在导入报告中。
+var ErrInvalidCSV = errors.New("invalid csv file")
+
+type customerService struct {
+	customerRepo repository.CustomerRepository
+	appRepo      repository.ApplicationRepository
+	dictRepo     repository.DictionaryRepository // 用于校验行业、区域编码
+	db           *gorm.DB                        // 用于批量导入时开启事务
+}
+
+// NewCustomerService 是 customerService 的构造函数。
+func NewCustomerService(db *gorm.DB, customerRepo repository.CustomerRepository, appRepo repository.ApplicationRepository, dictRepo repository.DictionaryRepository) CustomerService {
+	return &customerService{db: db, customerRepo: customerRepo, appRepo: appRepo, dictRepo: dictRepo}
+}
+
+// CreateCustomer 创建一个新客户。客户名称在系统中必须唯一，行业和区域必须是字典中的编码。
+// 同名客户曾被软删除时不会新建记录，而是恢复该客户 (见 restoreDeletedCustomer)。
+func (s *customerService) CreateCustomer(name, creditCode, industry, region string) (*core.Customer, error) {
+	if err := validateCustomerCodes(s.dictRepo, industry, region); err != nil {
+		return nil, err
+	}
+	creditCode = utils.NormalizeUSCC(creditCode)
+	if creditCode != "" {
+		if err := checkCreditCode(s.customerRepo, creditCode, uuid.Nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1. 检查名称是否已被占用
+	_, err := s.customerRepo.GetByName(name)
+	if err == nil {
+		return nil, errors.New("customer name already exists")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// 2. 软删除的客户仍占用名称和信用代码的唯一索引，同名的已删除客户直接恢复
+	restored, err := restoreDeletedCustomer(s.customerRepo, name, creditCode, industry, region)
+	if err != nil || restored != nil {
+		return restored, err
+	}
+
+	// 3. 创建客户实体。新客户的 IsDefault 总是 false，违约状态只能通过审批流程改变。
+	customer := &core.Customer{
+		Name:     name,
+		Industry: industry,
+		Region:   region,
+	}
+	if creditCode != "" {
+		customer.CreditCode = &creditCode
+	}
+	if err := s.customerRepo.Create(customer); err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+// GetCustomer 根据 ID 获取客户
+func (s *customerService) GetCustomer(id uuid.UUID) (*core.Customer, error) {
+	return getCustomer(s.customerRepo, id)
+}
+
+// ListCustomers 分页查询客户列表
+func (s *customerService) ListCustomers(params repository.CustomerQueryParams) ([]core.Customer, int64, error) {
+	return s.customerRepo.FindAll(params)
+}
+
+// UpdateCustomer 更新客户的主数据字段。
+// 注意：IsDefault 不允许通过此接口修改，它只能由审批和重生流程维护。
+// 改名时旧名称会作为曾用名保存，改名和记录曾用名在同一个事务中完成。
+func (s *customerService) UpdateCustomer(id uuid.UUID, name, creditCode, industry, region *string) (*core.Customer, error) {
+	customer, err := s.GetCustomer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	var previousName string
+	if name != nil && *name != customer.Name {
+		// 改名时同样需要保证名称唯一
+		existing, err := s.customerRepo.GetByName(*name)
+		if err == nil && existing.ID != customer.ID {
+			return nil, errors.New("customer name already exists")
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if _, err := s.customerRepo.GetDeletedByName(*name); err == nil {
+			return nil, errors.New("customer name belongs to a deleted customer")
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		previousName = customer.Name
+		customer.Name = *name
+		fields = append(fields, "Name")
+	}
+	if creditCode != nil {
+		code := utils.NormalizeUSCC(*creditCode)
+		if code == "" {
+			customer.CreditCode = nil
+		} else {
+			if err := checkCreditCode(s.customerRepo, code, customer.ID); err != nil {
+				return nil, err
+			}
+			if err := checkDeletedCreditCode(s.customerRepo, code, customer.ID); err != nil {
+				return nil, err
+			}
+			customer.CreditCode = &code
+		}
+		fields = append(fields, "CreditCode")
+	}
+	if industry != nil {
+		if err := validateDictionaryCode(s.dictRepo, "industry", *industry); err != nil {
+			return nil, err
+		}
+		customer.Industry = *industry
+		fields = append(fields, "Industry")
+	}
+	if region != nil {
+		if err := validateDictionaryCode(s.dictRepo, "region", *region); err != nil {
+			return nil, err
+		}
+		customer.Region = *region
+		fields = append(fields, "Region")
+	}
+
+	// 没有任何字段需要更新时，直接返回当前数据
+	if len(fields) == 0 {
+		return customer, nil
+	}
+	if previousName == "" {
+		if err := s.customerRepo.Update(customer, fields...); err != nil {
+			return nil, err
+		}
+		return customer, nil
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		txCustomerRepo := repository.NewCustomerRepository(tx, repository.CustomerSearchOptions{})
+		alias := &core.CustomerAlias{CustomerID: customer.ID, Name: previousName, ValidUntil: time.Now()}
+		if err := txCustomerRepo.CreateAlias(alias); err != nil {
+			return err
+		}
+		return txCustomerRepo.Update(customer, fields...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+// GetAliases 查询客户的曾用名历史
+func (s *customerService) GetAliases(id uuid.UUID) ([]core.CustomerAlias, error) {
+	if _, err := s.GetCustomer(id); err != nil {
+		return nil, err
+	}
+	return s.customerRepo.FindAliases(id)
+}
+
+// checkCreditCode 校验统一社会信用代码的校验位，并确认它没有被其他客户占用
+func checkCreditCode(customerRepo repository.CustomerRepository, creditCode string, selfID uuid.UUID) error {
+	if !utils.ValidateUSCC(creditCode) {
+		return errors.New("invalid unified social credit code")
+	}
+	existing, err := customerRepo.GetByCreditCode(creditCode)
+	if err == nil && existing.ID != selfID {
+		return errors.New("credit code already exists")
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// checkDeletedCreditCode 校验统一社会信用代码没有被其他已软删除的客户占用。
+// 软删除的记录仍在唯一索引中，不提前检查的话写入时会直接违反约束。
+func checkDeletedCreditCode(customerRepo repository.CustomerRepository, creditCode string, selfID uuid.UUID) error {
+	holder, err := customerRepo.GetDeletedByCreditCode(creditCode)
+	if err == nil && holder.ID != selfID {
+		return errors.New("credit code belongs to a deleted customer")
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// restoreDeletedCustomer 恢复一个与 name 同名的已软删除客户，没有这样的客户时返回 nil。
+// 恢复时只覆盖非空的字段，客户原有的违约申请、评级和曾用名都保持关联。
+// 被合并的客户 (名称已成为保留客户的曾用名) 不会被恢复，信用代码被另一个已删除客户占用时同样返回冲突错误。
+func restoreDeletedCustomer(customerRepo repository.CustomerRepository, name, creditCode, industry, region string) (*core.Customer, error) {
+	customer, err := customerRepo.GetDeletedByName(name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if creditCode != "" {
+			return nil, checkDeletedCreditCode(customerRepo, creditCode, uuid.Nil)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := customerRepo.GetByAlias(name); err == nil {
+		return nil, errors.New("customer was merged into another customer")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var fields []string
+	if industry != "" {
+		customer.Industry = industry
+		fields = append(fields, "Industry")
+	}
+	if region != "" {
+		customer.Region = region
+		fields = append(fields, "Region")
+	}
+	if creditCode != "" {
+		if err := checkDeletedCreditCode(customerRepo, creditCode, customer.ID); err != nil {
+			return nil, err
+		}
+		customer.CreditCode = &creditCode
+		fields = append(fields, "CreditCode")
+	}
+	if err := customerRepo.Restore(customer, fields...); err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+// DeleteCustomer 软删除一个客户。
+// 业务规则：已违约或仍有待处理申请的客户不能删除，否则会让审批流程失去目标。
+func (s *customerService) DeleteCustomer(id uuid.UUID) error {
+	customer, err := s.GetCustomer(id)
+	if err != nil {
+		return err
+	}
+	if customer.IsDefault {
+		return errors.New("customer is in default status")
+	}
+
+	pending, err := s.appRepo.FindPendingByCustomerID(customer.ID)
+	if err != nil {
+		return err
+	}
+	if pending != nil {
+		return errors.New("there is a pending application for this customer")
+	}
+
+	return s.customerRepo.Delete(customer.ID)
+}
+
+// customerImportRow 是从 CSV 中解析出的一行待导入数据
+type customerImportRow struct {
+	line       int
+	name       string
+	industry   string
+	region     string
+	creditCode string
+}
+
+// ImportCustomers 批量导入客户。
+// 1. 先完整解析 CSV，逐行做格式校验，不合法的行直接记为 rejected；
+// 2. 再把合法的行按 customerImportChunkSize 分块，每块在一个独立事务中按名称 upsert。
+// 单行校验或写入失败不会影响整个文件；只有某一块提交失败时，该块才会整体回滚并被标记为 rejected。
+func (s *customerService) ImportCustomers(r io.Reader) (*api.CustomerImportReport, error) {
+	rows, results, err := parseCustomerCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for start := 0; start < len(rows); start += customerImportChunkSize {
+		end := start + customerImportChunkSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		chunk := rows[start:end]
+
+		var chunkResults []api.CustomerImportRowResult
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			chunkResults = chunkResults[:0]
+			for _, row := range chunk {
+				// 每一行使用嵌套事务 (SAVEPOINT)，单行写入失败只回滚该行，不影响同一块中的其他行
+				var status string
+				err := tx.Transaction(func(rowTx *gorm.DB) error {
+					if err := validateCustomerCodes(repository.NewDictionaryRepository(rowTx), row.industry, row.region); err != nil {
+						return err
+					}
+					var err error
+					status, err = upsertCustomer(repository.NewCustomerRepository(rowTx, repository.CustomerSearchOptions{}), row)
+					return err
+				})
+				result := api.CustomerImportRowResult{Row: row.line, Name: row.name, Status: status}
+				if err != nil {
+					result.Status = "rejected"
+					result.Reason = err.Error()
+				}
+				chunkResults = append(chunkResults, result)
+			}
+			return nil
+		})
+		if err != nil {
+			// 提交失败时整块回滚，块内所有行都视为未导入
+			chunkResults = chunkResults[:0]
+			for _, row := range chunk {
+				chunkResults = append(chunkResults, api.CustomerImportRowResult{
+					Row:    row.line,
+					Name:   row.name,
+					Status: "rejected",
+					Reason: "database error: " + err.Error(),
+				})
+			}
+		}
+		results = append(results, chunkResults...)
+	}
+
+	// 按行号排序后汇总
+	report := &api.CustomerImportReport{Rows: sortImportResults(results)}
+	for _, res := range report.Rows {
+		report.Total++
+		switch res.Status {
+		case "created":
+			report.Created++
+		case "updated":
+			report.Updated++
+		default:
+			report.Rejected++
+		}
+	}
+	return report, nil
+}
+
+// validateCustomerCodes 校验客户的行业和区域编码
+func validateCustomerCodes(dictRepo repository.DictionaryRepository, industry, region string) error {
+	if err := validateDictionaryCode(dictRepo, "industry", industry); err != nil {
+		return err
+	}
+	return validateDictionaryCode(dictRepo, "region", region)
+}
+
+// upsertCustomer 以名称为键写入一行客户数据，返回 "created" 或 "updated"。
+// 已存在的客户只会覆盖 CSV 中非空的列，空单元格不会清空已有数据；信用代码只能补录，不能覆盖。
+// 同名客户已被软删除时会恢复该客户，在报告中计为 "created"。
+func upsertCustomer(customerRepo repository.CustomerRepository, row customerImportRow) (string, error) {
+	customer, err := customerRepo.GetByName(row.name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 与 CreateCustomer 一样先检查信用代码，重复的代码以业务错误拒绝该行
+		if row.creditCode != "" {
+			if err := checkCreditCode(customerRepo, row.creditCode, uuid.Nil); err != nil {
+				return "", err
+			}
+		}
+		restored, err := restoreDeletedCustomer(customerRepo, row.name, row.creditCode, row.industry, row.region)
+		if err != nil {
+			return "", err
+		}
+		if restored != nil {
+			return "created", nil
+		}
+		newCustomer := &core.Customer{
+			Name:     row.name,
+			Industry: row.industry,
+			Region:   row.region,
+		}
+		if row.creditCode != "" {
+			newCustomer.CreditCode = &row.creditCode
+		}
+		if err := customerRepo.Create(newCustomer); err != nil {
+			return "", err
+		}
+		return "created", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var fields []string
+	if row.industry != "" {
+		customer.Industry = row.industry
+		fields = append(fields, "Industry")
+	}
+	if row.region != "" {
+		customer.Region = row.region
+		fields = append(fields, "Region")
+	}
+	if row.creditCode != "" && (customer.CreditCode == nil || *customer.CreditCode != row.creditCode) {
+		// 信用代码是客户的登记标识，导入只能补录，不能覆盖已有的代码 (需要更正时使用 PUT /customers/{id})
+		if customer.CreditCode != nil {
+			return "", errors.New("credit code does not match the existing customer")
+		}
+		if err := checkCreditCode(customerRepo, row.creditCode, customer.ID); err != nil {
+			return "", err
+		}
+		if err := checkDeletedCreditCode(customerRepo, row.creditCode, customer.ID); err != nil {
+			return "", err
+		}
+		customer.CreditCode = &row.creditCode
+		fields = append(fields, "CreditCode")
+	}
+	if len(fields) > 0 {
+		if err := customerRepo.Update(customer, fields...); err != nil {
+			return "", err
+		}
+	}
+	return "updated", nil
+}
+
+// parseCustomerCSV 解析 CSV 并做逐行校验。
+// 表头不区分大小写，必须包含 Name 列，CreditCode/Industry/Region 列可选。
+// 其他列 (包括旧模板中的 LatestExtGrade) 会被忽略：最新外部评级只能由评级录入派生。
+// 返回通过校验的行，以及被拒绝行的处理结果。只有文件本身无法读取时才返回 error。
+func parseCustomerCSV(r io.Reader) ([]customerImportRow, []api.CustomerImportRowResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // 允许各行列数不一致，缺失的列按空值处理
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, fmt.Errorf("%w: file is empty", ErrInvalidCSV)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+
+	columns := make(map[string]int)
+	for i, col := range header {
+		// Excel 导出的 UTF-8 文件常带有 BOM，需要去掉
+		col = strings.TrimPrefix(col, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, nil, fmt.Errorf("%w: header must contain a Name column", ErrInvalidCSV)
+	}
+	cell := func(record []string, column string) string {
+		idx, ok := columns[column]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	var rows []customerImportRow
+	var rejected []api.CustomerImportRowResult
+	seen := make(map[string]int) // 名称 -> 首次出现的行号，用于检测文件内重复
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rejected = append(rejected, api.CustomerImportRowResult{Row: parseErr.StartLine, Status: "rejected", Reason: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, err
+		}
+
+		row := customerImportRow{
+			line:       line,
+			name:       cell(record, "name"),
+			industry:   cell(record, "industry"),
+			region:     cell(record, "region"),
+			creditCode: utils.NormalizeUSCC(cell(record, "creditcode")),
+		}
+		if reason := validateCustomerImportRow(row); reason != "" {
+			rejected = append(rejected, api.CustomerImportRowResult{Row: row.line, Name: row.name, Status: "rejected", Reason: reason})
+			continue
+		}
+		if firstLine, dup := seen[row.name]; dup {
+			rejected = append(rejected, api.CustomerImportRowResult{
+				Row:    row.line,
+				Name:   row.name,
+				Status: "rejected",
+				Reason: fmt.Sprintf("duplicate name in file (first seen at row %d)", firstLine),
+			})
+			continue
+		}
+		seen[row.name] = row.line
+		rows = append(rows, row)
+	}
+	return rows, rejected, nil
+}
+
+// validateCustomerImportRow 使用与 api.CreateCustomerRequest 相同的规则校验一行数据，
+// 返回空字符串表示校验通过。
+func validateCustomerImportRow(row customerImportRow) string {
+	switch {
+	case row.name == "":
+		return "name is required"
+	case utf8.RuneCountInString(row.name) > 255:
+		return "name must be at most 255 characters"
+	case utf8.RuneCountInString(row.industry) > 100:
+		return "industry must be at most 100 characters"
+	case utf8.RuneCountInString(row.region) > 100:
+		return "region must be at most 100 characters"
+	case row.creditCode != "" && !utils.ValidateUSCC(row.creditCode):
+		return "invalid unified social credit code"
+	}
+	return ""
+}
+
+// sortImportResults 按 CSV 行号对结果排序，方便用户对照原文件
+func sortImportResults(results []api.CustomerImportRowResult) []api.CustomerImportRowResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Row < results[j].Row
+	})
+	return results
+}
+
+// GetTimeline 查询客户的违约状态时间线。
+// Customer.IsDefault 只记录当前状态，历史区间完全由 DefaultApplication 上的审批时间推导：
+// ApprovalTime 是违约区间的开始，RebirthApprovalTime 是违约区间的结束和重生区间的开始。
+func (s *customerService) GetTimeline(customerID uuid.UUID, on *time.Time) (*api.CustomerTimelineResponse, error) {
+	customer, err := getCustomer(s.customerRepo, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := s.appRepo.FindByCustomerID(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &api.CustomerTimelineResponse{
+		CustomerID:   customer.ID.String(),
+		CustomerName: customer.Name,
+		IsDefault:    customer.IsDefault,
+		Periods:      buildDefaultTimeline(apps),
+	}
+	if on != nil {
+		inDefault := inDefaultOn(res.Periods, *on)
+		res.InDefaultOn = &inDefault
+	}
+	return res, nil
+}
+
+// buildDefaultTimeline 将客户的违约申请转换为按时间排序的违约/重生区间。
+// 只有被批准过的申请 (Approved / RebirthPending / Reborn) 才会形成违约区间，
+// Pending 和 Rejected 的申请从未改变过客户状态，因此不出现在时间线上。
+func buildDefaultTimeline(apps []core.DefaultApplication) []api.CustomerTimelinePeriod {
+	approved := make([]core.DefaultApplication, 0, len(apps))
+	for _, app := range apps {
+		if app.ApprovalTime == nil {
+			continue
+		}
+		switch app.Status {
+		case core.StatusApproved, core.StatusRebirthPending, core.StatusReborn:
+			approved = append(approved, app)
+		}
+	}
+	sort.SliceStable(approved, func(i, j int) bool {
+		return approved[i].ApprovalTime.Before(*approved[j].ApprovalTime)
+	})
+
+	periods := make([]api.CustomerTimelinePeriod, 0, len(approved)*2)
+	for i, app := range approved {
+		period := api.CustomerTimelinePeriod{
+			Type:          "Default",
+			Start:         *app.ApprovalTime,
+			ApplicationID: app.ID.String(),
+			Severity:      app.Severity,
+			DefaultReason: app.DefaultReason,
+		}
+		if app.Approver != nil {
+			period.ApproverName = app.Approver.Username
+		}
+		if app.Status != core.StatusReborn || app.RebirthApprovalTime == nil {
+			periods = append(periods, period)
+			continue
+		}
+		period.End = app.RebirthApprovalTime
+		periods = append(periods, period)
+
+		// 重生区间持续到下一次违约认定被批准为止
+		rebirth := api.CustomerTimelinePeriod{
+			Type:          "Reborn",
+			Start:         *app.RebirthApprovalTime,
+			ApplicationID: app.ID.String(),
+			Severity:      app.Severity,
+			RebirthReason: app.RebirthReason,
+		}
+		if app.RebirthApprover != nil {
+			rebirth.ApproverName = app.RebirthApprover.Username
+		}
+		if i+1 < len(approved) {
+			rebirth.End = approved[i+1].ApprovalTime
+		}
+		periods = append(periods, rebirth)
+	}
+	return periods
+}
+
+// inDefaultOn 判断客户在某一天内是否处于违约状态：只要该日与任一违约区间有交集即视为违约。
+func inDefaultOn(periods []api.CustomerTimelinePeriod, day time.Time) bool {
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	for _, p := range periods {
+		if p.Type != "Default" {
+			continue
+		}
+		if p.Start.Before(dayEnd) && (p.End == nil || p.End.After(dayStart)) {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchCustomers 搜索客户。limit 不合法时使用默认值，并且不超过 maxCustomerSearchLimit。
+func (s *customerService) SearchCustomers(q string, limit int) ([]repository.CustomerMatch, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, errors.New("search keyword is required")
+	}
+	if limit <= 0 {
+		limit = defaultCustomerSearchLimit
+	}
+	if limit > maxCustomerSearchLimit {
+		limit = maxCustomerSearchLimit
+	}
+	return s.customerRepo.Search(q, limit)
+}
@@ -0,0 +1,372 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCustomerService_CreateCustomer(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockAppRepo := new(mocks.ApplicationRepository)
+	mockDictRepo := new(mocks.DictionaryRepository)
+	customerService := NewCustomerService(nil, mockCustomerRepo, mockAppRepo, mockDictRepo)
+
+	t.Run("success", func(t *testing.T) {
+		mockDictRepo.On("GetByCode", "industry", "Tech").Return(&core.DictionaryEntry{Code: "Tech"}, nil).Once()
+		mockDictRepo.On("GetByCode", "region", "East").Return(&core.DictionaryEntry{Code: "East"}, nil).Once()
+		mockCustomerRepo.On("GetByName", "Acme").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetDeletedByName", "Acme").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("Create", mock.MatchedBy(func(c *core.Customer) bool {
+			return c.Name == "Acme" && c.LatestExtGrade == ""
+		})).Return(nil).Once()
+
+		customer, err := customerService.CreateCustomer("Acme", "", "Tech", "East")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Acme", customer.Name)
+		assert.False(t, customer.IsDefault)
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("name already exists", func(t *testing.T) {
+		mockCustomerRepo.On("GetByName", "Acme").Return(&core.Customer{Name: "Acme"}, nil).Once()
+
+		_, err := customerService.CreateCustomer("Acme", "", "", "")
+
+		assert.EqualError(t, err, "customer name already exists")
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("soft-deleted customer with the same name is restored", func(t *testing.T) {
+		deleted := &core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "Acme", Industry: "Tech"}
+		mockDictRepo.On("GetByCode", "region", "East").Return(&core.DictionaryEntry{Code: "East"}, nil).Once()
+		mockCustomerRepo.On("GetByCreditCode", "91350100M000100Y43").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetByName", "Acme").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetDeletedByName", "Acme").Return(deleted, nil).Once()
+		mockCustomerRepo.On("GetByAlias", "Acme").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetDeletedByCreditCode", "91350100M000100Y43").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("Restore", deleted, "Region", "CreditCode").Return(nil).Once()
+
+		customer, err := customerService.CreateCustomer("Acme", "91350100M000100Y43", "", "East")
+
+		assert.NoError(t, err)
+		assert.Same(t, deleted, customer)
+		assert.Equal(t, "Tech", customer.Industry)
+		assert.Equal(t, "East", customer.Region)
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("merged customer is not restored", func(t *testing.T) {
+		mockCustomerRepo.On("GetByName", "Acme").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetDeletedByName", "Acme").Return(&core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "Acme"}, nil).Once()
+		mockCustomerRepo.On("GetByAlias", "Acme").Return(&core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "Acme Holdings"}, nil).Once()
+
+		_, err := customerService.CreateCustomer("Acme", "", "", "")
+
+		assert.EqualError(t, err, "customer was merged into another customer")
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("credit code held by another soft-deleted customer", func(t *testing.T) {
+		mockCustomerRepo.On("GetByCreditCode", "91350100M000100Y43").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetByName", "Acme").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetDeletedByName", "Acme").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetDeletedByCreditCode", "91350100M000100Y43").Return(&core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "Globex"}, nil).Once()
+
+		_, err := customerService.CreateCustomer("Acme", "91350100M000100Y43", "", "")
+
+		assert.EqualError(t, err, "credit code belongs to a deleted customer")
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid credit code", func(t *testing.T) {
+		_, err := customerService.CreateCustomer("Acme", "91350100M000100Y44", "", "")
+
+		assert.EqualError(t, err, "invalid unified social credit code")
+	})
+
+	t.Run("credit code already exists", func(t *testing.T) {
+		mockCustomerRepo.On("GetByCreditCode", "91350100M000100Y43").Return(&core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}}, nil).Once()
+
+		_, err := customerService.CreateCustomer("Acme", "91350100m000100y43", "", "")
+
+		assert.EqualError(t, err, "credit code already exists")
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown industry code", func(t *testing.T) {
+		mockDictRepo.On("GetByCode", "industry", "Technology").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := customerService.CreateCustomer("Acme", "", "Technology", "")
+
+		assert.ErrorIs(t, err, ErrUnknownDictionaryCode)
+		assert.EqualError(t, err, "industry code is not defined in the dictionary")
+		mockDictRepo.AssertExpectations(t)
+	})
+}
+
+func TestCustomerService_UpdateCustomer(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockAppRepo := new(mocks.ApplicationRepository)
+	mockDictRepo := new(mocks.DictionaryRepository)
+	customerService := NewCustomerService(nil, mockCustomerRepo, mockAppRepo, mockDictRepo)
+	id := uuid.New()
+
+	t.Run("only provided fields are updated", func(t *testing.T) {
+		existing := &core.Customer{BaseModel: core.BaseModel{ID: id}, Name: "Acme", Industry: "Tech"}
+		region := "West"
+		mockCustomerRepo.On("GetByID", id).Return(existing, nil).Once()
+		mockDictRepo.On("GetByCode", "region", "West").Return(&core.DictionaryEntry{Code: "West"}, nil).Once()
+		mockCustomerRepo.On("Update", existing, "Region").Return(nil).Once()
+
+		customer, err := customerService.UpdateCustomer(id, nil, nil, nil, &region)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "West", customer.Region)
+		assert.Equal(t, "Tech", customer.Industry)
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("rename to a taken name", func(t *testing.T) {
+		existing := &core.Customer{BaseModel: core.BaseModel{ID: id}, Name: "Acme"}
+		other := &core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "Globex"}
+		name := "Globex"
+		mockCustomerRepo.On("GetByID", id).Return(existing, nil).Once()
+		mockCustomerRepo.On("GetByName", name).Return(other, nil).Once()
+
+		_, err := customerService.UpdateCustomer(id, &name, nil, nil, nil)
+
+		assert.EqualError(t, err, "customer name already exists")
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("rename to the name of a soft-deleted customer", func(t *testing.T) {
+		existing := &core.Customer{BaseModel: core.BaseModel{ID: id}, Name: "Acme"}
+		name := "Globex"
+		mockCustomerRepo.On("GetByID", id).Return(existing, nil).Once()
+		mockCustomerRepo.On("GetByName", name).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetDeletedByName", name).Return(&core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: name}, nil).Once()
+
+		_, err := customerService.UpdateCustomer(id, &name, nil, nil, nil)
+
+		assert.EqualError(t, err, "customer name belongs to a deleted customer")
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockCustomerRepo.On("GetByID", id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := customerService.UpdateCustomer(id, nil, nil, nil, nil)
+
+		assert.EqualError(t, err, "customer not found")
+		mockCustomerRepo.AssertExpectations(t)
+	})
+}
+
+func TestCustomerService_DeleteCustomer(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockAppRepo := new(mocks.ApplicationRepository)
+	mockDictRepo := new(mocks.DictionaryRepository)
+	customerService := NewCustomerService(nil, mockCustomerRepo, mockAppRepo, mockDictRepo)
+	id := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockCustomerRepo.On("GetByID", id).Return(&core.Customer{BaseModel: core.BaseModel{ID: id}}, nil).Once()
+		mockAppRepo.On("FindPendingByCustomerID", id).Return(nil, nil).Once()
+		mockCustomerRepo.On("Delete", id).Return(nil).Once()
+
+		err := customerService.DeleteCustomer(id)
+
+		assert.NoError(t, err)
+		mockCustomerRepo.AssertExpectations(t)
+		mockAppRepo.AssertExpectations(t)
+	})
+
+	t.Run("customer in default", func(t *testing.T) {
+		mockCustomerRepo.On("GetByID", id).Return(&core.Customer{BaseModel: core.BaseModel{ID: id}, IsDefault: true}, nil).Once()
+
+		err := customerService.DeleteCustomer(id)
+
+		assert.EqualError(t, err, "customer is in default status")
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("pending application blocks deletion", func(t *testing.T) {
+		mockCustomerRepo.On("GetByID", id).Return(&core.Customer{BaseModel: core.BaseModel{ID: id}}, nil).Once()
+		mockAppRepo.On("FindPendingByCustomerID", id).Return(&core.DefaultApplication{}, nil).Once()
+
+		err := customerService.DeleteCustomer(id)
+
+		assert.EqualError(t, err, "there is a pending application for this customer")
+		mockAppRepo.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		dbErr := errors.New("db error")
+		mockCustomerRepo.On("GetByID", id).Return(nil, dbErr).Once()
+
+		err := customerService.DeleteCustomer(id)
+
+		assert.Equal(t, dbErr, err)
+	})
+}
+
+func TestParseCustomerCSV(t *testing.T) {
+	t.Run("valid and rejected rows", func(t *testing.T) {
+		input := "\ufeffName,Industry,Region,LatestExtGrade\n" +
+			"Acme,Tech,East,AA\n" +
+			",Tech,East,AA\n" +
+			"Acme,Retail,West,\n" +
+			"Globex\n"
+
+		rows, rejected, err := parseCustomerCSV(strings.NewReader(input))
+
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		// LatestExtGrade 列被忽略，最新外部评级只能由评级录入派生
+		assert.Equal(t, customerImportRow{line: 2, name: "Acme", industry: "Tech", region: "East"}, rows[0])
+		assert.Equal(t, "Globex", rows[1].name)
+		assert.Len(t, rejected, 2)
+		assert.Equal(t, 3, rejected[0].Row)
+		assert.Equal(t, "name is required", rejected[0].Reason)
+		assert.Equal(t, 4, rejected[1].Row)
+		assert.Equal(t, "duplicate name in file (first seen at row 2)", rejected[1].Reason)
+	})
+
+	t.Run("missing name column", func(t *testing.T) {
+		_, _, err := parseCustomerCSV(strings.NewReader("Industry,Region\nTech,East\n"))
+
+		assert.ErrorIs(t, err, ErrInvalidCSV)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		_, _, err := parseCustomerCSV(strings.NewReader(""))
+
+		assert.ErrorIs(t, err, ErrInvalidCSV)
+	})
+}
+
+func TestUpsertCustomer(t *testing.T) {
+	code := "91350100M000100Y43"
+
+	t.Run("new customer with a taken credit code", func(t *testing.T) {
+		mockCustomerRepo := new(mocks.CustomerRepository)
+		mockCustomerRepo.On("GetByName", "Acme").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetByCreditCode", code).Return(&core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}}, nil).Once()
+
+		_, err := upsertCustomer(mockCustomerRepo, customerImportRow{line: 2, name: "Acme", creditCode: code})
+
+		assert.EqualError(t, err, "credit code already exists")
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("existing customer gets a missing credit code", func(t *testing.T) {
+		mockCustomerRepo := new(mocks.CustomerRepository)
+		existing := &core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "Acme"}
+		mockCustomerRepo.On("GetByName", "Acme").Return(existing, nil).Once()
+		mockCustomerRepo.On("GetByCreditCode", code).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetDeletedByCreditCode", code).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("Update", existing, "CreditCode").Return(nil).Once()
+
+		status, err := upsertCustomer(mockCustomerRepo, customerImportRow{line: 2, name: "Acme", creditCode: code})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "updated", status)
+		assert.Equal(t, code, *existing.CreditCode)
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("existing customer with a credit code held by a deleted customer", func(t *testing.T) {
+		mockCustomerRepo := new(mocks.CustomerRepository)
+		existing := &core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "Acme"}
+		mockCustomerRepo.On("GetByName", "Acme").Return(existing, nil).Once()
+		mockCustomerRepo.On("GetByCreditCode", code).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCustomerRepo.On("GetDeletedByCreditCode", code).Return(&core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}}, nil).Once()
+
+		_, err := upsertCustomer(mockCustomerRepo, customerImportRow{line: 2, name: "Acme", creditCode: code})
+
+		assert.EqualError(t, err, "credit code belongs to a deleted customer")
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("existing credit code is not overwritten", func(t *testing.T) {
+		mockCustomerRepo := new(mocks.CustomerRepository)
+		other := "91350100M000100Y44"
+		existing := &core.Customer{BaseModel: core.BaseModel{ID: uuid.New()}, Name: "Acme", CreditCode: &other}
+		mockCustomerRepo.On("GetByName", "Acme").Return(existing, nil).Once()
+
+		_, err := upsertCustomer(mockCustomerRepo, customerImportRow{line: 2, name: "Acme", region: "East", creditCode: code})
+
+		assert.EqualError(t, err, "credit code does not match the existing customer")
+		assert.Equal(t, other, *existing.CreditCode)
+		mockCustomerRepo.AssertExpectations(t)
+	})
+}
+
+func TestBuildDefaultTimeline(t *testing.T) {
+	day := func(d int) *time.Time {
+		tm := time.Date(2024, 1, d, 10, 0, 0, 0, time.UTC)
+		return &tm
+	}
+	approver := &core.User{Username: "approver"}
+	first := core.DefaultApplication{
+		BaseModel:           core.BaseModel{ID: uuid.New()},
+		Status:              "Reborn",
+		ApprovalTime:        day(5),
+		Approver:            approver,
+		RebirthApprovalTime: day(10),
+		RebirthReason:       "已结清",
+	}
+	second := core.DefaultApplication{
+		BaseModel:    core.BaseModel{ID: uuid.New()},
+		Status:       "Approved",
+		ApprovalTime: day(20),
+	}
+	rejected := core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: "Rejected", ApprovalTime: day(2)}
+
+	periods := buildDefaultTimeline([]core.DefaultApplication{second, rejected, first})
+
+	assert.Len(t, periods, 3)
+	assert.Equal(t, "Default", periods[0].Type)
+	assert.Equal(t, "approver", periods[0].ApproverName)
+	assert.Equal(t, day(10), periods[0].End)
+	assert.Equal(t, "Reborn", periods[1].Type)
+	assert.Equal(t, day(20), periods[1].End)
+	assert.Equal(t, "Default", periods[2].Type)
+	assert.Nil(t, periods[2].End)
+
+	assert.False(t, inDefaultOn(periods, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, inDefaultOn(periods, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, inDefaultOn(periods, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, inDefaultOn(periods, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCustomerService_SearchCustomers(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	customerService := NewCustomerService(nil, mockCustomerRepo, new(mocks.ApplicationRepository), new(mocks.DictionaryRepository))
+
+	t.Run("limit is capped", func(t *testing.T) {
+		mockCustomerRepo.On("Search", "Acme", maxCustomerSearchLimit).Return([]repository.CustomerMatch{}, nil).Once()
+
+		_, err := customerService.SearchCustomers("  Acme ", 1000)
+
+		assert.NoError(t, err)
+		mockCustomerRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty keyword", func(t *testing.T) {
+		_, err := customerService.SearchCustomers("   ", 10)
+
+		assert.EqualError(t, err, "search keyword is required")
+	})
+}
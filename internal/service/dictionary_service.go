@@ -0,0 +1,157 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// dictionaryKinds 是系统支持的字典类型。字典类型同时也是客户表中对应的列名。
+var dictionaryKinds = map[string]bool{
+	"industry": true,
+	"region":   true,
+}
+
+// ErrUnknownDictionaryCode 表示客户数据中的行业或区域编码在对应字典中不存在。
+var ErrUnknownDictionaryCode = errors.New("code is not defined in the dictionary")
+
+// DictionaryService 定义了行业、区域参考数据字典的维护接口。
+type DictionaryService interface {
+	ListEntries(kind string) ([]core.DictionaryEntry, error)
+	// CreateEntry 新增一个字典条目，parentCode 为空表示顶层条目。
+	CreateEntry(kind, code, name, parentCode string) (*core.DictionaryEntry, error)
+	// UpdateEntry 修改条目名称。编码和上级关系一旦建立不允许修改，以免历史统计口径发生漂移。
+	UpdateEntry(kind, code, name string) (*core.DictionaryEntry, error)
+	DeleteEntry(kind, code string) error
+}
+
+type dictionaryService struct {
+	dictRepo repository.DictionaryRepository
+}
+
+// NewDictionaryService 是 dictionaryService 的构造函数。
+func NewDictionaryService(dictRepo repository.DictionaryRepository) DictionaryService {
+	return &dictionaryService{dictRepo: dictRepo}
+}
+
+// ListEntries 查询某个字典的全部条目
+func (s *dictionaryService) ListEntries(kind string) ([]core.DictionaryEntry, error) {
+	if !dictionaryKinds[kind] {
+		return nil, errors.New("unknown dictionary kind")
+	}
+	return s.dictRepo.FindByKind(kind)
+}
+
+// CreateEntry 新增字典条目，层级由上级条目推导
+func (s *dictionaryService) CreateEntry(kind, code, name, parentCode string) (*core.DictionaryEntry, error) {
+	if !dictionaryKinds[kind] {
+		return nil, errors.New("unknown dictionary kind")
+	}
+
+	_, err := s.dictRepo.GetByCode(kind, code)
+	if err == nil {
+		return nil, errors.New("dictionary code already exists")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	entry := &core.DictionaryEntry{Kind: kind, Code: code, Name: name, Level: 1}
+	if parentCode != "" {
+		parent, err := s.dictRepo.GetByCode(kind, parentCode)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("parent dictionary entry not found")
+			}
+			return nil, err
+		}
+		entry.ParentCode = parent.Code
+		entry.Level = parent.Level + 1
+	}
+
+	if err := s.dictRepo.Create(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// UpdateEntry 修改条目名称
+func (s *dictionaryService) UpdateEntry(kind, code, name string) (*core.DictionaryEntry, error) {
+	entry, err := s.getEntry(kind, code)
+	if err != nil {
+		return nil, err
+	}
+	entry.Name = name
+	if err := s.dictRepo.Update(entry, "Name"); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// DeleteEntry 删除字典条目。
+// 业务规则：仍有下级条目或仍被客户引用的条目不能删除，否则会留下无法归类的数据。
+func (s *dictionaryService) DeleteEntry(kind, code string) error {
+	entry, err := s.getEntry(kind, code)
+	if err != nil {
+		return err
+	}
+
+	children, err := s.dictRepo.CountChildren(kind, code)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return errors.New("dictionary entry has child entries")
+	}
+
+	customers, err := s.dictRepo.CountCustomers(kind, code)
+	if err != nil {
+		return err
+	}
+	if customers > 0 {
+		return errors.New("dictionary entry is in use by customers")
+	}
+
+	return s.dictRepo.Delete(entry)
+}
+
+// getEntry 查询字典条目，并将 "记录不存在" 转换为业务错误
+func (s *dictionaryService) getEntry(kind, code string) (*core.DictionaryEntry, error) {
+	if !dictionaryKinds[kind] {
+		return nil, errors.New("unknown dictionary kind")
+	}
+	entry, err := s.dictRepo.GetByCode(kind, code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("dictionary entry not found")
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// validateDictionaryCode 校验客户数据中的编码在对应字典中存在。空值表示未填写，不做校验。
+func validateDictionaryCode(dictRepo repository.DictionaryRepository, kind, code string) error {
+	if code == "" {
+		return nil
+	}
+	_, err := dictRepo.GetByCode(kind, code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%s %w", kind, ErrUnknownDictionaryCode)
+	}
+	return err
+}
+
+// rollUpCode 沿上级关系把编码汇总到指定层级。
+// 编码本身的层级不高于 level、或编码不在字典中 (例如历史遗留的自由文本) 时原样返回。
+func rollUpCode(entries map[string]core.DictionaryEntry, code string, level int) string {
+	entry, ok := entries[code]
+	for ok && entry.Level > level && entry.ParentCode != "" {
+		code = entry.ParentCode
+		entry, ok = entries[code]
+	}
+	return code
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestDictionaryService_CreateEntry(t *testing.T) {
+	mockDictRepo := new(mocks.DictionaryRepository)
+	dictService := NewDictionaryService(mockDictRepo)
+
+	t.Run("level is derived from parent", func(t *testing.T) {
+		mockDictRepo.On("GetByCode", "industry", "C39").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockDictRepo.On("GetByCode", "industry", "C").Return(&core.DictionaryEntry{Code: "C", Level: 1}, nil).Once()
+		mockDictRepo.On("Create", mock.AnythingOfType("*core.DictionaryEntry")).Return(nil).Once()
+
+		entry, err := dictService.CreateEntry("industry", "C39", "计算机、通信和其他电子设备制造业", "C")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, entry.Level)
+		assert.Equal(t, "C", entry.ParentCode)
+		mockDictRepo.AssertExpectations(t)
+	})
+
+	t.Run("parent not found", func(t *testing.T) {
+		mockDictRepo.On("GetByCode", "region", "320100").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockDictRepo.On("GetByCode", "region", "320000").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := dictService.CreateEntry("region", "320100", "南京市", "320000")
+
+		assert.EqualError(t, err, "parent dictionary entry not found")
+		mockDictRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		_, err := dictService.CreateEntry("currency", "CNY", "人民币", "")
+
+		assert.EqualError(t, err, "unknown dictionary kind")
+	})
+}
+
+func TestRollUpCode(t *testing.T) {
+	entries := map[string]core.DictionaryEntry{
+		"C":    {Code: "C", Level: 1},
+		"C39":  {Code: "C39", ParentCode: "C", Level: 2},
+		"C391": {Code: "C391", ParentCode: "C39", Level: 3},
+	}
+
+	assert.Equal(t, "C", rollUpCode(entries, "C391", 1))
+	assert.Equal(t, "C39", rollUpCode(entries, "C391", 2))
+	assert.Equal(t, "C39", rollUpCode(entries, "C39", 3))
+	assert.Equal(t, "Tech", rollUpCode(entries, "Tech", 1), "编码不在字典中时原样保留")
+}
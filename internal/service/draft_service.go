@@ -0,0 +1,196 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrIncompleteDraft 表示草稿还缺少提交申请必须填写的内容。
+var ErrIncompleteDraft = errors.New("draft is incomplete")
+
+// DraftInput 是草稿的全部内容。自动保存每次提交整个表单，未填写的字段为空。
+type DraftInput struct {
+	Customer   CustomerRef
+	Severity   string
+	ReasonCode string
+	Reason     string
+	Remarks    string
+}
+
+// DraftService 定义了违约申请草稿相关的业务操作接口。草稿只对申请人本人可见。
+type DraftService interface {
+	CreateDraft(input DraftInput, actor Actor) (*core.ApplicationDraft, error)
+	// UpdateDraft 用 input 覆盖草稿的内容 (自动保存)。已提交的草稿不能修改。
+	UpdateDraft(id uuid.UUID, input DraftInput, actor Actor) (*core.ApplicationDraft, error)
+	GetDraft(id uuid.UUID, actor Actor) (*core.ApplicationDraft, error)
+	// ListDrafts 查询 actor 尚未提交的草稿，最近保存的在前。
+	ListDrafts(actor Actor) ([]core.ApplicationDraft, error)
+	DeleteDraft(id uuid.UUID, actor Actor) error
+	// SubmitDraft 提交草稿：按新申请的全部业务规则校验，通过后在同一个事务中创建待审核的申请并把草稿标记为已提交。
+	SubmitDraft(id uuid.UUID, actor Actor) (*core.DefaultApplication, error)
+}
+
+type draftService struct {
+	db        *gorm.DB // 用于提交草稿时同时创建申请和更新草稿的事务
+	draftRepo repository.DraftRepository
+}
+
+// NewDraftService 是 draftService 的构造函数。
+func NewDraftService(db *gorm.DB, draftRepo repository.DraftRepository) DraftService {
+	return &draftService{db: db, draftRepo: draftRepo}
+}
+
+// CreateDraft 新建草稿
+func (s *draftService) CreateDraft(input DraftInput, actor Actor) (*core.ApplicationDraft, error) {
+	draft := &core.ApplicationDraft{ApplicantID: actor.ID}
+	applyDraftInput(draft, input)
+	if err := s.draftRepo.Create(draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// UpdateDraft 自动保存草稿
+func (s *draftService) UpdateDraft(id uuid.UUID, input DraftInput, actor Actor) (*core.ApplicationDraft, error) {
+	draft, err := getOpenDraft(s.draftRepo, id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	applyDraftInput(draft, input)
+	if err := s.draftRepo.Update(draft, "CustomerID", "CustomerCreditCode", "CustomerName", "Severity", "DefaultReasonCode", "DefaultReason", "Remarks"); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// GetDraft 查询一个草稿 (包括已提交的草稿，便于找到它创建的申请)
+func (s *draftService) GetDraft(id uuid.UUID, actor Actor) (*core.ApplicationDraft, error) {
+	return getOwnDraft(s.draftRepo, id, actor)
+}
+
+// ListDrafts 查询尚未提交的草稿
+func (s *draftService) ListDrafts(actor Actor) ([]core.ApplicationDraft, error) {
+	return s.draftRepo.FindOpenByApplicantID(actor.ID)
+}
+
+// DeleteDraft 放弃草稿
+func (s *draftService) DeleteDraft(id uuid.UUID, actor Actor) error {
+	draft, err := getOpenDraft(s.draftRepo, id, actor)
+	if err != nil {
+		return err
+	}
+	return s.draftRepo.Delete(draft)
+}
+
+// SubmitDraft 提交草稿。
+// 业务规则：草稿必须填写完整；客户存在、客户尚未违约、客户没有待处理申请、违约原因有效等校验与直接提交申请完全相同。
+func (s *draftService) SubmitDraft(id uuid.UUID, actor Actor) (*core.DefaultApplication, error) {
+	var app *core.DefaultApplication
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txDraftRepo := repository.NewDraftRepository(tx)
+		draft, err := getOpenDraft(txDraftRepo, id, actor)
+		if err != nil {
+			return err
+		}
+		if missing := DraftMissingFields(draft); len(missing) > 0 {
+			return fmt.Errorf("%w: missing %s", ErrIncompleteDraft, strings.Join(missing, ", "))
+		}
+
+		app, err = createApplication(repository.NewApplicationRepository(tx), repository.NewCustomerRepository(tx, repository.CustomerSearchOptions{}),
+			repository.NewRoutingRepository(tx), repository.NewReasonRepository(tx),
+			draftCustomerRef(draft), draft.Severity, draft.DefaultReasonCode, draft.DefaultReason, draft.Remarks, actor.ID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		draft.SubmittedApplicationID = &app.ID
+		draft.SubmittedAt = &now
+		if err := txDraftRepo.MarkSubmitted(draft); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// 并发的另一个请求已经提交了这个草稿，回滚本次创建的申请
+				return errors.New("draft has already been submitted")
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// DraftMissingFields 返回草稿提交前还必须填写的字段 (使用请求体中的字段名)，为空表示可以提交。
+func DraftMissingFields(draft *core.ApplicationDraft) []string {
+	var missing []string
+	if draft.CustomerID == nil && draft.CustomerCreditCode == "" && draft.CustomerName == "" {
+		missing = append(missing, "customer")
+	}
+	if draft.Severity == "" {
+		missing = append(missing, "severity")
+	}
+	if draft.DefaultReasonCode == "" {
+		missing = append(missing, "reason_code")
+	}
+	return missing
+}
+
+// getOwnDraft 查询 actor 自己的草稿。别人的草稿与不存在的草稿一样返回 "draft not found"。
+func getOwnDraft(draftRepo repository.DraftRepository, id uuid.UUID, actor Actor) (*core.ApplicationDraft, error) {
+	draft, err := draftRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("draft not found")
+		}
+		return nil, err
+	}
+	if draft.ApplicantID != actor.ID {
+		return nil, errors.New("draft not found")
+	}
+	return draft, nil
+}
+
+// getOpenDraft 查询 actor 自己尚未提交的草稿
+func getOpenDraft(draftRepo repository.DraftRepository, id uuid.UUID, actor Actor) (*core.ApplicationDraft, error) {
+	draft, err := getOwnDraft(draftRepo, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if draft.SubmittedAt != nil {
+		return nil, errors.New("draft has already been submitted")
+	}
+	return draft, nil
+}
+
+// applyDraftInput 把输入内容写入草稿
+func applyDraftInput(draft *core.ApplicationDraft, input DraftInput) {
+	draft.CustomerID = nil
+	if input.Customer.ID != uuid.Nil {
+		customerID := input.Customer.ID
+		draft.CustomerID = &customerID
+	}
+	draft.CustomerCreditCode = input.Customer.CreditCode
+	draft.CustomerName = input.Customer.Name
+	draft.Severity = input.Severity
+	draft.DefaultReasonCode = input.ReasonCode
+	draft.DefaultReason = input.Reason
+	draft.Remarks = input.Remarks
+}
+
+// draftCustomerRef 把草稿记录的客户转换为 CustomerRef
+func draftCustomerRef(draft *core.ApplicationDraft) CustomerRef {
+	ref := CustomerRef{CreditCode: draft.CustomerCreditCode, Name: draft.CustomerName}
+	if draft.CustomerID != nil {
+		ref.ID = *draft.CustomerID
+	}
+	return ref
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestDraftService_UpdateDraft(t *testing.T) {
+	mockDraftRepo := new(mocks.DraftRepository)
+	draftService := NewDraftService(nil, mockDraftRepo)
+	applicant := Actor{ID: uuid.New(), Role: core.RoleApplicant}
+	customerID := uuid.New()
+
+	t.Run("autosave replaces the whole form", func(t *testing.T) {
+		draft := &core.ApplicationDraft{BaseModel: core.BaseModel{ID: uuid.New()}, ApplicantID: applicant.ID, CustomerName: "旧名称", Remarks: "草稿"}
+		mockDraftRepo.On("GetByID", draft.ID).Return(draft, nil).Once()
+		mockDraftRepo.On("Update", draft, "CustomerID", "CustomerCreditCode", "CustomerName", "Severity", "DefaultReasonCode", "DefaultReason", "Remarks").Return(nil).Once()
+
+		updated, err := draftService.UpdateDraft(draft.ID, DraftInput{Customer: CustomerRef{ID: customerID}, Severity: "High"}, applicant)
+
+		assert.NoError(t, err)
+		assert.Equal(t, customerID, *updated.CustomerID)
+		assert.Empty(t, updated.CustomerName)
+		assert.Empty(t, updated.Remarks)
+		assert.Equal(t, []string{"reason_code"}, DraftMissingFields(updated))
+		mockDraftRepo.AssertExpectations(t)
+	})
+
+	t.Run("drafts of other applicants are hidden", func(t *testing.T) {
+		draft := &core.ApplicationDraft{BaseModel: core.BaseModel{ID: uuid.New()}, ApplicantID: uuid.New()}
+		mockDraftRepo.On("GetByID", draft.ID).Return(draft, nil).Once()
+
+		_, err := draftService.UpdateDraft(draft.ID, DraftInput{}, applicant)
+
+		assert.EqualError(t, err, "draft not found")
+		mockDraftRepo.AssertExpectations(t)
+	})
+
+	t.Run("submitted drafts cannot be changed", func(t *testing.T) {
+		submittedAt := time.Now()
+		draft := &core.ApplicationDraft{BaseModel: core.BaseModel{ID: uuid.New()}, ApplicantID: applicant.ID, SubmittedAt: &submittedAt}
+		mockDraftRepo.On("GetByID", draft.ID).Return(draft, nil).Once()
+
+		_, err := draftService.UpdateDraft(draft.ID, DraftInput{Severity: "Low"}, applicant)
+
+		assert.EqualError(t, err, "draft has already been submitted")
+		mockDraftRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		id := uuid.New()
+		mockDraftRepo.On("GetByID", id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := draftService.UpdateDraft(id, DraftInput{}, applicant)
+
+		assert.EqualError(t, err, "draft not found")
+		mockDraftRepo.AssertExpectations(t)
+	})
+}
+
+func TestDraftService_CreateDraft(t *testing.T) {
+	mockDraftRepo := new(mocks.DraftRepository)
+	draftService := NewDraftService(nil, mockDraftRepo)
+	applicant := Actor{ID: uuid.New(), Role: core.RoleApplicant}
+
+	mockDraftRepo.On("Create", mock.MatchedBy(func(d *core.ApplicationDraft) bool {
+		return d.ApplicantID == applicant.ID && d.CustomerID == nil && d.CustomerCreditCode == "91310000MA1FL8XQ30" && d.DefaultReason == "逾期说明写了一半"
+	})).Return(nil).Once()
+
+	draft, err := draftService.CreateDraft(DraftInput{Customer: CustomerRef{CreditCode: "91310000MA1FL8XQ30"}, Reason: "逾期说明写了一半"}, applicant)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"severity", "reason_code"}, DraftMissingFields(draft))
+	mockDraftRepo.AssertExpectations(t)
+}
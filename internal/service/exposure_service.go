@@ -0,0 +1,67 @@
+package service
+
+import (
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// ExposureService 定义了客户信用敞口相关的业务操作接口。
+type ExposureService interface {
+	RecordExposure(customerID uuid.UUID, principal, interest float64, currency string, asOfDate time.Time) (*core.Exposure, error)
+	GetExposures(customerID uuid.UUID) ([]core.Exposure, error)
+}
+
+type exposureService struct {
+	exposureRepo repository.ExposureRepository
+	customerRepo repository.CustomerRepository
+}
+
+// NewExposureService 是 exposureService 的构造函数。
+func NewExposureService(exposureRepo repository.ExposureRepository, customerRepo repository.CustomerRepository) ExposureService {
+	return &exposureService{exposureRepo: exposureRepo, customerRepo: customerRepo}
+}
+
+// RecordExposure 为客户追加一条敞口记录
+func (s *exposureService) RecordExposure(customerID uuid.UUID, principal, interest float64, currency string, asOfDate time.Time) (*core.Exposure, error) {
+	if _, err := getCustomer(s.customerRepo, customerID); err != nil {
+		return nil, err
+	}
+
+	exposure := &core.Exposure{
+		CustomerID: customerID,
+		Principal:  principal,
+		Interest:   interest,
+		Currency:   currency,
+		AsOfDate:   asOfDate,
+	}
+	if err := s.exposureRepo.Create(exposure); err != nil {
+		return nil, err
+	}
+	return exposure, nil
+}
+
+// GetExposures 查询客户的敞口历史
+func (s *exposureService) GetExposures(customerID uuid.UUID) ([]core.Exposure, error) {
+	if _, err := getCustomer(s.customerRepo, customerID); err != nil {
+		return nil, err
+	}
+	return s.exposureRepo.FindByCustomerID(customerID)
+}
+
+// snapshotExposure 将客户最新的敞口记录拷贝到申请上，客户没有敞口记录时不做任何修改。
+// 返回需要随申请一起更新的字段。
+func snapshotExposure(exposureRepo repository.ExposureRepository, app *core.DefaultApplication) ([]string, error) {
+	exposure, err := exposureRepo.FindLatestByCustomerID(app.CustomerID)
+	if err != nil || exposure == nil {
+		return nil, err
+	}
+	principal, interest, asOfDate := exposure.Principal, exposure.Interest, exposure.AsOfDate
+	app.ExposurePrincipal = &principal
+	app.ExposureInterest = &interest
+	app.ExposureCurrency = exposure.Currency
+	app.ExposureAsOfDate = &asOfDate
+	return []string{"exposure_principal", "exposure_interest", "exposure_currency", "exposure_as_of_date"}, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Idempotency-Key 相关的业务错误
+var (
+	// ErrIdempotencyKeyReused 表示同一个 Key 已经用于内容不同的另一个请求。
+	ErrIdempotencyKeyReused = errors.New("idempotency key has already been used for a different request")
+	// ErrIdempotencyKeyInProgress 表示使用同一个 Key 的请求仍在处理中 (客户端在第一次请求返回前就重试了)。
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+// IdempotencyResponse 是为重试保存的响应。
+type IdempotencyResponse struct {
+	StatusCode  int
+	ContentType string
+	ETag        string
+	Body        []byte
+}
+
+// IdempotencyService 定义了 Idempotency-Key 的登记、保存响应和过期清理接口。
+type IdempotencyService interface {
+	// Begin 登记一个带 Key 的请求，requestHash 是请求内容的摘要。
+	// 返回的记录已完成 (CompletedAt 不为空) 时调用方直接重放保存的响应；
+	// 否则由调用方执行请求，之后调用 Complete 保存响应，或调用 Release 放弃。
+	Begin(userID uuid.UUID, key, requestHash string) (*core.IdempotencyRecord, error)
+	Complete(record *core.IdempotencyRecord, response IdempotencyResponse) error
+	// Release 删除处理中的记录 (请求没有产生确定的结果时)，客户端可以用同一个 Key 重试。
+	Release(record *core.IdempotencyRecord) error
+	// Run 启动过期记录的清理：立即清理一次，之后每隔 interval 清理一次，直到 ctx 结束。
+	Run(ctx context.Context, interval time.Duration)
+	// PurgeExpired 删除在 now 之前已经过期的记录，返回删除的数量。
+	PurgeExpired(now time.Time) (int64, error)
+}
+
+type idempotencyService struct {
+	idempotencyRepo repository.IdempotencyRepository
+	ttl             time.Duration
+}
+
+// NewIdempotencyService 是 idempotencyService 的构造函数，ttl 是 Key 的保留时间。
+func NewIdempotencyService(idempotencyRepo repository.IdempotencyRepository, ttl time.Duration) IdempotencyService {
+	return &idempotencyService{idempotencyRepo: idempotencyRepo, ttl: ttl}
+}
+
+// Begin 登记请求。
+// 业务规则：
+//  1. 未使用过 (或已过期) 的 Key 登记为处理中，由本次请求执行；
+//  2. 同一个 Key 用于内容不同的请求时拒绝，不论第一次请求是否已完成；
+//  3. 第一次请求仍在处理中时拒绝，已完成时返回保存的响应。
+func (s *idempotencyService) Begin(userID uuid.UUID, key, requestHash string) (*core.IdempotencyRecord, error) {
+	now := time.Now()
+	// 与并发的请求竞争同一个 Key 时最多重试一次：对方删除了过期记录，或者放弃了处理中的记录
+	for attempt := 0; attempt < 2; attempt++ {
+		record := &core.IdempotencyRecord{UserID: userID, Key: key, RequestHash: requestHash, ExpiresAt: now.Add(s.ttl)}
+		created, err := s.idempotencyRepo.Create(record)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			return record, nil
+		}
+
+		existing, err := s.idempotencyRepo.GetByKey(userID, key)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !existing.ExpiresAt.After(now) {
+			// 过期的 Key 可以重新使用
+			if err := s.idempotencyRepo.Delete(existing); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if existing.RequestHash != requestHash {
+			return nil, ErrIdempotencyKeyReused
+		}
+		if existing.CompletedAt == nil {
+			return nil, ErrIdempotencyKeyInProgress
+		}
+		return existing, nil
+	}
+	return nil, ErrIdempotencyKeyInProgress
+}
+
+// Complete 保存响应，之后使用同一个 Key 的重试直接得到该响应
+func (s *idempotencyService) Complete(record *core.IdempotencyRecord, response IdempotencyResponse) error {
+	now := time.Now()
+	record.StatusCode = response.StatusCode
+	record.ContentType = response.ContentType
+	record.ETag = response.ETag
+	record.ResponseBody = response.Body
+	record.CompletedAt = &now
+	return s.idempotencyRepo.Complete(record)
+}
+
+// Release 放弃处理中的记录
+func (s *idempotencyService) Release(record *core.IdempotencyRecord) error {
+	return s.idempotencyRepo.Delete(record)
+}
+
+// Run 按固定间隔清理过期记录，单次清理失败只记录日志
+func (s *idempotencyService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.PurgeExpired(time.Now()); err != nil {
+			log.Printf("清理过期的 Idempotency-Key 失败: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeExpired 删除过期记录
+func (s *idempotencyService) PurgeExpired(now time.Time) (int64, error) {
+	return s.idempotencyRepo.DeleteExpired(now)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	userID := uuid.New()
+	ttl := 24 * time.Hour
+
+	t.Run("new key is registered as in progress", func(t *testing.T) {
+		mockRepo := new(mocks.IdempotencyRepository)
+		idempotencyService := NewIdempotencyService(mockRepo, ttl)
+		mockRepo.On("Create", mock.MatchedBy(func(r *core.IdempotencyRecord) bool {
+			return r.UserID == userID && r.Key == "k1" && r.RequestHash == "h1" && r.ExpiresAt.After(time.Now().Add(23*time.Hour))
+		})).Return(true, nil).Once()
+
+		record, err := idempotencyService.Begin(userID, "k1", "h1")
+
+		assert.NoError(t, err)
+		assert.Nil(t, record.CompletedAt)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("completed key returns the saved response", func(t *testing.T) {
+		mockRepo := new(mocks.IdempotencyRepository)
+		idempotencyService := NewIdempotencyService(mockRepo, ttl)
+		completedAt := time.Now().Add(-time.Minute)
+		existing := &core.IdempotencyRecord{UserID: userID, Key: "k1", RequestHash: "h1", StatusCode: 201, CompletedAt: &completedAt, ExpiresAt: time.Now().Add(time.Hour)}
+		mockRepo.On("Create", mock.Anything).Return(false, nil).Once()
+		mockRepo.On("GetByKey", userID, "k1").Return(existing, nil).Once()
+
+		record, err := idempotencyService.Begin(userID, "k1", "h1")
+
+		assert.NoError(t, err)
+		assert.Same(t, existing, record)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("key reused for a different request", func(t *testing.T) {
+		mockRepo := new(mocks.IdempotencyRepository)
+		idempotencyService := NewIdempotencyService(mockRepo, ttl)
+		completedAt := time.Now()
+		existing := &core.IdempotencyRecord{UserID: userID, Key: "k1", RequestHash: "h1", CompletedAt: &completedAt, ExpiresAt: time.Now().Add(time.Hour)}
+		mockRepo.On("Create", mock.Anything).Return(false, nil).Once()
+		mockRepo.On("GetByKey", userID, "k1").Return(existing, nil).Once()
+
+		_, err := idempotencyService.Begin(userID, "k1", "h2")
+
+		assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("first request still in progress", func(t *testing.T) {
+		mockRepo := new(mocks.IdempotencyRepository)
+		idempotencyService := NewIdempotencyService(mockRepo, ttl)
+		existing := &core.IdempotencyRecord{UserID: userID, Key: "k1", RequestHash: "h1", ExpiresAt: time.Now().Add(time.Hour)}
+		mockRepo.On("Create", mock.Anything).Return(false, nil).Once()
+		mockRepo.On("GetByKey", userID, "k1").Return(existing, nil).Once()
+
+		_, err := idempotencyService.Begin(userID, "k1", "h1")
+
+		assert.ErrorIs(t, err, ErrIdempotencyKeyInProgress)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("expired key can be used again", func(t *testing.T) {
+		mockRepo := new(mocks.IdempotencyRepository)
+		idempotencyService := NewIdempotencyService(mockRepo, ttl)
+		completedAt := time.Now().Add(-25 * time.Hour)
+		expired := &core.IdempotencyRecord{UserID: userID, Key: "k1", RequestHash: "h1", CompletedAt: &completedAt, ExpiresAt: time.Now().Add(-time.Hour)}
+		mockRepo.On("Create", mock.Anything).Return(false, nil).Once()
+		mockRepo.On("GetByKey", userID, "k1").Return(expired, nil).Once()
+		mockRepo.On("Delete", expired).Return(nil).Once()
+		mockRepo.On("Create", mock.Anything).Return(true, nil).Once()
+
+		record, err := idempotencyService.Begin(userID, "k1", "h2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "h2", record.RequestHash)
+		assert.Nil(t, record.CompletedAt)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -1,12 +1,19 @@
 package service
 
 import (
+	"errors"
 	"xquant-default-management/internal/core"
 	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type QueryService interface {
-	FindApplications(params repository.QueryParams) ([]core.DefaultApplication, int64, error)
+	// FindApplications 按条件分页查询操作者可以查看的申请。
+	FindApplications(params repository.QueryParams, actor Actor) ([]core.DefaultApplication, int64, error)
+	// GetApplication 查询操作者可以查看的一个申请的完整记录。
+	GetApplication(id uuid.UUID, actor Actor) (*core.DefaultApplication, error)
 }
 
 type queryService struct {
@@ -17,7 +24,32 @@ func NewQueryService(appRepo repository.ApplicationRepository) QueryService {
 	return &queryService{appRepo: appRepo}
 }
 
-func (s *queryService) FindApplications(params repository.QueryParams) ([]core.DefaultApplication, int64, error) {
+// FindApplications 查询申请列表。可见范围与 canViewApplication 一致：
+// 审批人、管理员和系统可以查到全部申请，其他角色 (申请人) 只能查到自己提交或发起重生的申请。
+func (s *queryService) FindApplications(params repository.QueryParams, actor Actor) ([]core.DefaultApplication, int64, error) {
+	switch actor.Role {
+	case core.RoleApprover, core.RoleAdmin, core.RoleSystem:
+		params.VisibleTo = nil
+	default:
+		params.VisibleTo = &actor.ID
+	}
 	// 可以在此层添加缓存等逻辑
 	return s.appRepo.FindAll(params)
 }
+
+// GetApplication 查询申请详情。
+// 可见范围与附件、评论一致 (见 canViewApplication)：申请人只能查看自己提交或发起重生的申请，
+// 看不到的申请与不存在的申请一样返回 "application not found"。
+func (s *queryService) GetApplication(id uuid.UUID, actor Actor) (*core.DefaultApplication, error) {
+	app, err := s.appRepo.GetDetailByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("application not found")
+		}
+		return nil, err
+	}
+	if !canViewApplication(app, actor) {
+		return nil, errors.New("application not found")
+	}
+	return app, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"testing"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestQueryService_GetApplication(t *testing.T) {
+	appID := uuid.New()
+	applicantID := uuid.New()
+	rebirthApplicantID := uuid.New()
+	app := &core.DefaultApplication{
+		BaseModel:          core.BaseModel{ID: appID},
+		ApplicantID:        applicantID,
+		RebirthApplicantID: &rebirthApplicantID,
+		Status:             core.StatusRebirthPending,
+	}
+
+	tests := []struct {
+		name    string
+		actor   Actor
+		visible bool
+	}{
+		{"approver sees every application", Actor{ID: uuid.New(), Role: core.RoleApprover}, true},
+		{"admin sees every application", Actor{ID: uuid.New(), Role: core.RoleAdmin}, true},
+		{"applicant sees own application", Actor{ID: applicantID, Role: core.RoleApplicant}, true},
+		{"rebirth applicant sees the application", Actor{ID: rebirthApplicantID, Role: core.RoleApplicant}, true},
+		{"other applicant cannot see it", Actor{ID: uuid.New(), Role: core.RoleApplicant}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAppRepo := new(mocks.ApplicationRepository)
+			queryService := NewQueryService(mockAppRepo)
+			mockAppRepo.On("GetDetailByID", appID).Return(app, nil).Once()
+
+			result, err := queryService.GetApplication(appID, tt.actor)
+
+			if tt.visible {
+				assert.NoError(t, err)
+				assert.Same(t, app, result)
+			} else {
+				assert.EqualError(t, err, "application not found")
+				assert.Nil(t, result)
+			}
+			mockAppRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("application does not exist", func(t *testing.T) {
+		mockAppRepo := new(mocks.ApplicationRepository)
+		queryService := NewQueryService(mockAppRepo)
+		mockAppRepo.On("GetDetailByID", appID).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := queryService.GetApplication(appID, Actor{ID: uuid.New(), Role: core.RoleApprover})
+
+		assert.EqualError(t, err, "application not found")
+		mockAppRepo.AssertExpectations(t)
+	})
+}
+
+func TestQueryService_FindApplications(t *testing.T) {
+	tests := []struct {
+		name          string
+		actor         Actor
+		scopedToActor bool
+	}{
+		{"approver sees every application", Actor{ID: uuid.New(), Role: core.RoleApprover}, false},
+		{"admin sees every application", Actor{ID: uuid.New(), Role: core.RoleAdmin}, false},
+		{"applicant only sees own applications", Actor{ID: uuid.New(), Role: core.RoleApplicant}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAppRepo := new(mocks.ApplicationRepository)
+			queryService := NewQueryService(mockAppRepo)
+			mockAppRepo.On("FindAll", mock.MatchedBy(func(params repository.QueryParams) bool {
+				if !tt.scopedToActor {
+					return params.VisibleTo == nil
+				}
+				return params.VisibleTo != nil && *params.VisibleTo == tt.actor.ID
+			})).Return([]core.DefaultApplication{}, int64(0), nil).Once()
+
+			// 客户端传入的 VisibleTo 会被忽略，可见范围只由操作者决定
+			otherID := uuid.New()
+			_, _, err := queryService.FindApplications(repository.QueryParams{VisibleTo: &otherID, Page: 1, PageSize: 10}, tt.actor)
+
+			assert.NoError(t, err)
+			mockAppRepo.AssertExpectations(t)
+		})
+	}
+}
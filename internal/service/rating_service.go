@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RatingService 定义了外部评级历史与评级映射表相关的业务操作接口。
+type RatingService interface {
+	// IngestRating 写入一条新的外部评级，并据此重新派生客户的 LatestExtGrade。
+	IngestRating(customerID uuid.UUID, agency, grade string, effectiveDate time.Time, source string) (*core.ExternalRating, error)
+	GetRatingHistory(customerID uuid.UUID) ([]core.ExternalRating, error)
+	ListScale() ([]core.RatingScaleEntry, error)
+	UpsertScaleEntry(agency, grade string, ordinal int, isDefault bool) (*core.RatingScaleEntry, error)
+	DeleteScaleEntry(id uuid.UUID) error
+}
+
+type ratingService struct {
+	ratingRepo   repository.RatingRepository
+	customerRepo repository.CustomerRepository
+	db           *gorm.DB // 用于写入评级与更新客户最新评级的事务
+}
+
+// NewRatingService 是 ratingService 的构造函数。
+func NewRatingService(db *gorm.DB, ratingRepo repository.RatingRepository, customerRepo repository.CustomerRepository) RatingService {
+	return &ratingService{db: db, ratingRepo: ratingRepo, customerRepo: customerRepo}
+}
+
+// IngestRating 写入外部评级。
+// 业务规则：评级必须在评级映射表中有定义，写入时会把映射项的序数和违约标志快照到记录上。
+// 写入后重新查询最新的一条记录 (而不是直接使用本次写入的评级)，
+// 这样补录一条较早生效的历史评级时不会错误地覆盖客户当前的评级。
+func (s *ratingService) IngestRating(customerID uuid.UUID, agency, grade string, effectiveDate time.Time, source string) (*core.ExternalRating, error) {
+	var rating *core.ExternalRating
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txRatingRepo := repository.NewRatingRepository(tx)
+		txCustomerRepo := repository.NewCustomerRepository(tx, repository.CustomerSearchOptions{})
+
+		customer, err := getCustomer(txCustomerRepo, customerID)
+		if err != nil {
+			return err
+		}
+
+		entry, err := txRatingRepo.GetScaleEntry(agency, grade)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("rating grade is not defined in the rating scale")
+			}
+			return err
+		}
+
+		rating = &core.ExternalRating{
+			CustomerID:     customer.ID,
+			Agency:         agency,
+			Grade:          grade,
+			EffectiveDate:  effectiveDate,
+			Source:         source,
+			Ordinal:        entry.Ordinal,
+			IsDefaultGrade: entry.IsDefault,
+		}
+		if err := txRatingRepo.Create(rating); err != nil {
+			return err
+		}
+
+		latest, err := txRatingRepo.FindLatestByCustomerID(customer.ID)
+		if err != nil {
+			return err
+		}
+		if latest != nil && latest.Grade != customer.LatestExtGrade {
+			customer.LatestExtGrade = latest.Grade
+			return txCustomerRepo.Update(customer, "LatestExtGrade")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rating, nil
+}
+
+// GetRatingHistory 查询客户的评级历史
+func (s *ratingService) GetRatingHistory(customerID uuid.UUID) ([]core.ExternalRating, error) {
+	if _, err := getCustomer(s.customerRepo, customerID); err != nil {
+		return nil, err
+	}
+	return s.ratingRepo.FindByCustomerID(customerID)
+}
+
+// ListScale 查询完整的评级映射表
+func (s *ratingService) ListScale() ([]core.RatingScaleEntry, error) {
+	return s.ratingRepo.FindScale()
+}
+
+// UpsertScaleEntry 新增或调整一个评级映射项。已有的评级历史保留写入时的快照，不受影响。
+func (s *ratingService) UpsertScaleEntry(agency, grade string, ordinal int, isDefault bool) (*core.RatingScaleEntry, error) {
+	entry := &core.RatingScaleEntry{
+		Agency:    agency,
+		Grade:     grade,
+		Ordinal:   ordinal,
+		IsDefault: isDefault,
+	}
+	if err := s.ratingRepo.UpsertScaleEntry(entry); err != nil {
+		return nil, err
+	}
+	// 发生冲突更新时，内存中的 ID 并不是数据库中的 ID，因此重新查询一次
+	return s.ratingRepo.GetScaleEntry(agency, grade)
+}
+
+// DeleteScaleEntry 删除一个评级映射项
+func (s *ratingService) DeleteScaleEntry(id uuid.UUID) error {
+	err := s.ratingRepo.DeleteScaleEntry(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("rating scale entry not found")
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRatingService_GetRatingHistory(t *testing.T) {
+	mockRatingRepo := new(mocks.RatingRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	ratingService := NewRatingService(nil, mockRatingRepo, mockCustomerRepo)
+	customerID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		ratings := []core.ExternalRating{{CustomerID: customerID, Agency: "Moody's", Grade: "Baa1"}}
+		mockCustomerRepo.On("GetByID", customerID).Return(&core.Customer{BaseModel: core.BaseModel{ID: customerID}}, nil).Once()
+		mockRatingRepo.On("FindByCustomerID", customerID).Return(ratings, nil).Once()
+
+		result, err := ratingService.GetRatingHistory(customerID)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		mockCustomerRepo.AssertExpectations(t)
+		mockRatingRepo.AssertExpectations(t)
+	})
+
+	t.Run("customer not found", func(t *testing.T) {
+		mockCustomerRepo.On("GetByID", customerID).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := ratingService.GetRatingHistory(customerID)
+
+		assert.EqualError(t, err, "customer not found")
+		mockCustomerRepo.AssertExpectations(t)
+	})
+}
+
+func TestRatingService_DeleteScaleEntry(t *testing.T) {
+	mockRatingRepo := new(mocks.RatingRepository)
+	ratingService := NewRatingService(nil, mockRatingRepo, new(mocks.CustomerRepository))
+	id := uuid.New()
+
+	mockRatingRepo.On("DeleteScaleEntry", id).Return(gorm.ErrRecordNotFound).Once()
+
+	err := ratingService.DeleteScaleEntry(id)
+
+	assert.EqualError(t, err, "rating scale entry not found")
+	mockRatingRepo.AssertExpectations(t)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUnknownReason 表示申请使用的原因编码不在原因目录中。
+	ErrUnknownReason = errors.New("reason code is not in the catalog")
+	// ErrInactiveReason 表示原因编码存在，但当前不在生效期间内。
+	ErrInactiveReason = errors.New("reason is not active")
+)
+
+// reasonKinds 是系统支持的原因目录类型
+var reasonKinds = map[core.ReasonKind]bool{
+	core.ReasonKindDefault: true,
+	core.ReasonKindRebirth: true,
+}
+
+// ReasonInput 是新增或修改原因条目时可以填写的内容。
+type ReasonInput struct {
+	LabelZh      string
+	LabelEn      string
+	ValidFrom    *time.Time
+	ValidTo      *time.Time
+	EvidenceHint string
+}
+
+// ReasonService 定义了违约原因、重生原因目录的维护接口。
+type ReasonService interface {
+	// ListReasons 查询某个目录的条目，activeOnly 为 true 时只返回当前生效的条目 (供申请人选择)。
+	ListReasons(kind string, activeOnly bool) ([]core.ReasonCatalogEntry, error)
+	CreateReason(kind, code string, input ReasonInput) (*core.ReasonCatalogEntry, error)
+	// UpdateReason 修改条目的名称、生效期间和证据提示。编码一旦建立不允许修改，以免历史申请和统计口径发生漂移。
+	UpdateReason(kind, code string, input ReasonInput) (*core.ReasonCatalogEntry, error)
+	DeleteReason(kind, code string) error
+}
+
+type reasonService struct {
+	reasonRepo repository.ReasonRepository
+}
+
+// NewReasonService 是 reasonService 的构造函数。
+func NewReasonService(reasonRepo repository.ReasonRepository) ReasonService {
+	return &reasonService{reasonRepo: reasonRepo}
+}
+
+// ListReasons 查询目录条目
+func (s *reasonService) ListReasons(kind string, activeOnly bool) ([]core.ReasonCatalogEntry, error) {
+	if !reasonKinds[core.ReasonKind(kind)] {
+		return nil, errors.New("unknown reason kind")
+	}
+	entries, err := s.reasonRepo.FindByKind(core.ReasonKind(kind))
+	if err != nil || !activeOnly {
+		return entries, err
+	}
+
+	now := time.Now()
+	active := make([]core.ReasonCatalogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if ReasonActive(&entry, now) {
+			active = append(active, entry)
+		}
+	}
+	return active, nil
+}
+
+// CreateReason 新增原因条目
+func (s *reasonService) CreateReason(kind, code string, input ReasonInput) (*core.ReasonCatalogEntry, error) {
+	if !reasonKinds[core.ReasonKind(kind)] {
+		return nil, errors.New("unknown reason kind")
+	}
+	if err := validateReasonPeriod(input); err != nil {
+		return nil, err
+	}
+
+	_, err := s.reasonRepo.GetByCode(core.ReasonKind(kind), code)
+	if err == nil {
+		return nil, errors.New("reason code already exists")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	entry := &core.ReasonCatalogEntry{Kind: core.ReasonKind(kind), Code: code}
+	applyReasonInput(entry, input)
+	if err := s.reasonRepo.Create(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// UpdateReason 修改原因条目
+func (s *reasonService) UpdateReason(kind, code string, input ReasonInput) (*core.ReasonCatalogEntry, error) {
+	entry, err := s.getReason(kind, code)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateReasonPeriod(input); err != nil {
+		return nil, err
+	}
+
+	if isBuiltinReason(entry) && !reasonActiveIndefinitely(input, time.Now()) {
+		return nil, errors.New("built-in reason cannot be retired")
+	}
+
+	applyReasonInput(entry, input)
+	if err := s.reasonRepo.Update(entry, "LabelZh", "LabelEn", "ValidFrom", "ValidTo", "EvidenceHint"); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// DeleteReason 删除原因条目。
+// 业务规则：已被申请引用的原因不能删除，只能通过设置 ValidTo 停用，否则历史申请和统计会失去原因名称。
+// 关联集团使用的内置原因既不能删除也不能停用。
+func (s *reasonService) DeleteReason(kind, code string) error {
+	entry, err := s.getReason(kind, code)
+	if err != nil {
+		return err
+	}
+	if isBuiltinReason(entry) {
+		return errors.New("built-in reason cannot be deleted")
+	}
+
+	count, err := s.reasonRepo.CountApplications(entry.Kind, entry.Code)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("reason is in use by applications")
+	}
+	return s.reasonRepo.Delete(entry)
+}
+
+// getReason 查询原因条目，并将 "记录不存在" 转换为业务错误
+func (s *reasonService) getReason(kind, code string) (*core.ReasonCatalogEntry, error) {
+	if !reasonKinds[core.ReasonKind(kind)] {
+		return nil, errors.New("unknown reason kind")
+	}
+	entry, err := s.reasonRepo.GetByCode(core.ReasonKind(kind), code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("reason not found")
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// validateReasonPeriod 校验生效期间：结束时间必须晚于开始时间
+func validateReasonPeriod(input ReasonInput) error {
+	if input.ValidFrom != nil && input.ValidTo != nil && !input.ValidTo.After(*input.ValidFrom) {
+		return errors.New("valid_to must be after valid_from")
+	}
+	return nil
+}
+
+// isBuiltinReason 判断条目是否为系统内置的原因 (见 builtinReasonCodes)
+func isBuiltinReason(entry *core.ReasonCatalogEntry) bool {
+	return builtinReasonCodes[entry.Kind] == entry.Code
+}
+
+// reasonActiveIndefinitely 判断按 input 设置生效期间后，原因是否从 at 起一直生效
+func reasonActiveIndefinitely(input ReasonInput, at time.Time) bool {
+	return input.ValidTo == nil && (input.ValidFrom == nil || !input.ValidFrom.After(at))
+}
+
+// applyReasonInput 把输入内容写入原因条目
+func applyReasonInput(entry *core.ReasonCatalogEntry, input ReasonInput) {
+	entry.LabelZh = input.LabelZh
+	entry.LabelEn = input.LabelEn
+	entry.ValidFrom = input.ValidFrom
+	entry.ValidTo = input.ValidTo
+	entry.EvidenceHint = input.EvidenceHint
+}
+
+// ReasonActive 判断原因在 at 时是否处于生效期间 (含开始时间，不含结束时间)
+func ReasonActive(entry *core.ReasonCatalogEntry, at time.Time) bool {
+	if entry.ValidFrom != nil && at.Before(*entry.ValidFrom) {
+		return false
+	}
+	return entry.ValidTo == nil || at.Before(*entry.ValidTo)
+}
+
+// resolveReason 校验申请使用的原因编码在目录中存在并且在 at 时生效，返回对应的条目。
+func resolveReason(reasonRepo repository.ReasonRepository, kind core.ReasonKind, code string, at time.Time) (*core.ReasonCatalogEntry, error) {
+	entry, err := reasonRepo.GetByCode(kind, code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s %w", kind, ErrUnknownReason)
+		}
+		return nil, err
+	}
+	if !ReasonActive(entry, at) {
+		return nil, fmt.Errorf("%s %w", kind, ErrInactiveReason)
+	}
+	return entry, nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"testing"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestReasonService_ListReasons(t *testing.T) {
+	mockReasonRepo := new(mocks.ReasonRepository)
+	reasonService := NewReasonService(mockReasonRepo)
+
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	mockReasonRepo.On("FindByKind", core.ReasonKindDefault).Return([]core.ReasonCatalogEntry{
+		{Code: "BANKRUPTCY"},
+		{Code: "PAYMENT_DEFAULT", ValidFrom: &past},
+		{Code: "RETIRED", ValidTo: &past},
+		{Code: "UPCOMING", ValidFrom: &future},
+	}, nil).Twice()
+
+	all, err := reasonService.ListReasons("default", false)
+	assert.NoError(t, err)
+	assert.Len(t, all, 4)
+
+	active, err := reasonService.ListReasons("default", true)
+	assert.NoError(t, err)
+	if assert.Len(t, active, 2) {
+		assert.Equal(t, "BANKRUPTCY", active[0].Code)
+		assert.Equal(t, "PAYMENT_DEFAULT", active[1].Code)
+	}
+
+	_, err = reasonService.ListReasons("industry", false)
+	assert.EqualError(t, err, "unknown reason kind")
+	mockReasonRepo.AssertExpectations(t)
+}
+
+func TestReasonService_DeleteReason(t *testing.T) {
+	mockReasonRepo := new(mocks.ReasonRepository)
+	reasonService := NewReasonService(mockReasonRepo)
+
+	t.Run("reasons in use can only be retired", func(t *testing.T) {
+		entry := &core.ReasonCatalogEntry{Kind: core.ReasonKindRebirth, Code: "NORMAL_SETTLEMENT"}
+		mockReasonRepo.On("GetByCode", core.ReasonKindRebirth, "NORMAL_SETTLEMENT").Return(entry, nil).Once()
+		mockReasonRepo.On("CountApplications", core.ReasonKindRebirth, "NORMAL_SETTLEMENT").Return(int64(3), nil).Once()
+
+		err := reasonService.DeleteReason("rebirth", "NORMAL_SETTLEMENT")
+
+		assert.EqualError(t, err, "reason is in use by applications")
+		mockReasonRepo.AssertExpectations(t)
+	})
+
+	t.Run("built-in reasons cannot be deleted", func(t *testing.T) {
+		entry := &core.ReasonCatalogEntry{Kind: core.ReasonKindDefault, Code: groupDefaultReasonCode}
+		mockReasonRepo.On("GetByCode", core.ReasonKindDefault, groupDefaultReasonCode).Return(entry, nil).Once()
+
+		err := reasonService.DeleteReason("default", groupDefaultReasonCode)
+
+		assert.EqualError(t, err, "built-in reason cannot be deleted")
+		mockReasonRepo.AssertNotCalled(t, "Delete", entry)
+		mockReasonRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockReasonRepo.On("GetByCode", core.ReasonKindDefault, "MISSING").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		err := reasonService.DeleteReason("default", "MISSING")
+
+		assert.EqualError(t, err, "reason not found")
+		mockReasonRepo.AssertExpectations(t)
+	})
+}
+
+func TestReasonService_UpdateReason(t *testing.T) {
+	mockReasonRepo := new(mocks.ReasonRepository)
+	reasonService := NewReasonService(mockReasonRepo)
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	t.Run("built-in reasons cannot be retired", func(t *testing.T) {
+		for _, input := range []ReasonInput{
+			{LabelZh: "集团成员已重生", ValidTo: &future},
+			{LabelZh: "集团成员已重生", ValidFrom: &future},
+		} {
+			entry := &core.ReasonCatalogEntry{Kind: core.ReasonKindRebirth, Code: groupRebirthReasonCode}
+			mockReasonRepo.On("GetByCode", core.ReasonKindRebirth, groupRebirthReasonCode).Return(entry, nil).Once()
+
+			_, err := reasonService.UpdateReason("rebirth", groupRebirthReasonCode, input)
+
+			assert.EqualError(t, err, "built-in reason cannot be retired")
+		}
+		mockReasonRepo.AssertExpectations(t)
+	})
+
+	t.Run("built-in reasons can be relabeled", func(t *testing.T) {
+		entry := &core.ReasonCatalogEntry{Kind: core.ReasonKindRebirth, Code: groupRebirthReasonCode}
+		mockReasonRepo.On("GetByCode", core.ReasonKindRebirth, groupRebirthReasonCode).Return(entry, nil).Once()
+		mockReasonRepo.On("Update", entry, "LabelZh", "LabelEn", "ValidFrom", "ValidTo", "EvidenceHint").Return(nil).Once()
+
+		updated, err := reasonService.UpdateReason("rebirth", groupRebirthReasonCode, ReasonInput{LabelZh: "集团成员已重生", ValidFrom: &past})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "集团成员已重生", updated.LabelZh)
+		mockReasonRepo.AssertExpectations(t)
+	})
+
+	t.Run("other reasons can be retired", func(t *testing.T) {
+		entry := &core.ReasonCatalogEntry{Kind: core.ReasonKindRebirth, Code: "NORMAL_SETTLEMENT"}
+		mockReasonRepo.On("GetByCode", core.ReasonKindRebirth, "NORMAL_SETTLEMENT").Return(entry, nil).Once()
+		mockReasonRepo.On("Update", entry, "LabelZh", "LabelEn", "ValidFrom", "ValidTo", "EvidenceHint").Return(nil).Once()
+
+		updated, err := reasonService.UpdateReason("rebirth", "NORMAL_SETTLEMENT", ReasonInput{LabelZh: "正常结算后解除", ValidTo: &future})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &future, updated.ValidTo)
+		mockReasonRepo.AssertExpectations(t)
+	})
+}
+
+func TestResolveReason(t *testing.T) {
+	mockReasonRepo := new(mocks.ReasonRepository)
+	at := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	validTo := at
+
+	mockReasonRepo.On("GetByCode", core.ReasonKindDefault, "PAYMENT_DEFAULT").Return(&core.ReasonCatalogEntry{Code: "PAYMENT_DEFAULT"}, nil).Once()
+	mockReasonRepo.On("GetByCode", core.ReasonKindDefault, "RETIRED").Return(&core.ReasonCatalogEntry{Code: "RETIRED", ValidTo: &validTo}, nil).Once()
+	mockReasonRepo.On("GetByCode", core.ReasonKindDefault, "MISSING").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	entry, err := resolveReason(mockReasonRepo, core.ReasonKindDefault, "PAYMENT_DEFAULT", at)
+	assert.NoError(t, err)
+	assert.Equal(t, "PAYMENT_DEFAULT", entry.Code)
+
+	// 生效期间不含结束时间
+	_, err = resolveReason(mockReasonRepo, core.ReasonKindDefault, "RETIRED", at)
+	assert.ErrorIs(t, err, ErrInactiveReason)
+
+	_, err = resolveReason(mockReasonRepo, core.ReasonKindDefault, "MISSING", at)
+	assert.ErrorIs(t, err, ErrUnknownReason)
+	assert.EqualError(t, err, "default reason code is not in the catalog")
+	mockReasonRepo.AssertExpectations(t)
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/repository"
+)
+
+// SLAPolicy 按严重等级规定审批人处理违约认定和重生的时限。
+type SLAPolicy struct {
+	hours map[string]int
+}
+
+// NewSLAPolicy 根据配置创建 SLA 策略，键与 ApprovalPolicy 一样不区分大小写。
+func NewSLAPolicy(hours map[string]int) SLAPolicy {
+	normalized := make(map[string]int, len(hours))
+	for severity, h := range hours {
+		normalized[strings.ToLower(severity)] = h
+	}
+	return SLAPolicy{hours: normalized}
+}
+
+// DueAt 返回从 start 开始计时的到期时间。该严重等级未配置时限 (或配置小于 1) 时返回 nil，即不设 SLA。
+func (p SLAPolicy) DueAt(severity string, start time.Time) *time.Time {
+	h := p.hours[strings.ToLower(severity)]
+	if h < 1 {
+		return nil
+	}
+	due := start.Add(time.Duration(h) * time.Hour)
+	return &due
+}
+
+// slaStageStart 返回申请当前审核环节的开始时间：
+// Pending 从本轮提交开始计时 (退回后重新提交重新计时)，RebirthPending 从发起重生开始计时。
+// 其他状态不计 SLA，返回 nil。
+func slaStageStart(app *core.DefaultApplication) *time.Time {
+	switch app.Status {
+	case core.StatusPending:
+		if app.RoundSubmittedAt != nil {
+			return app.RoundSubmittedAt
+		}
+		return &app.ApplicationTime
+	case core.StatusRebirthPending:
+		return app.RebirthAppliedAt
+	default:
+		return nil
+	}
+}
+
+// SLAState 返回申请当前审核环节的到期时间以及在 now 时是否已经超时。
+// 申请不在计时状态、未设 SLA 或调度器尚未为当前环节计算到期时间时，返回 (nil, false)。
+func SLAState(app *core.DefaultApplication, now time.Time) (*time.Time, bool) {
+	start := slaStageStart(app)
+	if start == nil || app.DueAt == nil || app.SLAStartedAt == nil || !app.SLAStartedAt.Equal(*start) {
+		return nil, false
+	}
+	return app.DueAt, now.After(*app.DueAt)
+}
+
+// SLAService 定义了 SLA 调度和超时升级相关的业务操作接口。
+type SLAService interface {
+	// Run 启动 SLA 调度器：立即检查一次，之后每隔 interval 检查一次，直到 ctx 结束。
+	Run(ctx context.Context, interval time.Duration)
+	// Check 为进入新环节的申请计算到期时间，并把已超时的申请升级给主管，返回本次升级的申请数量。
+	Check(now time.Time) (int, error)
+	// ListEscalations 查询全部升级记录 (从新到旧)。
+	ListEscalations() ([]core.EscalationEvent, error)
+}
+
+type slaService struct {
+	escalationRepo repository.EscalationRepository
+	policy         SLAPolicy
+}
+
+// NewSLAService 是 slaService 的构造函数。
+func NewSLAService(escalationRepo repository.EscalationRepository, policy SLAPolicy) SLAService {
+	return &slaService{escalationRepo: escalationRepo, policy: policy}
+}
+
+// Run 按固定间隔执行 Check，单次检查失败只记录日志，不会停止调度
+func (s *slaService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.Check(time.Now()); err != nil {
+			log.Printf("SLA 检查失败: %v", err)
+		} else if n > 0 {
+			log.Printf("SLA 检查：%d 个申请超时，已升级给 %s", n, core.RoleAdmin)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check 刷新到期时间并升级超时申请。
+// 业务规则：
+//  1. 申请进入新的审核环节 (SLAStartedAt 与环节开始时间不一致) 时，按严重等级重新计算到期时间并清空升级标记；
+//  2. 超过到期时间仍处于该环节的申请升级给管理员 (主管角色)，每个环节只升级一次。
+//
+// 单个申请处理失败不影响其他申请，全部错误合并后返回。
+func (s *slaService) Check(now time.Time) (int, error) {
+	apps, err := s.escalationRepo.FindSLAApplications()
+	if err != nil {
+		return 0, err
+	}
+
+	escalated := 0
+	var errs []error
+	for i := range apps {
+		app := &apps[i]
+		start := slaStageStart(app)
+		if start == nil {
+			continue
+		}
+		if app.SLAStartedAt == nil || !app.SLAStartedAt.Equal(*start) {
+			app.SLAStartedAt = start
+			app.DueAt = s.policy.DueAt(app.Severity, *start)
+			app.EscalatedAt = nil
+			if err := s.escalationRepo.UpdateDueDate(app); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		if app.DueAt == nil || app.EscalatedAt != nil || !now.After(*app.DueAt) {
+			continue
+		}
+
+		created, err := s.escalationRepo.RecordEscalation(&core.EscalationEvent{
+			ApplicationID:  app.ID,
+			Stage:          app.Status,
+			StageStartedAt: *app.SLAStartedAt,
+			DueAt:          *app.DueAt,
+			EscalatedAt:    now,
+			AssigneeID:     app.AssigneeID,
+			EscalatedTo:    core.RoleAdmin,
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if created {
+			escalated++
+		}
+	}
+	return escalated, errors.Join(errs...)
+}
+
+// ListEscalations 查询全部升级记录
+func (s *slaService) ListEscalations() ([]core.EscalationEvent, error) {
+	return s.escalationRepo.FindEscalations()
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"testing"
+	"time"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSLAPolicy_DueAt(t *testing.T) {
+	policy := NewSLAPolicy(map[string]int{"High": 24, "low": 0})
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, start.Add(24*time.Hour), *policy.DueAt("high", start))
+	assert.Nil(t, policy.DueAt("Low", start))
+	assert.Nil(t, policy.DueAt("Medium", start))
+}
+
+func TestSLAState(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	due := start.Add(24 * time.Hour)
+	app := &core.DefaultApplication{Status: core.StatusPending, ApplicationTime: start, SLAStartedAt: &start, DueAt: &due}
+
+	dueAt, overdue := SLAState(app, due.Add(time.Minute))
+	assert.Equal(t, &due, dueAt)
+	assert.True(t, overdue)
+
+	// 退回后重新提交进入新的一轮，调度器重新计算之前不再沿用上一轮的到期时间
+	resubmitted := start.Add(48 * time.Hour)
+	app.RoundSubmittedAt = &resubmitted
+	dueAt, overdue = SLAState(app, due.Add(time.Minute))
+	assert.Nil(t, dueAt)
+	assert.False(t, overdue)
+}
+
+func TestSLAService_Check(t *testing.T) {
+	mockRepo := new(mocks.EscalationRepository)
+	svc := NewSLAService(mockRepo, NewSLAPolicy(map[string]int{"High": 24}))
+
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	assigneeID := uuid.New()
+	oldStart := now.Add(-72 * time.Hour)
+	oldDue := oldStart.Add(24 * time.Hour)
+	rebirthAt := now.Add(-30 * time.Hour)
+
+	// 1. 新进入审核且未超时：只计算到期时间
+	fresh := core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusPending, Severity: "High", ApplicationTime: now.Add(-time.Hour)}
+	// 2. 已计算过到期时间且超时：升级
+	overdue := core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusPending, Severity: "High", ApplicationTime: oldStart,
+		SLAStartedAt: &oldStart, DueAt: &oldDue, AssigneeID: &assigneeID}
+	// 3. 已升级过：不重复升级
+	escalated := core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusPending, Severity: "High", ApplicationTime: oldStart,
+		SLAStartedAt: &oldStart, DueAt: &oldDue, EscalatedAt: &oldDue}
+	// 4. 重生环节：按发起重生的时间重新计算，已经超时，同一次检查中直接升级
+	rebirth := core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusRebirthPending, Severity: "High", ApplicationTime: oldStart,
+		RebirthAppliedAt: &rebirthAt, SLAStartedAt: &oldStart, DueAt: &oldDue, EscalatedAt: &oldDue}
+	// 5. 未配置 SLA 的等级
+	medium := core.DefaultApplication{BaseModel: core.BaseModel{ID: uuid.New()}, Status: core.StatusPending, Severity: "Medium", ApplicationTime: oldStart}
+
+	mockRepo.On("FindSLAApplications").Return([]core.DefaultApplication{fresh, overdue, escalated, rebirth, medium}, nil).Once()
+	mockRepo.On("UpdateDueDate", mock.MatchedBy(func(app *core.DefaultApplication) bool {
+		return app.ID == fresh.ID && app.DueAt.Equal(fresh.ApplicationTime.Add(24*time.Hour))
+	})).Return(nil).Once()
+	mockRepo.On("UpdateDueDate", mock.MatchedBy(func(app *core.DefaultApplication) bool {
+		return app.ID == rebirth.ID && app.SLAStartedAt.Equal(rebirthAt) && app.DueAt.Equal(rebirthAt.Add(24*time.Hour)) && app.EscalatedAt == nil
+	})).Return(nil).Once()
+	mockRepo.On("UpdateDueDate", mock.MatchedBy(func(app *core.DefaultApplication) bool {
+		return app.ID == medium.ID && app.DueAt == nil
+	})).Return(nil).Once()
+	mockRepo.On("RecordEscalation", mock.MatchedBy(func(e *core.EscalationEvent) bool {
+		return e.ApplicationID == overdue.ID && e.Stage == core.StatusPending && e.DueAt.Equal(oldDue) &&
+			*e.AssigneeID == assigneeID && e.EscalatedTo == core.RoleAdmin && e.EscalatedAt.Equal(now)
+	})).Return(true, nil).Once()
+	mockRepo.On("RecordEscalation", mock.MatchedBy(func(e *core.EscalationEvent) bool {
+		return e.ApplicationID == rebirth.ID && e.Stage == core.StatusRebirthPending && e.StageStartedAt.Equal(rebirthAt)
+	})).Return(false, nil).Once()
+
+	n, err := svc.Check(now)
+
+	assert.NoError(t, err)
+	// rebirth 已被另一个调度器实例升级 (RecordEscalation 返回 false)，不计入本次数量
+	assert.Equal(t, 1, n)
+	mockRepo.AssertExpectations(t)
+}
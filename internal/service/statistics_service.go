@@ -4,57 +4,76 @@ import (
 	"math"
 	"sort"
 	"xquant-default-management/internal/api"
+	"xquant-default-management/internal/core"
 	"xquant-default-management/internal/repository"
 )
 
 // StatisticsService 定义了统计相关的业务逻辑接口
 type StatisticsService interface {
 	// 返回一个包含完整计算结果的 DTO 列表
-	GetStatisticsByDimension(year int, dimension string, status string) ([]api.StatisticsResponse, error)
-	GetStatisticsByDimensionIncludeHistorical(year int, dimension string, status string) ([]api.StatisticsResponse, error)
+	// level 大于 0 时，按字典层级把维度编码向上汇总到该层级；为 0 时按客户记录的原始编码统计。
+	// currency 指定金额加权统计使用的币种。
+	GetStatisticsByDimension(year int, dimension string, status string, level int, currency string) ([]api.StatisticsResponse, error)
+	GetStatisticsByDimensionIncludeHistorical(year int, dimension string, status string, level int, currency string) ([]api.StatisticsResponse, error)
 }
 
 type statisticsService struct {
-	statsRepo repository.StatisticsRepository
+	statsRepo  repository.StatisticsRepository
+	dictRepo   repository.DictionaryRepository // 用于维度编码的层级汇总和名称展示
+	reasonRepo repository.ReasonRepository     // 按原因统计时用于原因名称展示
 }
 
-func NewStatisticsService(statsRepo repository.StatisticsRepository) StatisticsService {
-	return &statisticsService{statsRepo: statsRepo}
+func NewStatisticsService(statsRepo repository.StatisticsRepository, dictRepo repository.DictionaryRepository, reasonRepo repository.ReasonRepository) StatisticsService {
+	return &statisticsService{statsRepo: statsRepo, dictRepo: dictRepo, reasonRepo: reasonRepo}
 }
 
 // GetStatisticsByDimension 获取按维度统计的数据
-func (s *statisticsService) GetStatisticsByDimension(year int, dimension string, status string) ([]api.StatisticsResponse, error) {
-	return s.getStatisticsByDimensionWithOptions(year, dimension, status, false)
+func (s *statisticsService) GetStatisticsByDimension(year int, dimension string, status string, level int, currency string) ([]api.StatisticsResponse, error) {
+	return s.getStatisticsByDimensionWithOptions(year, dimension, status, level, currency, false)
 }
 
 // GetStatisticsByDimensionIncludeHistorical 获取按维度统计的数据，包含历史维度
-func (s *statisticsService) GetStatisticsByDimensionIncludeHistorical(year int, dimension string, status string) ([]api.StatisticsResponse, error) {
-	return s.getStatisticsByDimensionWithOptions(year, dimension, status, true)
+func (s *statisticsService) GetStatisticsByDimensionIncludeHistorical(year int, dimension string, status string, level int, currency string) ([]api.StatisticsResponse, error) {
+	return s.getStatisticsByDimensionWithOptions(year, dimension, status, level, currency, true)
 }
 
 // getStatisticsByDimensionWithOptions 是核心计算函数
-func (s *statisticsService) getStatisticsByDimensionWithOptions(year int, dimension string, status string, includeHistorical bool) ([]api.StatisticsResponse, error) {
+func (s *statisticsService) getStatisticsByDimensionWithOptions(year int, dimension string, status string, level int, currency string, includeHistorical bool) ([]api.StatisticsResponse, error) {
 	// 1. 获取当年的数据
-	currentYearStats, err := s.statsRepo.GetCountsByDimension(year, dimension, status)
+	currentYearStats, err := s.statsRepo.GetCountsByDimension(year, dimension, status, currency)
 	if err != nil {
 		return nil, err
 	}
 
 	// 2. 获取去年的数据，用于计算同比增长
-	previousYearStats, err := s.statsRepo.GetCountsByDimension(year-1, dimension, status)
+	previousYearStats, err := s.statsRepo.GetCountsByDimension(year-1, dimension, status, currency)
 	if err != nil {
 		return nil, err
 	}
 
+	// 2.1 加载维度字典，按需将编码汇总到指定层级
+	dictionary, err := s.loadDimensionDictionary(dimension, status)
+	if err != nil {
+		return nil, err
+	}
+	if level > 0 {
+		currentYearStats = rollUpStats(dictionary, currentYearStats, level)
+		previousYearStats = rollUpStats(dictionary, previousYearStats, level)
+	}
+
 	// 3. 构建数据映射
 	currentYearMap := make(map[string]int64)
+	currentAmountMap := make(map[string]float64)
 	for _, stat := range currentYearStats {
 		currentYearMap[stat.Dimension] = stat.Count
+		currentAmountMap[stat.Dimension] = stat.Amount
 	}
 
 	previousYearMap := make(map[string]int64)
+	previousAmountMap := make(map[string]float64)
 	for _, stat := range previousYearStats {
 		previousYearMap[stat.Dimension] = stat.Count
+		previousAmountMap[stat.Dimension] = stat.Amount
 	}
 
 	// 4. 确定要处理的维度集合
@@ -83,6 +102,10 @@ func (s *statisticsService) getStatisticsByDimensionWithOptions(year int, dimens
 	for _, count := range currentYearMap {
 		totalCount += count
 	}
+	var totalAmount float64
+	for _, amount := range currentAmountMap {
+		totalAmount += amount
+	}
 
 	// 6. 构建响应数据
 	var response []api.StatisticsResponse
@@ -112,11 +135,28 @@ func (s *statisticsService) getStatisticsByDimensionWithOptions(year int, dimens
 		}
 		// 如果 currentCount == 0 && previousCount == 0，growthRate 保持为 nil
 
+		// 金额加权的占比和同比，口径与数量一致
+		currentAmount := currentAmountMap[dim]
+		var amountPercentage float64
+		if totalAmount > 0 {
+			amountPercentage = math.Round(currentAmount/totalAmount*10000) / 10000
+		}
+		var amountGrowthRate *float64
+		if previousAmount := previousAmountMap[dim]; previousAmount > 0 {
+			rate := math.Round((currentAmount-previousAmount)/previousAmount*10000) / 10000
+			amountGrowthRate = &rate
+		}
+
 		response = append(response, api.StatisticsResponse{
-			Dimension:  dim,
-			Count:      currentCount,
-			Percentage: percentage,
-			GrowthRate: growthRate,
+			Dimension:        dim,
+			DimensionName:    dictionary[dim].Name, // 不在字典中的历史数据没有名称
+			Count:            currentCount,
+			Percentage:       percentage,
+			GrowthRate:       growthRate,
+			Currency:         currency,
+			Amount:           math.Round(currentAmount*100) / 100,
+			AmountPercentage: amountPercentage,
+			AmountGrowthRate: amountGrowthRate,
 		})
 	}
 
@@ -128,6 +168,56 @@ func (s *statisticsService) getStatisticsByDimensionWithOptions(year int, dimens
 	return response, nil
 }
 
+// loadDimensionDictionary 加载维度编码的字典。
+// 原因维度取自原因目录 (违约统计使用违约原因目录，重生统计使用重生原因目录)，目录没有层级，只提供名称；其他维度取自维度字典。
+func (s *statisticsService) loadDimensionDictionary(dimension, status string) (map[string]core.DictionaryEntry, error) {
+	if dimension == "reason" {
+		kind := core.ReasonKindDefault
+		if status == "Reborn" {
+			kind = core.ReasonKindRebirth
+		}
+		reasons, err := s.reasonRepo.FindByKind(kind)
+		if err != nil {
+			return nil, err
+		}
+		dictionary := make(map[string]core.DictionaryEntry, len(reasons))
+		for _, reason := range reasons {
+			dictionary[reason.Code] = core.DictionaryEntry{Code: reason.Code, Name: reason.LabelZh, Level: 1}
+		}
+		return dictionary, nil
+	}
+
+	entries, err := s.dictRepo.FindByKind(dimension)
+	if err != nil {
+		return nil, err
+	}
+	dictionary := make(map[string]core.DictionaryEntry, len(entries))
+	for _, entry := range entries {
+		dictionary[entry.Code] = entry
+	}
+	return dictionary, nil
+}
+
+// rollUpStats 把各编码的计数和金额汇总到其在指定层级上的祖先编码，保持原有的计数降序
+func rollUpStats(dictionary map[string]core.DictionaryEntry, stats []repository.StatResult, level int) []repository.StatResult {
+	index := make(map[string]int)
+	var merged []repository.StatResult
+	for _, stat := range stats {
+		code := rollUpCode(dictionary, stat.Dimension, level)
+		if i, ok := index[code]; ok {
+			merged[i].Count += stat.Count
+			merged[i].Amount += stat.Amount
+			continue
+		}
+		index[code] = len(merged)
+		merged = append(merged, repository.StatResult{Dimension: code, Count: stat.Count, Amount: stat.Amount})
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Count > merged[j].Count
+	})
+	return merged
+}
+
 // // GetStatisticsByDimension 是核心计算函数
 // func (s *statisticsService) GetStatisticsByDimension(year int, dimension string, status string) ([]api.StatisticsResponse, error) {
 // 	// 1. 获取当年的数据
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+	"xquant-default-management/internal/core"
+	"xquant-default-management/internal/mocks"
+	"xquant-default-management/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatisticsService_GetStatisticsByDimension(t *testing.T) {
+	mockStatsRepo := new(mocks.StatisticsRepository)
+	mockDictRepo := new(mocks.DictionaryRepository)
+	statsService := NewStatisticsService(mockStatsRepo, mockDictRepo, new(mocks.ReasonRepository))
+
+	mockStatsRepo.On("GetCountsByDimension", 2024, "industry", "Approved", "CNY").Return([]repository.StatResult{
+		{Dimension: "C391", Count: 2, Amount: 300},
+		{Dimension: "C392", Count: 1, Amount: 100},
+		{Dimension: "Tech", Count: 1, Amount: 0},
+	}, nil).Once()
+	mockStatsRepo.On("GetCountsByDimension", 2023, "industry", "Approved", "CNY").Return([]repository.StatResult{
+		{Dimension: "C391", Count: 1, Amount: 200},
+	}, nil).Once()
+	mockDictRepo.On("FindByKind", "industry").Return([]core.DictionaryEntry{
+		{Code: "C", Name: "制造业", Level: 1},
+		{Code: "C39", Name: "计算机、通信和其他电子设备制造业", ParentCode: "C", Level: 2},
+		{Code: "C391", ParentCode: "C39", Level: 3},
+		{Code: "C392", ParentCode: "C39", Level: 3},
+	}, nil).Once()
+
+	stats, err := statsService.GetStatisticsByDimension(2024, "industry", "Approved", 1, "CNY")
+
+	assert.NoError(t, err)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, "C", stats[0].Dimension)
+	assert.Equal(t, "制造业", stats[0].DimensionName)
+	assert.Equal(t, int64(3), stats[0].Count)
+	assert.Equal(t, 400.0, stats[0].Amount)
+	assert.Equal(t, 1.0, stats[0].AmountPercentage)
+	if assert.NotNil(t, stats[0].AmountGrowthRate) {
+		assert.Equal(t, 1.0, *stats[0].AmountGrowthRate)
+	}
+	assert.Equal(t, "Tech", stats[1].Dimension, "不在字典中的编码保持原样")
+	assert.Equal(t, 0.0, stats[1].AmountPercentage)
+	mockStatsRepo.AssertExpectations(t)
+	mockDictRepo.AssertExpectations(t)
+}
+
+func TestStatisticsService_GetStatisticsByReason(t *testing.T) {
+	mockStatsRepo := new(mocks.StatisticsRepository)
+	mockReasonRepo := new(mocks.ReasonRepository)
+	statsService := NewStatisticsService(mockStatsRepo, new(mocks.DictionaryRepository), mockReasonRepo)
+
+	mockStatsRepo.On("GetCountsByDimension", 2024, "reason", "Reborn", "CNY").Return([]repository.StatResult{
+		{Dimension: "NORMAL_SETTLEMENT", Count: 3},
+		{Dimension: "", Count: 1},
+	}, nil).Once()
+	mockStatsRepo.On("GetCountsByDimension", 2023, "reason", "Reborn", "CNY").Return(nil, nil).Once()
+	mockReasonRepo.On("FindByKind", core.ReasonKindRebirth).Return([]core.ReasonCatalogEntry{
+		{Code: "NORMAL_SETTLEMENT", LabelZh: "正常结算后解除"},
+	}, nil).Once()
+
+	stats, err := statsService.GetStatisticsByDimension(2024, "reason", "Reborn", 0, "CNY")
+
+	assert.NoError(t, err)
+	if assert.Len(t, stats, 2) {
+		assert.Equal(t, "NORMAL_SETTLEMENT", stats[0].Dimension)
+		assert.Equal(t, "正常结算后解除", stats[0].DimensionName)
+		assert.Equal(t, 0.75, stats[0].Percentage)
+		assert.Empty(t, stats[1].DimensionName, "目录引入之前无法回填编码的历史申请没有名称")
+	}
+	mockStatsRepo.AssertExpectations(t)
+	mockReasonRepo.AssertExpectations(t)
+}
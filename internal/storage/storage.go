@@ -0,0 +1,50 @@
+// package storage 封装了附件文件内容的存储。
+// 附件的元数据 (文件名、大小、校验和等) 保存在数据库中，文件内容通过 Storage 接口保存在可替换的后端：
+// 默认是本地文件系统，也可以配置为 S3 兼容的对象存储。
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"xquant-default-management/internal/config"
+)
+
+// ErrNotFound 表示指定的对象不存在。
+var ErrNotFound = errors.New("storage object not found")
+
+// Storage 定义了附件内容的存储后端。key 是以 "/" 分隔的相对路径，由调用方生成。
+type Storage interface {
+	// Put 写入一个对象，已存在的同名对象会被覆盖。
+	Put(key string, r io.Reader, size int64, contentType string) error
+	// Get 读取一个对象，调用方负责关闭返回的 ReadCloser。对象不存在时返回 ErrNotFound。
+	Get(key string) (io.ReadCloser, error)
+	// Delete 删除一个对象。对象不存在时不视为错误。
+	Delete(key string) error
+}
+
+// defaultLocalDir 未配置 ATTACHMENT_DIR 时本地存储使用的目录
+const defaultLocalDir = "./data/attachments"
+
+// New 根据配置创建存储后端。ATTACHMENT_STORAGE 为空或 "local" 时使用本地文件系统，"s3" 时使用 S3 兼容的对象存储。
+func New(cfg config.Config) (Storage, error) {
+	switch cfg.AttachmentStorage {
+	case "", "local":
+		dir := cfg.AttachmentDir
+		if dir == "" {
+			dir = defaultLocalDir
+		}
+		return NewLocalStorage(dir)
+	case "s3":
+		return NewS3Storage(S3Options{
+			Endpoint:     cfg.S3Endpoint,
+			Region:       cfg.S3Region,
+			Bucket:       cfg.S3Bucket,
+			AccessKey:    cfg.S3AccessKey,
+			SecretKey:    cfg.S3SecretKey,
+			UsePathStyle: cfg.S3UsePathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown attachment storage %q", cfg.AttachmentStorage)
+	}
+}
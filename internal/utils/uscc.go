@@ -0,0 +1,33 @@
+package utils
+
+import "strings"
+
+// usccCharset 是统一社会信用代码 (GB 32100-2015) 使用的 31 个字符，不含 I、O、Z、S、V。
+// 字符在其中的下标即为该字符代表的数值。
+const usccCharset = "0123456789ABCDEFGHJKLMNPQRTUWXY"
+
+// usccWeights 是前 17 位字符对应的加权因子。
+var usccWeights = [17]int{1, 3, 9, 27, 19, 26, 16, 17, 20, 29, 25, 13, 8, 24, 10, 30, 28}
+
+// NormalizeUSCC 去除首尾空白并转换为大写，便于用户输入小写字母时也能匹配。
+func NormalizeUSCC(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ValidateUSCC 校验 18 位统一社会信用代码的字符集和第 18 位校验码。
+// 校验码 = 31 - (前 17 位数值的加权和 mod 31)，结果为 31 时取 0。
+func ValidateUSCC(code string) bool {
+	if len(code) != 18 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		value := strings.IndexByte(usccCharset, code[i])
+		if value < 0 {
+			return false
+		}
+		sum += value * usccWeights[i]
+	}
+	check := (31 - sum%31) % 31
+	return code[17] == usccCharset[check]
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUSCC(t *testing.T) {
+	assert.True(t, ValidateUSCC("91350100M000100Y43"), "valid code should pass")
+	assert.True(t, ValidateUSCC("9144030071526726XG"), "valid code should pass")
+
+	assert.False(t, ValidateUSCC("91350100M000100Y44"), "wrong check character should fail")
+	assert.False(t, ValidateUSCC("91350100M000100Y4"), "code must have 18 characters")
+	assert.False(t, ValidateUSCC("91350100I000100Y43"), "letter I is not allowed")
+	assert.False(t, ValidateUSCC("91350100m000100Y43"), "lowercase must be normalized first")
+}
+
+func TestNormalizeUSCC(t *testing.T) {
+	assert.Equal(t, "91350100M000100Y43", NormalizeUSCC(" 91350100m000100y43 "))
+}